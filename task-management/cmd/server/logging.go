@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/requestid"
+)
+
+// Log output formats
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// newLogger creates a logger writing records at or above the given level
+// (debug, info, warn or error) to w, as key=value text or JSON lines
+func newLogger(w io.Writer, level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: must be debug, info, warn or error", level)
+	}
+	
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case logFormatText:
+		return slog.New(requestIDHandler{slog.NewTextHandler(w, opts)}), nil
+	case logFormatJSON:
+		return slog.New(requestIDHandler{slog.NewJSONHandler(w, opts)}), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be %s or %s", format, logFormatText, logFormatJSON)
+	}
+}
+
+// requestIDHandler adds the request ID of the context a record is logged with,
+// so every line logged while serving a request can be correlated
+type requestIDHandler struct {
+	slog.Handler
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
+
+// loggingMiddleware logs every request with its method, path, status,
+// duration and the user authenticated when it completed
+func loggingMiddleware(repo *memory.MemoryRepository) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			slog.DebugContext(r.Context(), "request started",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"remote_addr", r.RemoteAddr)
+			
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			
+			attrs := []any{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", recorder.status,
+				"duration", time.Since(start),
+			}
+			if user, err := repo.GetCurrentUser(context.Background()); err == nil && user != nil {
+				attrs = append(attrs, "user", string(*user))
+			}
+			
+			level := slog.LevelInfo
+			if recorder.status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			slog.Log(r.Context(), level, "request completed", attrs...)
+		})
+	}
+}
+
+// statusRecorder captures the status code written by a handler. It passes
+// Hijack through so WebSocket upgrades keep working.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -2,150 +2,489 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
 	"time"
 	
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/api/http/middleware"
 	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/events"
 	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+	"github.com/bhatti/sample-task-management/internal/notifications"
 	"github.com/bhatti/sample-task-management/internal/usecase"
 	"github.com/bhatti/sample-task-management/pkg/invariants"
 )
 
 func main() {
+	maxTasks := flag.Int("max-tasks", domain.MaxTasks, "maximum number of tasks in the system")
+	maxDependencyDepth := flag.Int("max-dependency-depth", 0, "maximum length of a dependency chain, in dependencies (0 means unlimited)")
+	countLiveTasks := flag.Bool("max-tasks-live", false, "apply -max-tasks to the tasks currently stored, so purged tasks free capacity, instead of every task ever created")
+	drainTimeout := flag.Duration("shutdown-timeout", 15*time.Second, "time to wait for in-flight requests on shutdown")
+	requestTimeout := flag.Duration("request-timeout", 30*time.Second, "maximum time to handle a request (0 disables)")
+	sessionDuration := flag.Duration("session-duration", usecase.DefaultSessionDuration, "session lifetime after login")
+	rememberMeDuration := flag.Duration("remember-me-duration", usecase.DefaultRememberMeDuration, "session lifetime of a \"remember me\" login")
+	detachDependents := flag.Bool("delete-detaches-dependents", false, "let DELETE /tasks/{id} archive a task others depend on, removing it from their dependencies and unblocking those left ready, instead of refusing")
+	requireFutureDueDate := flag.Bool("require-future-due-date", false, "reject due dates in the past")
+	requireDepsToComplete := flag.Bool("require-dependencies-to-complete", false, "reject completing a task, singly or in bulk, while a dependency is not completed")
+	defaultUsers := flag.Bool("default-users", true, "create the demo members alice, bob and charlie at startup, with the passwords alice-dev, bob-dev and charlie-dev; others register with POST /users")
+	defaultAdmin := flag.Bool("default-admin", false, "make the demo user alice an admin, for local development only: her password is public")
+	noAuth := flag.Bool("dev-no-auth", false, "log in as any user without a password, for local development only")
+	maxTitleLength := flag.Int("max-title-length", domain.DefaultMaxTitleLength, "maximum length of a task title, in characters")
+	maxDescriptionLength := flag.Int("max-description-length", domain.DefaultMaxDescriptionLength, "maximum length of a task description, in characters")
+	vocabularyFile := flag.String("vocabulary", "", "JSON file defining the allowed {\"priorities\": [lowest, ..., highest], \"tags\": [...]}; omitted keys keep the defaults")
+	disabledInvariants := flag.String("disable-invariants", "", "comma-separated safety invariants not to check, e.g. NoOrphanTasks during a migration (all are checked by default)")
+	invariantMode := flag.String("invariant-mode", middleware.InvariantModeFailOpen, "on an invariant violation after a request: fail-open logs it, fail-closed rolls the request back and returns 500")
+	retention := flag.Duration("retention", 0, "purge completed and cancelled tasks not updated for this long (0 disables)")
+	escalate := flag.Bool("escalate-stale", false, "raise the priority of idle pending tasks when dependencies are checked")
+	escalationAge := flag.Duration("escalation-age", usecase.DefaultEscalationAge, "how long a pending task may go without updates before it is escalated")
+	pendingWarningAge := flag.Duration("pending-warning-age", invariants.DefaultStalePendingThreshold, "warn about tasks pending longer than this (negative disables)")
+	inProgressWarningAge := flag.Duration("in-progress-warning-age", invariants.DefaultStuckInProgressThreshold, "warn about in-progress tasks without updates for longer than this (negative disables)")
+	livenessInterval := flag.Duration("liveness-stream-interval", handlers.DefaultLivenessStreamInterval, "how often GET /admin/liveness/stream checks for new or resolved liveness warnings")
+	sweepInterval := flag.Duration("retention-interval", time.Hour, "how often to apply the retention policy")
+	logLevel := flag.String("log-level", "info", "minimum level of log records: debug, info, warn or error")
+	logFormat := flag.String("log-format", logFormatText, "log output format: text (key=value) or json")
+	corsOrigins := flag.String("cors-origins", "", "comma-separated origins allowed to call the API from a browser (none by default)")
+	corsMethods := flag.String("cors-methods", "GET,POST,PUT,PATCH,DELETE", "comma-separated methods allowed for cross-origin requests")
+	corsHeaders := flag.String("cors-headers", "Content-Type,Authorization", "comma-separated request headers allowed for cross-origin requests")
+	flag.Parse()
+	
+	logger, err := newLogger(os.Stderr, *logLevel, *logFormat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	slog.SetDefault(logger)
+	
+	if *invariantMode != middleware.InvariantModeFailOpen && *invariantMode != middleware.InvariantModeFailClosed {
+		fatal("invalid -invariant-mode", "mode", *invariantMode, "allowed", []string{middleware.InvariantModeFailOpen, middleware.InvariantModeFailClosed})
+	}
+	if *retention > 0 && *sweepInterval <= 0 {
+		fatal("invalid -retention-interval", "interval", *sweepInterval, "error", "must be positive when -retention is set")
+	}
+	
+	disabled := splitList(*disabledInvariants)
+	for _, name := range disabled {
+		if !slices.Contains(invariants.SafetyInvariantNames(), name) {
+			fatal("invalid -disable-invariants", "invariant", name, "allowed", invariants.SafetyInvariantNames())
+		}
+	}
+	if len(disabled) > 0 {
+		slog.Warn("safety invariants disabled", "invariants", disabled)
+	}
+	if *noAuth {
+		slog.Warn("authentication disabled: anyone can log in as any user without a password")
+	}
+	if *defaultAdmin {
+		if !*defaultUsers {
+			fatal("invalid -default-admin", "error", "requires -default-users")
+		}
+		slog.Warn("demo user alice is an admin with the public password alice-dev")
+	}
+	
+	if *vocabularyFile != "" {
+		vocabulary, err := loadVocabulary(*vocabularyFile)
+		if err != nil {
+			fatal("invalid -vocabulary", "file", *vocabularyFile, "error", err)
+		}
+		if err := domain.SetVocabulary(vocabulary); err != nil {
+			fatal("invalid -vocabulary", "file", *vocabularyFile, "error", err)
+		}
+		slog.Info("vocabulary loaded", "priorities", vocabulary.Priorities, "tags", vocabulary.Tags)
+	}
+	
+	if err := domain.SetFieldLimits(domain.FieldLimits{
+		MaxTitleLength:       *maxTitleLength,
+		MaxDescriptionLength: *maxDescriptionLength,
+	}); err != nil {
+		fatal("invalid field length limits", "error", err)
+	}
+	
 	// Initialize repository and dependencies
 	repo := memory.NewMemoryRepository()
 	uow := memory.NewMemoryUnitOfWork(repo)
-	checker := invariants.NewInvariantChecker()
-	taskUseCase := usecase.NewTaskUseCase(uow, checker)
+	checker := invariants.NewInvariantCheckerWithConfig(invariants.Config{
+		DueSoonThreshold:         invariants.DefaultDueSoonThreshold,
+		StalePendingThreshold:    *pendingWarningAge,
+		StuckInProgressThreshold: *inProgressWarningAge,
+		DisabledInvariants:       disabled,
+	})
+	taskUseCase := usecase.NewTaskUseCaseWithConfig(uow, checker, usecase.Config{
+		MaxTasks:                      *maxTasks,
+		CountLiveTasks:                *countLiveTasks,
+		SessionDuration:               *sessionDuration,
+		RememberMeDuration:            *rememberMeDuration,
+		RequireFutureDueDate:          *requireFutureDueDate,
+		EscalationEnabled:             *escalate,
+		EscalationAge:                 *escalationAge,
+		MaxDependencyDepth:            *maxDependencyDepth,
+		NoAuth:                        *noAuth,
+		DetachDependentsOnDelete:      *detachDependents,
+		RequireDependenciesToComplete: *requireDepsToComplete,
+	})
+	broker := events.NewBroker()
+	taskUseCase.SetEventPublisher(events.Fanout{logEventPublisher{}, broker})
+	notificationStore := notifications.NewStore()
+	taskUseCase.SetNotificationService(notificationStore)
 	
 	// Initialize default users (for testing)
-	initializeDefaultUsers(repo)
+	if *defaultUsers {
+		initializeDefaultUsers(context.Background(), repo, *defaultAdmin)
+	}
+	
+	// Apply the retention policy in the background until shutdown
+	sweepCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	if *retention > 0 {
+		go runRetentionSweeper(sweepCtx, taskUseCase, *sweepInterval, *retention)
+	}
 	
 	// Create HTTP handlers
 	taskHandler := handlers.NewTaskHandler(taskUseCase)
+	eventHandler := handlers.NewEventHandler(broker)
+	notificationHandler := handlers.NewNotificationHandler(notificationStore)
+	invariantHandler := handlers.NewInvariantHandler(repo, checker)
+	invariantHandler.SetStreamInterval(*livenessInterval)
 	
 	// Setup routes
-	router := setupRoutes(taskHandler)
+	router := setupRoutes(taskHandler, eventHandler, notificationHandler, invariantHandler)
 	
 	// Add middleware
-	router.Use(loggingMiddleware)
-	router.Use(invariantCheckMiddleware(repo, checker))
+	router.Use(loggingMiddleware(repo))
+	router.Use(timeoutMiddleware(*requestTimeout))
+	router.Use(middleware.NewInvariantGuard(uow, checker, *invariantMode).Middleware)
 	
 	// Start server
 	port := ":8080"
-	log.Printf("Task Management Server starting on port %s", port)
-	log.Printf("TLA+ specification-compliant implementation")
-	log.Printf("All invariants will be checked at runtime")
+	slog.Info("task management server starting",
+		"port", port,
+		"invariant_mode", *invariantMode,
+		"log_level", *logLevel)
+	
+	// CORS wraps the router so preflight requests are answered before routing;
+	// request IDs are assigned before either, so every response carries one
+	cors := middleware.CORS(middleware.CORSConfig{
+		AllowedOrigins: splitList(*corsOrigins),
+		AllowedMethods: splitList(*corsMethods),
+		AllowedHeaders: splitList(*corsHeaders),
+		MaxAge:         10 * time.Minute,
+	})
+	
+	server := &http.Server{
+		Addr:    port,
+		Handler: middleware.RequestID(cors(router)),
+	}
+	// Shutdown does not wait for hijacked WebSocket connections; closing the
+	// broker ends their streams
+	server.RegisterOnShutdown(broker.Close)
+	
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
 	
-	if err := http.ListenAndServe(port, router); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	// Wait for a termination signal or a server failure
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			fatal("server failed to start", "error", err)
+		}
+		return
+	case sig := <-stop:
+		slog.Info("shutting down", "signal", sig.String(), "drain_timeout", *drainTimeout)
+	}
+	
+	ctx, cancel := context.WithTimeout(context.Background(), *drainTimeout)
+	defer cancel()
+	
+	stopSweeper()
+	if err := server.Shutdown(ctx); err != nil {
+		slog.Error("graceful shutdown failed, forcing close", "error", err)
+		server.Close()
+	}
+	slog.Info("server stopped")
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadVocabulary reads the priorities and tags from a JSON file; a key the
+// file leaves out keeps its default values
+func loadVocabulary(path string) (domain.Vocabulary, error) {
+	vocabulary := domain.DefaultVocabulary()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return vocabulary, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&vocabulary); err != nil {
+		return vocabulary, fmt.Errorf("failed to parse %s: %w", path, err)
 	}
+	return vocabulary, nil
+}
+
+// fatal logs an error and exits
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
 
-func setupRoutes(taskHandler *handlers.TaskHandler) *mux.Router {
+func setupRoutes(
+	taskHandler *handlers.TaskHandler,
+	eventHandler *handlers.EventHandler,
+	notificationHandler *handlers.NotificationHandler,
+	invariantHandler *handlers.InvariantHandler,
+) *mux.Router {
 	router := mux.NewRouter()
 	
 	// Authentication endpoints
 	router.HandleFunc("/auth/login", taskHandler.Login).Methods("POST")
 	router.HandleFunc("/auth/logout", taskHandler.Logout).Methods("POST")
+	router.HandleFunc("/auth/logout-all", taskHandler.LogoutAll).Methods("POST")
+	router.HandleFunc("/auth/me", taskHandler.Me).Methods("GET")
+	router.HandleFunc("/auth/refresh", taskHandler.RefreshSession).Methods("POST")
 	
 	// Task endpoints (maps to TLA+ actions)
 	router.HandleFunc("/tasks", taskHandler.CreateTask).Methods("POST")
+	router.HandleFunc("/tasks/validate", taskHandler.ValidateTask).Methods("POST")
+	router.HandleFunc("/tasks", taskHandler.ListTasks).Methods("GET")
+	router.HandleFunc("/tasks/due", taskHandler.GetTasksDueBetween).Methods("GET")
+	router.HandleFunc("/tasks/blocked", taskHandler.GetBlockedTasks).Methods("GET")
+	router.HandleFunc("/tasks/ordered", taskHandler.GetOrderedTasks).Methods("GET")
+	router.HandleFunc("/tasks/by-label", taskHandler.FindByLabel).Methods("GET")
+	router.HandleFunc("/tasks/export", taskHandler.ExportTasks).Methods("GET")
+	router.HandleFunc("/tasks/purge", taskHandler.PurgeOldTasks).Methods("POST")
+	router.HandleFunc("/tasks/dependencies/graph", taskHandler.GetDependencyGraph).Methods("GET")
 	router.HandleFunc("/tasks/{id}/status", taskHandler.UpdateTaskStatus).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/reopen", taskHandler.ReopenTask).Methods("POST")
+	router.HandleFunc("/tasks/{id}/snooze", taskHandler.SnoozeTask).Methods("POST")
 	router.HandleFunc("/tasks/{id}/priority", taskHandler.UpdateTaskPriority).Methods("PUT")
 	router.HandleFunc("/tasks/{id}/reassign", taskHandler.ReassignTask).Methods("PUT")
 	router.HandleFunc("/tasks/{id}/details", taskHandler.UpdateTaskDetails).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/cancel", taskHandler.CancelTask).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/time", taskHandler.LogTime).Methods("POST")
+	router.HandleFunc("/tasks/{id}/status-durations", taskHandler.GetStatusDurations).Methods("GET")
+	router.HandleFunc("/tasks/{id}/assignment-history", taskHandler.GetAssignmentHistory).Methods("GET")
+	router.HandleFunc("/tasks/{id}/labels", taskHandler.SetLabels).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/rank", taskHandler.SetRank).Methods("PUT")
+	router.HandleFunc("/tasks/{id}/move", taskHandler.MoveTask).Methods("POST")
+	router.HandleFunc("/tasks/{id}/dependencies/transitive", taskHandler.GetTransitiveDependencies).Methods("GET")
+	router.HandleFunc("/tasks/{id}/dependencies/{depId}", taskHandler.AddDependency).Methods("POST")
+	router.HandleFunc("/tasks/{id}/dependencies/{depId}", taskHandler.RemoveDependency).Methods("DELETE")
+	router.HandleFunc("/tasks/{id}/relations", taskHandler.GetTaskRelations).Methods("GET")
+	router.HandleFunc("/tasks/{id}/rollup", taskHandler.GetTaskRollup).Methods("GET")
+	router.HandleFunc("/tasks/{id}/restore", taskHandler.RestoreTask).Methods("PUT")
+	router.HandleFunc("/tasks/{id}", taskHandler.GetTask).Methods("GET")
+	router.HandleFunc("/tasks/{id}", taskHandler.PatchTask).Methods("PATCH")
 	router.HandleFunc("/tasks/{id}", taskHandler.DeleteTask).Methods("DELETE")
 	
+	// Comment endpoints
+	router.HandleFunc("/tasks/{id}/comments", taskHandler.AddComment).Methods("POST")
+	router.HandleFunc("/tasks/{id}/comments", taskHandler.ListComments).Methods("GET")
+	
+	// Attachment endpoints
+	router.HandleFunc("/tasks/{id}/attachments", taskHandler.AddAttachment).Methods("POST")
+	router.HandleFunc("/tasks/{id}/attachments", taskHandler.ListAttachments).Methods("GET")
+	
+	// Watcher endpoints
+	router.HandleFunc("/tasks/{id}/watchers", taskHandler.AddWatcher).Methods("POST")
+	router.HandleFunc("/tasks/{id}/watchers", taskHandler.RemoveWatcher).Methods("DELETE")
+	router.HandleFunc("/tasks/{id}/collaborators", taskHandler.AddCollaborators).Methods("POST")
+	
+	// User endpoints
+	router.HandleFunc("/users", taskHandler.ListUsers).Methods("GET")
+	router.HandleFunc("/users", taskHandler.RegisterUser).Methods("POST")
+	router.HandleFunc("/users/by-email", taskHandler.FindUserByEmail).Methods("GET")
+	router.HandleFunc("/users/suggest-assignee", taskHandler.SuggestAssignee).Methods("GET")
+	router.HandleFunc("/users/{id}/tasks", taskHandler.GetTasksByUser).Methods("GET")
+	router.HandleFunc("/users/{id}/tasks/overdue", taskHandler.GetOverdueTasksForUser).Methods("GET")
+	router.HandleFunc("/users/{id}/created-tasks", taskHandler.GetTasksByCreator).Methods("GET")
+	router.HandleFunc("/users/{id}/notifications", notificationHandler.List).Methods("GET")
+	
 	// Bulk operations
 	router.HandleFunc("/tasks/bulk-update", taskHandler.BulkUpdateStatus).Methods("POST")
+	router.HandleFunc("/tasks/bulk-validate", taskHandler.ValidateBulkTransition).Methods("POST")
+	router.HandleFunc("/tasks/bulk-reassign", taskHandler.BulkReassign).Methods("POST")
 	router.HandleFunc("/tasks/check-dependencies", taskHandler.CheckDependencies).Methods("POST")
 	
-	// Health check
+	// Real-time task events and the activity feed
+	router.HandleFunc("/ws", eventHandler.Stream).Methods("GET")
+	router.HandleFunc("/activity", taskHandler.GetActivity).Methods("GET")
+	
+	// Dashboard headline numbers
+	router.HandleFunc("/stats", taskHandler.GetStats).Methods("GET")
+	
+	// Maintenance
+	router.HandleFunc("/admin/repair-index", taskHandler.RepairIndex).Methods("POST")
+	router.HandleFunc("/admin/invariants", invariantHandler.Report).Methods("GET")
+	router.HandleFunc("/admin/liveness/stream", invariantHandler.LivenessStream).Methods("GET").Name(livenessStreamRoute)
+	router.HandleFunc("/admin/export", taskHandler.ExportState).Methods("GET")
+	router.HandleFunc("/admin/import", taskHandler.ImportState).Methods("POST")
+	
+	// Health checks
 	router.HandleFunc("/health", healthCheck).Methods("GET")
+	router.HandleFunc("/ready", taskHandler.Ready).Methods("GET")
+	
+	// Metrics
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
+	
+	// API description
+	router.HandleFunc("/openapi.json", taskHandler.OpenAPI).Methods("GET")
 	
 	return router
 }
 
-func initializeDefaultUsers(repo *memory.MemoryRepository) {
+func initializeDefaultUsers(ctx context.Context, repo *memory.MemoryRepository, admin bool) {
 	users := []domain.User{
 		{
 			ID:       "alice",
 			Name:     "Alice",
 			Email:    "alice@example.com",
+			Role:     domain.RoleMember,
 			JoinedAt: time.Now(),
 		},
 		{
 			ID:       "bob",
 			Name:     "Bob",
 			Email:    "bob@example.com",
+			Role:     domain.RoleMember,
 			JoinedAt: time.Now(),
 		},
 		{
 			ID:       "charlie",
 			Name:     "Charlie",
 			Email:    "charlie@example.com",
+			Role:     domain.RoleMember,
 			JoinedAt: time.Now(),
 		},
 	}
+	// The demo passwords are public, so alice is only an admin on request
+	if admin {
+		users[0].Role = domain.RoleAdmin
+	}
 	
 	for _, user := range users {
-		if err := repo.CreateUser(&user); err != nil {
-			log.Printf("Failed to create user %s: %v", user.ID, err)
+		// Each demo user's password is its ID followed by "-dev"
+		hash, err := domain.HashPassword(string(user.ID) + "-dev")
+		if err != nil {
+			slog.Error("failed to hash default user password", "user", user.ID, "error", err)
+			continue
+		}
+		user.PasswordHash = hash
+		if err := repo.CreateUser(ctx, &user); err != nil {
+			slog.Error("failed to create default user", "user", user.ID, "error", err)
 		} else {
-			log.Printf("Created default user: %s", user.ID)
+			slog.Debug("created default user", "user", user.ID)
+		}
+	}
+}
+
+// logEventPublisher delivers task events by logging them per recipient
+type logEventPublisher struct{}
+
+func (logEventPublisher) Publish(event domain.Event) {
+	for _, recipient := range event.Recipients {
+		attrs := []any{
+			"recipient", recipient,
+			"event", event.Type,
+			"task_id", event.TaskID,
+			"data", event.Data,
+		}
+		if event.RequestID != "" {
+			attrs = append(attrs, "request_id", event.RequestID)
+		}
+		slog.Info("notify", attrs...)
+	}
+}
+
+// runRetentionSweeper purges old completed and cancelled tasks every interval
+// until ctx is cancelled
+func runRetentionSweeper(ctx context.Context, uc *usecase.TaskUseCase, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := uc.SweepOldTasks(ctx, retention)
+			if err != nil {
+				slog.Error("retention sweep failed", "error", err)
+				continue
+			}
+			if purged > 0 {
+				slog.Info("retention sweep purged tasks", "purged", purged, "older_than", retention)
+			}
 		}
 	}
 }
 
+// healthCheck is the liveness probe: it only reports that the server is up,
+// see TaskHandler.Ready for the readiness probe
 func healthCheck(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintf(w, `{"status":"healthy","message":"TLA+ compliant task management system"}`)
 }
 
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-		
-		// Log request
-		log.Printf("[%s] %s %s", r.Method, r.RequestURI, r.RemoteAddr)
-		
-		// Call next handler
-		next.ServeHTTP(w, r)
-		
-		// Log response time
-		log.Printf("Request completed in %v", time.Since(start))
-	})
-}
+// livenessStreamRoute names the server-sent event route exempt from the
+// request timeout
+const livenessStreamRoute = "liveness-stream"
 
-func invariantCheckMiddleware(repo *memory.MemoryRepository, checker *invariants.InvariantChecker) mux.MiddlewareFunc {
+// timeoutMiddleware cancels the request context after the given duration so
+// use cases and repositories stop working on requests that took too long.
+// WebSocket connections and the liveness event stream are long-lived and are
+// not subject to the timeout.
+func timeoutMiddleware(timeout time.Duration) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Call next handler
-			next.ServeHTTP(w, r)
-			
-			// Check invariants after each request
-			state, err := repo.GetSystemState()
-			if err != nil {
-				log.Printf("Failed to get system state: %v", err)
+			if websocket.IsWebSocketUpgrade(r) || isRoute(r, livenessStreamRoute) {
+				next.ServeHTTP(w, r)
 				return
 			}
 			
-			if err := checker.CheckAllInvariants(state); err != nil {
-				log.Printf("INVARIANT VIOLATION DETECTED: %v", err)
-				// In production, you might want to trigger alerts here
-			}
-			
-			// Check liveness properties for monitoring
-			warnings := checker.CheckLivenessProperties(state)
-			for _, warning := range warnings {
-				log.Printf("LIVENESS WARNING: %s", warning)
-			}
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// isRoute reports whether the request matched the named route
+func isRoute(r *http.Request, name string) bool {
+	route := mux.CurrentRoute(r)
+	return route != nil && route.GetName() == name
+}
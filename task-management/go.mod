@@ -4,7 +4,9 @@ go 1.21
 
 require (
 	github.com/gorilla/mux v1.8.1
+	github.com/gorilla/websocket v1.5.3
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/crypto v0.33.0
 )
 
 require (
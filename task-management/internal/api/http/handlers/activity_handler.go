@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// ActivityResponse is one page of the activity feed
+type ActivityResponse struct {
+	Activities []*domain.Activity `json:"activities"`
+	// Total is the number of entries matching the filter
+	Total int `json:"total"`
+	// NextBefore is the before parameter for the next page; it is omitted on
+	// the last page
+	NextBefore domain.ActivityID `json:"next_before,omitempty"`
+}
+
+// GetActivity handles GET /activity, the activity feed newest first,
+// filtered by ?user=, ?task= and ?action= and paged with ?limit= and either
+// ?offset= or ?before=
+func (h *TaskHandler) GetActivity(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.ActivityFilter{
+		Actor:  domain.UserID(query.Get("user")),
+		Action: domain.EventType(query.Get("action")),
+	}
+
+	var params [4]int
+	for i, name := range []string{"task", "before", "offset", "limit"} {
+		value, err := intParam(r, name)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid query parameter", err.Error())
+			return
+		}
+		params[i] = value
+	}
+	filter.TaskID = domain.TaskID(params[0])
+	filter.Before = domain.ActivityID(params[1])
+	offset, limit := params[2], params[3]
+
+	activities, total, err := h.taskUseCase.GetActivity(r.Context(), filter, offset, limit)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to get activity", err)
+		return
+	}
+
+	response := ActivityResponse{Activities: activities, Total: total}
+	if len(activities) > 0 && offset+len(activities) < total {
+		response.NextBefore = activities[len(activities)-1].ID
+	}
+	h.sendJSON(w, http.StatusOK, response)
+}
+
+// intParam returns the integer query parameter of the given name, or 0 if it
+// is absent
+func intParam(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer: %q", name, value)
+	}
+	return n, nil
+}
+
+// AssignmentHistoryResponse lists the reassignments of a task, oldest first
+type AssignmentHistoryResponse struct {
+	TaskID        domain.TaskID             `json:"task_id"`
+	Reassignments []domain.AssignmentChange `json:"reassignments"`
+}
+
+// GetAssignmentHistory handles GET /tasks/{id}/assignment-history
+func (h *TaskHandler) GetAssignmentHistory(w http.ResponseWriter, r *http.Request) {
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	history, err := h.taskUseCase.GetAssignmentHistory(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusNotFound, "Failed to get assignment history", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, AssignmentHistoryResponse{
+		TaskID:        domain.TaskID(taskID),
+		Reassignments: history,
+	})
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// AddAttachmentRequest represents the metadata of a file attached to a task
+type AddAttachmentRequest struct {
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+	Size     int64  `json:"size"`
+}
+
+// AddAttachment handles POST /tasks/{id}/attachments
+func (h *TaskHandler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	var req AddAttachmentRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+
+	attachment, err := h.taskUseCase.AddAttachment(r.Context(), domain.TaskID(taskID), req.Filename, req.URL, req.Size)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to add attachment", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, attachment)
+}
+
+// ListAttachments handles GET /tasks/{id}/attachments
+func (h *TaskHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	attachments, err := h.taskUseCase.ListAttachments(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusNotFound, "Failed to list attachments", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, attachments)
+}
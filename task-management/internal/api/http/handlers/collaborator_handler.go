@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// CollaboratorsRequest represents the request body for sharing a task
+type CollaboratorsRequest struct {
+	UserIDs []domain.UserID `json:"user_ids"`
+}
+
+// AddCollaborators handles POST /tasks/{id}/collaborators
+func (h *TaskHandler) AddCollaborators(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	var req CollaboratorsRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+
+	task, err := h.taskUseCase.AddCollaborators(r.Context(), domain.TaskID(taskID), req.UserIDs)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to add collaborators", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"task_id":       task.ID,
+		"collaborators": task.Collaborators,
+	})
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// AddCommentRequest represents the request body for commenting on a task
+type AddCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// AddComment handles POST /tasks/{id}/comments
+func (h *TaskHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	var req AddCommentRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+
+	comment, err := h.taskUseCase.AddComment(r.Context(), domain.TaskID(taskID), req.Body)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to add comment", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, comment)
+}
+
+// ListComments handles GET /tasks/{id}/comments
+func (h *TaskHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	comments, err := h.taskUseCase.ListComments(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusNotFound, "Failed to list comments", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, comments)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// AddDependency handles POST /tasks/{id}/dependencies/{depId}
+func (h *TaskHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	h.updateDependencies(w, r, true)
+}
+
+// RemoveDependency handles DELETE /tasks/{id}/dependencies/{depId}
+func (h *TaskHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	h.updateDependencies(w, r, false)
+}
+
+func (h *TaskHandler) updateDependencies(w http.ResponseWriter, r *http.Request, add bool) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+	depID, err := strconv.Atoi(vars["depId"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid dependency ID", err.Error())
+		return
+	}
+
+	var task *domain.Task
+	if add {
+		task, err = h.taskUseCase.AddDependency(r.Context(), domain.TaskID(taskID), domain.TaskID(depID))
+	} else {
+		task, err = h.taskUseCase.RemoveDependency(r.Context(), domain.TaskID(taskID), domain.TaskID(depID))
+	}
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to update dependencies", err)
+		return
+	}
+
+	h.sendTask(w, r, http.StatusOK, task)
+}
+
+// TaskRelationsResponse lists a task's dependencies in both directions
+type TaskRelationsResponse struct {
+	TaskID    domain.TaskID   `json:"task_id"`
+	BlockedBy []domain.TaskID `json:"blocked_by"`
+	Blocks    []domain.TaskID `json:"blocks"`
+}
+
+// TransitiveDependenciesResponse lists every task a task depends on, directly
+// or indirectly
+type TransitiveDependenciesResponse struct {
+	TaskID       domain.TaskID   `json:"task_id"`
+	Dependencies []domain.TaskID `json:"dependencies"`
+}
+
+// GetTransitiveDependencies handles GET /tasks/{id}/dependencies/transitive
+func (h *TaskHandler) GetTransitiveDependencies(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	dependencies, err := h.taskUseCase.GetTransitiveDependencies(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusNotFound, "Failed to get transitive dependencies", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, TransitiveDependenciesResponse{
+		TaskID:       domain.TaskID(taskID),
+		Dependencies: dependencies,
+	})
+}
+
+// GetTaskRelations handles GET /tasks/{id}/relations
+func (h *TaskHandler) GetTaskRelations(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	relations, err := h.taskUseCase.GetTaskRelations(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusNotFound, "Failed to get task relations", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, TaskRelationsResponse{
+		TaskID:    domain.TaskID(taskID),
+		BlockedBy: relations.BlockedBy,
+		Blocks:    relations.Blocks,
+	})
+}
+
+// BlockedTaskDetailResponse is a blocked task with the dependencies it waits on
+type BlockedTaskDetailResponse struct {
+	TaskResponse
+	WaitingOn []DependencyStatusResponse `json:"waiting_on"`
+}
+
+// DependencyStatusResponse is the current status and assignee of one dependency
+type DependencyStatusResponse struct {
+	TaskID   domain.TaskID     `json:"task_id"`
+	Status   domain.TaskStatus `json:"status"`
+	Assignee domain.UserID     `json:"assignee"`
+}
+
+// GetBlockedTasks handles GET /tasks/blocked
+func (h *TaskHandler) GetBlockedTasks(w http.ResponseWriter, r *http.Request) {
+	reasons, err := h.taskUseCase.GetBlockedTasks(r.Context())
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to get blocked tasks", err)
+		return
+	}
+
+	tasks := make([]*domain.Task, len(reasons))
+	for i, reason := range reasons {
+		tasks[i] = reason.Task
+	}
+	responses, err := h.taskResponses(r.Context(), tasks)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to build task response", err)
+		return
+	}
+
+	blocked := make([]BlockedTaskDetailResponse, len(reasons))
+	for i, reason := range reasons {
+		blocked[i] = BlockedTaskDetailResponse{
+			TaskResponse: responses[i],
+			WaitingOn:    make([]DependencyStatusResponse, len(reason.WaitingOn)),
+		}
+		for j, dep := range reason.WaitingOn {
+			blocked[i].WaitingOn[j] = DependencyStatusResponse{TaskID: dep.TaskID, Status: dep.Status, Assignee: dep.Assignee}
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, blocked)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// errorMapping associates a domain error with an HTTP status and a machine-readable code
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorMappings is checked in order; the first match wins
+var errorMappings = []errorMapping{
+	{context.DeadlineExceeded, http.StatusServiceUnavailable, "timeout"},
+	{context.Canceled, http.StatusServiceUnavailable, "canceled"},
+	{domain.ErrUnauthenticated, http.StatusUnauthorized, "unauthenticated"},
+	{domain.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials"},
+	{domain.ErrTaskNotFound, http.StatusNotFound, "task_not_found"},
+	{domain.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{domain.ErrDuplicateEmail, http.StatusConflict, "duplicate_email"},
+	{domain.ErrDuplicateUser, http.StatusConflict, "duplicate_user"},
+	{domain.ErrTitleEmpty, http.StatusBadRequest, "title_empty"},
+	{domain.ErrDescriptionEmpty, http.StatusBadRequest, "description_empty"},
+	{domain.ErrTitleTooLong, http.StatusBadRequest, "title_too_long"},
+	{domain.ErrDescriptionTooLong, http.StatusBadRequest, "description_too_long"},
+	{domain.ErrInvalidStatus, http.StatusBadRequest, "invalid_status"},
+	{domain.ErrInvalidPriority, http.StatusBadRequest, "invalid_priority"},
+	{domain.ErrAssigneeRequired, http.StatusBadRequest, "assignee_required"},
+	{domain.ErrCreatorRequired, http.StatusBadRequest, "creator_required"},
+	{domain.ErrInvalidTimestamps, http.StatusBadRequest, "invalid_timestamps"},
+	{domain.ErrInvalidTag, http.StatusBadRequest, "invalid_tag"},
+	{domain.ErrInvalidLabel, http.StatusBadRequest, "invalid_label"},
+	{domain.ErrInvalidRank, http.StatusBadRequest, "invalid_rank"},
+	{domain.ErrNegativeHours, http.StatusBadRequest, "negative_hours"},
+	{domain.ErrInvalidRecurrence, http.StatusBadRequest, "invalid_recurrence"},
+	{domain.ErrDueDateInPast, http.StatusBadRequest, "due_date_in_past"},
+	{domain.ErrSnoozeInPast, http.StatusBadRequest, "snooze_in_past"},
+	{domain.ErrInvalidSortKey, http.StatusBadRequest, "invalid_sort_key"},
+	{domain.ErrReasonRequired, http.StatusBadRequest, "reason_required"},
+	{domain.ErrInvalidPage, http.StatusBadRequest, "invalid_page"},
+	{domain.ErrInvalidDependency, http.StatusBadRequest, "invalid_dependency"},
+	{domain.ErrInvalidParent, http.StatusBadRequest, "invalid_parent"},
+	{domain.ErrCyclicDependency, http.StatusConflict, "cyclic_dependency"},
+	{domain.ErrDependencyTooDeep, http.StatusConflict, "dependency_too_deep"},
+	{domain.ErrInvalidTransition, http.StatusConflict, "invalid_transition"},
+	{domain.ErrMaxTasksReached, http.StatusConflict, "max_tasks_reached"},
+	{domain.ErrInvariantViolation, http.StatusConflict, "invariant_violation"},
+	{domain.ErrReadOnly, http.StatusServiceUnavailable, "read_only"},
+}
+
+// classifyError returns the HTTP status and code for a use case error,
+// falling back to the given status and an empty code for unknown errors
+func classifyError(err error, fallback int) (int, string) {
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return m.status, m.code
+		}
+	}
+	return fallback, ""
+}
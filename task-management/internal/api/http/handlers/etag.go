@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// sendTaskWithETag writes a task like sendTask, tagged with a strong ETag
+// over its JSON representation. The hash covers computed fields such as
+// dependency_progress, so the tag changes whenever the response would. When
+// If-None-Match already holds the tag, only 304 Not Modified is sent.
+func (h *TaskHandler) sendTaskWithETag(w http.ResponseWriter, r *http.Request, task *domain.Task) {
+	responses, err := h.taskResponses(r.Context(), []*domain.Task{task})
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to build task response", err)
+		return
+	}
+	body, err := json.Marshal(responses[0])
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, "Failed to encode task", err.Error())
+		return
+	}
+
+	etag := computeETag(body)
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(body, '\n'))
+}
+
+// computeETag returns a quoted strong entity tag for a response body
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header lists the tag or is
+// "*". The comparison is weak, as RFC 9110 prescribes for If-None-Match, so a
+// W/ prefix is ignored.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/events"
+)
+
+const (
+	// eventBufferSize is the number of events queued per client before
+	// further events are dropped for that client
+	eventBufferSize = 64
+
+	// eventWriteTimeout bounds how long a slow client can stall a write
+	eventWriteTimeout = 10 * time.Second
+)
+
+// EventHandler streams task events to WebSocket clients
+type EventHandler struct {
+	broker   *events.Broker
+	upgrader websocket.Upgrader
+}
+
+// NewEventHandler creates a new event stream handler
+func NewEventHandler(broker *events.Broker) *EventHandler {
+	return &EventHandler{broker: broker}
+}
+
+// Stream handles GET /ws?assignee=. Each task event is sent as a JSON text
+// message; with assignee set only events for tasks assigned to that user
+// (including tasks reassigned away from them) are sent.
+// The subscription ends when the client disconnects or the broker is closed.
+func (h *EventHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	assignee := domain.UserID(r.URL.Query().Get("assignee"))
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied with an HTTP error
+		slog.Warn("websocket upgrade failed", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	stream, unsubscribe := h.broker.Subscribe(eventBufferSize)
+	defer unsubscribe()
+
+	// Clients only listen; reading detects the disconnect (and handles control frames)
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-disconnected:
+			return
+		case event, ok := <-stream:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+					time.Now().Add(eventWriteTimeout))
+				return
+			}
+			if assignee != "" && !concernsAssignee(event, assignee) {
+				continue
+			}
+			conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// concernsAssignee reports whether the event is about a task assigned to the
+// user, before or after a reassignment
+func concernsAssignee(event domain.Event, assignee domain.UserID) bool {
+	if event.Assignee == assignee {
+		return true
+	}
+	return event.Type == domain.EventTaskReassigned && domain.UserID(event.Data["from"]) == assignee
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// graphvizContentType is the media type for DOT output
+const graphvizContentType = "text/vnd.graphviz"
+
+// DependencyGraphResponse represents the dependency graph of all tasks
+type DependencyGraphResponse struct {
+	Graph   map[domain.TaskID][]domain.TaskID `json:"graph"`
+	Acyclic bool                              `json:"acyclic"`
+}
+
+// GetDependencyGraph handles GET /tasks/dependencies/graph.
+// Edges point from a task to the tasks it depends on. Sending
+// "Accept: text/vnd.graphviz" returns the graph in DOT format.
+func (h *TaskHandler) GetDependencyGraph(w http.ResponseWriter, r *http.Request) {
+	graph, err := h.taskUseCase.GetDependencyGraph(r.Context())
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to get dependency graph", err)
+		return
+	}
+	acyclic := h.taskUseCase.CheckDependencyGraph(r.Context()) == nil
+
+	if strings.Contains(r.Header.Get("Accept"), graphvizContentType) {
+		w.Header().Set("Content-Type", graphvizContentType)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, formatDOT(graph, acyclic))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, DependencyGraphResponse{
+		Graph:   graph,
+		Acyclic: acyclic,
+	})
+}
+
+// GetOrderedTasks handles GET /tasks/ordered, listing tasks so that every
+// task follows the tasks it depends on; a dependency cycle yields 409
+func (h *TaskHandler) GetOrderedTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := h.taskUseCase.TopologicalOrder(r.Context())
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to order tasks", err)
+		return
+	}
+
+	h.sendTasks(w, r, tasks)
+}
+
+func formatDOT(graph map[domain.TaskID][]domain.TaskID, acyclic bool) string {
+	ids := make([]domain.TaskID, 0, len(graph))
+	for id := range graph {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// acyclic: %t\n", acyclic)
+	b.WriteString("digraph dependencies {\n")
+	for _, id := range ids {
+		fmt.Fprintf(&b, "  \"%d\";\n", id)
+		for _, depID := range graph[id] {
+			fmt.Fprintf(&b, "  \"%d\" -> \"%d\";\n", id, depID)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
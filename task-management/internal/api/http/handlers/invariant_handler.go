@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/repository"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// DefaultLivenessStreamInterval is how often the liveness stream checks the
+// state unless SetStreamInterval changes it
+const DefaultLivenessStreamInterval = 10 * time.Second
+
+// InvariantHandler exposes the runtime TLA+ invariant checks over HTTP
+type InvariantHandler struct {
+	states         repository.SystemStateRepository
+	checker        *invariants.InvariantChecker
+	streamInterval time.Duration
+}
+
+// NewInvariantHandler creates a new invariant report handler
+func NewInvariantHandler(states repository.SystemStateRepository, checker *invariants.InvariantChecker) *InvariantHandler {
+	return &InvariantHandler{states: states, checker: checker, streamInterval: DefaultLivenessStreamInterval}
+}
+
+// SetStreamInterval sets how often LivenessStream checks the state; a
+// non-positive interval keeps the current one
+func (h *InvariantHandler) SetStreamInterval(interval time.Duration) {
+	if interval > 0 {
+		h.streamInterval = interval
+	}
+}
+
+// Report handles GET /admin/invariants, listing each safety invariant as
+// passed or failed together with the current liveness warnings
+func (h *InvariantHandler) Report(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	state, err := h.states.GetSystemState(r.Context())
+	if err != nil {
+		status, code := classifyError(err, http.StatusInternalServerError)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(ErrorResponse{
+			Error:   "Failed to get system state",
+			Code:    code,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.checker.Report(state))
+}
+
+// warningKey identifies a liveness warning across checks. A task's warning
+// stays the same while its message only updates an age; system-wide
+// warnings carry their state in the message, such as the number of critical
+// tasks pending, so a changed message makes a new warning.
+type warningKey struct {
+	kind    string
+	taskID  domain.TaskID
+	message string
+}
+
+func keyOf(warning invariants.LivenessWarning) warningKey {
+	key := warningKey{kind: warning.Kind, taskID: warning.TaskID}
+	if warning.TaskID == 0 {
+		key.message = warning.Message
+	}
+	return key
+}
+
+// LivenessStream handles GET /admin/liveness/stream, a server-sent events
+// stream of liveness warnings. The state is checked on connect and then at
+// every stream interval. A warning is sent once as a "warning" event when it
+// appears and once as a "resolved" event when it goes away, so unchanged
+// warnings are not repeated. The stream ends when the client disconnects.
+func (h *InvariantHandler) LivenessStream(w http.ResponseWriter, r *http.Request) {
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		slog.Warn("liveness stream not supported", "error", err)
+		return
+	}
+
+	ticker := time.NewTicker(h.streamInterval)
+	defer ticker.Stop()
+
+	sent := make(map[warningKey]invariants.LivenessWarning)
+	for {
+		state, err := h.states.GetSystemState(r.Context())
+		if err != nil {
+			if r.Context().Err() != nil {
+				return
+			}
+			slog.Warn("liveness stream failed to get system state", "error", err)
+		} else {
+			current := make(map[warningKey]invariants.LivenessWarning)
+			for _, warning := range h.checker.LivenessWarnings(state) {
+				current[keyOf(warning)] = warning
+			}
+			if err := writeWarningChanges(w, sent, current); err != nil {
+				return
+			}
+			if err := controller.Flush(); err != nil {
+				return
+			}
+			sent = current
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeWarningChanges writes a "resolved" event for each warning sent before
+// and no longer current, then a "warning" event for each current warning not
+// sent before, both ordered by task and kind
+func writeWarningChanges(w http.ResponseWriter, sent, current map[warningKey]invariants.LivenessWarning) error {
+	var resolved, appeared []invariants.LivenessWarning
+	for key, warning := range sent {
+		if _, ok := current[key]; !ok {
+			resolved = append(resolved, warning)
+		}
+	}
+	for key, warning := range current {
+		if _, ok := sent[key]; !ok {
+			appeared = append(appeared, warning)
+		}
+	}
+
+	for _, change := range []struct {
+		event    string
+		warnings []invariants.LivenessWarning
+	}{{"resolved", resolved}, {"warning", appeared}} {
+		sort.Slice(change.warnings, func(i, j int) bool {
+			a, b := change.warnings[i], change.warnings[j]
+			if a.TaskID != b.TaskID {
+				return a.TaskID < b.TaskID
+			}
+			if a.Kind != b.Kind {
+				return a.Kind < b.Kind
+			}
+			return a.Message < b.Message
+		})
+		for _, warning := range change.warnings {
+			data, err := json.Marshal(warning)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", change.event, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
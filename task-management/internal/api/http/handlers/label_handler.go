@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// LabelsRequest represents the request body replacing a task's labels
+type LabelsRequest struct {
+	Labels map[string]string `json:"labels"`
+}
+
+// SetLabels handles PUT /tasks/{id}/labels
+func (h *TaskHandler) SetLabels(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	var req LabelsRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+
+	task, err := h.taskUseCase.SetLabels(r.Context(), domain.TaskID(taskID), req.Labels)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to set labels", err)
+		return
+	}
+
+	h.sendTask(w, r, http.StatusOK, task)
+}
+
+// FindByLabel handles GET /tasks/by-label?key=&value=
+func (h *TaskHandler) FindByLabel(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		h.sendError(w, http.StatusBadRequest, "Label key required", "")
+		return
+	}
+
+	tasks, err := h.taskUseCase.FindByLabel(r.Context(), key, r.URL.Query().Get("value"))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to find tasks", err)
+		return
+	}
+
+	h.sendTasks(w, r, tasks)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/notifications"
+)
+
+// NotificationHandler serves the notifications recorded for each user
+type NotificationHandler struct {
+	store *notifications.Store
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(store *notifications.Store) *NotificationHandler {
+	return &NotificationHandler{store: store}
+}
+
+// List handles GET /users/{id}/notifications, oldest first
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := domain.UserID(mux.Vars(r)["id"])
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.store.Notifications(userID))
+}
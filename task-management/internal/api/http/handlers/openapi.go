@@ -0,0 +1,415 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// schema is a JSON Schema object as embedded in an OpenAPI 3 document
+type schema = map[string]interface{}
+
+// apiParam documents a query parameter of a route
+type apiParam struct {
+	name        string
+	schema      schema
+	description string
+}
+
+// apiOperation documents one route. body and result are zero values of the
+// request and response types, described by reflecting over their JSON tags;
+// result may also be a schema for responses built from maps.
+type apiOperation struct {
+	method  string
+	path    string
+	summary string
+	query   []apiParam
+	body    interface{}
+	status  int
+	result  interface{}
+}
+
+var (
+	stringSchema  = schema{"type": "string"}
+	integerSchema = schema{"type": "integer"}
+	booleanSchema = schema{"type": "boolean"}
+	taskIDsSchema = schema{"type": "array", "items": integerSchema}
+	messageSchema = objectSchema(schema{"message": stringSchema})
+
+	includeArchivedParam = apiParam{"include_archived", booleanSchema, "include archived tasks"}
+	includeSnoozedParam  = apiParam{"include_snoozed", booleanSchema, "include tasks snoozed until a later time"}
+	sortParam            = apiParam{"sort", schema{"type": "string", "enum": []string{"priority", "due_date", "created_at", "status", "rank"}}, "listing order, rank for the backlog order; task ID by default"}
+)
+
+// openAPIEnums lists the values of the string types that are closed sets, so
+// generated clients can type them; priorities and tags are added from the
+// configured vocabulary by enumValues
+var openAPIEnums = map[reflect.Type][]string{
+	reflect.TypeOf(domain.TaskStatus("")): {
+		string(domain.StatusPending), string(domain.StatusInProgress), string(domain.StatusCompleted),
+		string(domain.StatusCancelled), string(domain.StatusBlocked),
+	},
+	reflect.TypeOf(domain.Recurrence("")): {
+		string(domain.RecurrenceNone), string(domain.RecurrenceDaily), string(domain.RecurrenceWeekly), string(domain.RecurrenceMonthly),
+	},
+	reflect.TypeOf(domain.Role("")): {
+		string(domain.RoleMember), string(domain.RoleAdmin),
+	},
+	reflect.TypeOf(domain.EventType("")): {
+		string(domain.EventTaskCreated), string(domain.EventTaskStatusChanged),
+		string(domain.EventTaskReassigned), string(domain.EventTaskEscalated),
+		string(domain.EventTaskReopened), string(domain.EventTaskSnoozed),
+		string(domain.EventTaskUnblocked),
+	},
+}
+
+// apiOperations documents every route registered by the server; keep it in
+// step with setupRoutes
+var apiOperations = []apiOperation{
+	{method: "POST", path: "/auth/login", summary: "Log in with a password (TLA+ Authenticate); a wrong user ID or password fails with 401 invalid_credentials", body: LoginRequest{}, status: http.StatusOK, result: domain.Session{}},
+	{method: "POST", path: "/auth/logout", summary: "Log out the bearer token's session, idempotently, or the X-User-ID user (TLA+ Logout)", status: http.StatusOK, result: messageSchema},
+	{method: "POST", path: "/auth/logout-all", summary: "Revoke every session of the bearer token's user", status: http.StatusOK, result: messageSchema},
+	{method: "GET", path: "/auth/me", summary: "Describe the session of the bearer token", status: http.StatusOK, result: SessionInfoResponse{}},
+	{method: "POST", path: "/auth/refresh", summary: "Extend the bearer token's session by the session duration from now", status: http.StatusOK, result: domain.Session{}},
+
+	{method: "POST", path: "/tasks", summary: "Create a task (TLA+ CreateTask)", body: CreateTaskRequest{}, status: http.StatusCreated, result: CreateTaskResponse{}},
+	{method: "POST", path: "/tasks/validate", summary: "Check a task creation, including its invariants, without creating anything", body: CreateTaskRequest{}, status: http.StatusOK, result: ValidateTaskResponse{}},
+	{method: "GET", path: "/tasks", summary: "List active tasks", query: []apiParam{
+		sortParam, includeArchivedParam, includeSnoozedParam,
+		{"include_done", booleanSchema, "include completed and cancelled tasks"},
+		{"tags", stringSchema, "comma-separated tags; only tasks carrying them, 400 for a tag outside the vocabulary"},
+		{"tag_match", schema{"type": "string", "enum": []string{"any", "all"}}, "whether tasks need any (the default) or all of the tags"},
+		{"created_from", schema{"type": "string", "format": "date-time"}, "only tasks created at or after this time (RFC3339)"},
+		{"created_to", schema{"type": "string", "format": "date-time"}, "only tasks created before this time (RFC3339)"},
+	}, status: http.StatusOK, result: []TaskResponse{}},
+	{method: "GET", path: "/tasks/due", summary: "List tasks due in a time range", query: []apiParam{
+		{"from", schema{"type": "string", "format": "date-time"}, "start of the range (RFC3339)"},
+		{"to", schema{"type": "string", "format": "date-time"}, "end of the range (RFC3339)"},
+		includeArchivedParam,
+	}, status: http.StatusOK, result: []TaskResponse{}},
+	{method: "GET", path: "/tasks/blocked", summary: "List blocked tasks with the dependencies they wait on", status: http.StatusOK, result: []BlockedTaskDetailResponse{}},
+	{method: "GET", path: "/tasks/ordered", summary: "List tasks with dependencies before dependents; 409 on a cycle", status: http.StatusOK, result: []TaskResponse{}},
+	{method: "GET", path: "/tasks/by-label", summary: "List unarchived tasks whose label key has the given value", query: []apiParam{
+		{"key", stringSchema, "label key"},
+		{"value", stringSchema, "label value, matched exactly"},
+	}, status: http.StatusOK, result: []TaskResponse{}},
+	{method: "GET", path: "/tasks/export", summary: "Export tasks as CSV (default) or JSON", query: []apiParam{
+		{"format", schema{"type": "string", "enum": []string{"csv", "json"}}, "export format"},
+		sortParam, includeArchivedParam,
+	}, status: http.StatusOK, result: []TaskResponse{}},
+	{method: "POST", path: "/tasks/purge", summary: "Permanently delete old completed and cancelled tasks (admins only)", body: PurgeRequest{}, status: http.StatusOK, result: objectSchema(schema{"message": stringSchema, "purged": integerSchema})},
+	{method: "GET", path: "/tasks/dependencies/graph", summary: "Get the dependency graph", status: http.StatusOK, result: DependencyGraphResponse{}},
+	{method: "PUT", path: "/tasks/{id}/status", summary: "Change the status (TLA+ UpdateTaskStatus)", body: UpdateStatusRequest{}, status: http.StatusOK, result: messageSchema},
+	{method: "POST", path: "/tasks/{id}/reopen", summary: "Move a completed or cancelled task back to in_progress with a reason (admins and the creator only)", body: ReopenTaskRequest{}, status: http.StatusOK, result: TaskResponse{}},
+	{method: "POST", path: "/tasks/{id}/snooze", summary: "Hide the task from listings until a future time; null wakes it", body: SnoozeTaskRequest{}, status: http.StatusOK, result: TaskResponse{}},
+	{method: "PUT", path: "/tasks/{id}/priority", summary: "Change the priority (TLA+ UpdateTaskPriority)", body: UpdatePriorityRequest{}, status: http.StatusOK, result: messageSchema},
+	{method: "PUT", path: "/tasks/{id}/reassign", summary: "Reassign the task (TLA+ ReassignTask)", body: ReassignTaskRequest{}, status: http.StatusOK, result: messageSchema},
+	{method: "PUT", path: "/tasks/{id}/details", summary: "Update title, description and due date (TLA+ UpdateTaskDetails)", body: UpdateDetailsRequest{}, status: http.StatusOK, result: messageSchema},
+	{method: "PUT", path: "/tasks/{id}/cancel", summary: "Cancel the task", status: http.StatusOK, result: objectSchema(schema{"message": stringSchema, "blocked_dependents": taskIDsSchema})},
+	{method: "POST", path: "/tasks/{id}/time", summary: "Log hours worked", body: LogTimeRequest{}, status: http.StatusOK, result: TaskResponse{}},
+	{method: "GET", path: "/tasks/{id}/status-durations", summary: "Seconds spent in each status", status: http.StatusOK, result: StatusDurationsResponse{}},
+	{method: "GET", path: "/tasks/{id}/assignment-history", summary: "Reassignments of the task, oldest first", status: http.StatusOK, result: AssignmentHistoryResponse{}},
+	{method: "PUT", path: "/tasks/{id}/rank", summary: "Set the task's rank in its assignee's backlog", body: RankRequest{}, status: http.StatusOK, result: TaskResponse{}},
+	{method: "POST", path: "/tasks/{id}/move", summary: "Move the task up or down its assignee's backlog, or between two tasks", body: MoveRequest{}, status: http.StatusOK, result: TaskResponse{}},
+	{method: "PUT", path: "/tasks/{id}/labels", summary: "Replace the task's key-value labels", body: LabelsRequest{}, status: http.StatusOK, result: TaskResponse{}},
+	{method: "GET", path: "/tasks/{id}/dependencies/transitive", summary: "List every task this task depends on, directly or indirectly", status: http.StatusOK, result: TransitiveDependenciesResponse{}},
+	{method: "POST", path: "/tasks/{id}/dependencies/{depId}", summary: "Add a dependency", status: http.StatusOK, result: TaskResponse{}},
+	{method: "DELETE", path: "/tasks/{id}/dependencies/{depId}", summary: "Remove a dependency", status: http.StatusOK, result: TaskResponse{}},
+	{method: "GET", path: "/tasks/{id}/relations", summary: "List the tasks this task is blocked by and blocks", status: http.StatusOK, result: TaskRelationsResponse{}},
+	{method: "GET", path: "/tasks/{id}/rollup", summary: "Get the task with the hours and completion of its subtasks rolled up", status: http.StatusOK, result: TaskRollupResponse{}},
+	{method: "PUT", path: "/tasks/{id}/restore", summary: "Restore an archived task", status: http.StatusOK, result: TaskResponse{}},
+	{method: "GET", path: "/tasks/{id}", summary: "Get a task with an ETag; If-None-Match holding it yields 304 Not Modified", status: http.StatusOK, result: TaskResponse{}},
+	{method: "PATCH", path: "/tasks/{id}", summary: "Update some fields; null clears the due date", body: PatchTaskRequest{}, status: http.StatusOK, result: TaskResponse{}},
+	{method: "DELETE", path: "/tasks/{id}", summary: "Archive the task, or delete it permanently with hard=true (admins only)", query: []apiParam{
+		{"hard", booleanSchema, "delete permanently instead of archiving"},
+		{"dry_run", booleanSchema, "delete nothing and return {task_id, deletable, reason} instead"},
+	}, status: http.StatusOK, result: messageSchema},
+
+	{method: "POST", path: "/tasks/{id}/comments", summary: "Add a comment", body: AddCommentRequest{}, status: http.StatusCreated, result: domain.Comment{}},
+	{method: "GET", path: "/tasks/{id}/comments", summary: "List comments, oldest first", status: http.StatusOK, result: []domain.Comment{}},
+	{method: "POST", path: "/tasks/{id}/attachments", summary: "Attach a file by URL", body: AddAttachmentRequest{}, status: http.StatusCreated, result: domain.Attachment{}},
+	{method: "GET", path: "/tasks/{id}/attachments", summary: "List attachments", status: http.StatusOK, result: []domain.Attachment{}},
+	{method: "POST", path: "/tasks/{id}/watchers", summary: "Watch the task", body: WatcherRequest{}, status: http.StatusOK, result: objectSchema(schema{"task_id": integerSchema, "watchers": schema{"type": "array", "items": stringSchema}})},
+	{method: "DELETE", path: "/tasks/{id}/watchers", summary: "Stop watching the task", body: WatcherRequest{}, status: http.StatusOK, result: objectSchema(schema{"task_id": integerSchema, "watchers": schema{"type": "array", "items": stringSchema}})},
+	{method: "POST", path: "/tasks/{id}/collaborators", summary: "Add collaborators who share ownership", body: CollaboratorsRequest{}, status: http.StatusOK, result: objectSchema(schema{"task_id": integerSchema, "collaborators": schema{"type": "array", "items": stringSchema}})},
+
+	{method: "GET", path: "/users", summary: "List users, by ID unless sorted otherwise; the number of matching users is in the X-Total-Count header", query: []apiParam{
+		{"role", schema{"$ref": "#/components/schemas/Role"}, "only users with this role"},
+		{"joined_after", schema{"type": "string", "format": "date-time"}, "only users who joined after this time"},
+		{"sort", schema{"type": "string", "enum": []string{"name", "joined_at"}}, "listing order, ties broken by user ID"},
+		{"offset", integerSchema, "matching users to skip"},
+		{"limit", integerSchema, fmt.Sprintf("page size, default %d, at most %d", usecase.DefaultUserLimit, usecase.MaxUserLimit)},
+	}, status: http.StatusOK, result: []domain.User{}},
+	{method: "POST", path: "/users", summary: "Register a member; repeating a registration returns the existing user with 200", body: RegisterUserRequest{}, status: http.StatusCreated, result: domain.User{}},
+	{method: "GET", path: "/users/by-email", summary: "Find a user by email", query: []apiParam{
+		{"email", stringSchema, "email address, matched case-insensitively"},
+	}, status: http.StatusOK, result: domain.User{}},
+	{method: "GET", path: "/users/suggest-assignee", summary: "Suggest the user with the fewest open tasks, lowest ID first on ties", status: http.StatusOK, result: SuggestAssigneeResponse{}},
+	{method: "GET", path: "/users/{id}/tasks", summary: "List the user's tasks", query: []apiParam{
+		{"status", schema{"$ref": "#/components/schemas/TaskStatus"}, "only tasks in this status"},
+		includeArchivedParam, includeSnoozedParam, sortParam,
+	}, status: http.StatusOK, result: []TaskResponse{}},
+	{method: "GET", path: "/users/{id}/created-tasks", summary: "List the tasks the user created, whoever they are assigned to", query: []apiParam{
+		{"status", schema{"$ref": "#/components/schemas/TaskStatus"}, "only tasks in this status"},
+		includeArchivedParam, includeSnoozedParam, sortParam,
+	}, status: http.StatusOK, result: []TaskResponse{}},
+	{method: "GET", path: "/users/{id}/tasks/overdue", summary: "List the user's overdue tasks, most overdue first", status: http.StatusOK, result: []TaskResponse{}},
+	{method: "GET", path: "/users/{id}/notifications", summary: "List the user's notifications, oldest first", status: http.StatusOK, result: []domain.Notification{}},
+
+	{method: "POST", path: "/tasks/bulk-update", summary: "Change the status of several tasks (TLA+ BulkUpdateStatus)", body: BulkUpdateRequest{}, status: http.StatusOK, result: objectSchema(schema{"message": stringSchema, "count": stringSchema})},
+	{method: "POST", path: "/tasks/bulk-validate", summary: "Report which tasks a bulk status change would reject, changing nothing", body: BulkUpdateRequest{}, status: http.StatusOK, result: BulkValidateResponse{}},
+	{method: "POST", path: "/tasks/bulk-reassign", summary: "Move every task of one user to another (admins only)", body: BulkReassignRequest{}, status: http.StatusOK, result: BulkReassignResponse{}},
+	{method: "POST", path: "/tasks/check-dependencies", summary: "Unblock tasks whose dependencies are done (TLA+ CheckDependencies)", status: http.StatusOK, result: CheckDependenciesResponse{}},
+
+	{method: "GET", path: "/ws", summary: "Stream task events over a WebSocket as JSON text messages", query: []apiParam{
+		{"assignee", stringSchema, "only events for tasks assigned to this user"},
+	}, status: http.StatusSwitchingProtocols, result: domain.Event{}},
+	{method: "GET", path: "/activity", summary: "Recorded task events, newest first", query: []apiParam{
+		{"user", stringSchema, "only changes made by this user"},
+		{"task", integerSchema, "only changes to this task"},
+		{"action", schema{"$ref": "#/components/schemas/EventType"}, "only events of this type"},
+		{"limit", integerSchema, fmt.Sprintf("page size, default %d, at most %d", usecase.DefaultActivityLimit, usecase.MaxActivityLimit)},
+		{"offset", integerSchema, "matching entries to skip"},
+		{"before", integerSchema, "only entries with a smaller ID; pass next_before from the previous page"},
+	}, status: http.StatusOK, result: ActivityResponse{}},
+	{method: "GET", path: "/stats", summary: "Count unarchived tasks in total, per status and per priority, overdue tasks, archived tasks and active sessions", status: http.StatusOK, result: StatsResponse{}},
+
+	{method: "POST", path: "/admin/repair-index", summary: "Rebuild the userTasks index (admins only)", status: http.StatusOK, result: objectSchema(schema{"message": stringSchema, "corrected_entries": integerSchema})},
+	{method: "GET", path: "/admin/invariants", summary: "Report every safety invariant and liveness warning", status: http.StatusOK, result: invariants.Report{}},
+	{method: "GET", path: "/admin/liveness/stream", summary: "Stream liveness warnings as server-sent events: \"warning\" when one appears, \"resolved\" when it goes away", status: http.StatusOK, result: invariants.LivenessWarning{}},
+	{method: "GET", path: "/admin/export", summary: "Export the whole system state (admins only)", status: http.StatusOK, result: domain.SystemState{}},
+	{method: "POST", path: "/admin/import", summary: "Replace the system state with an exported one; 409 if it violates an invariant (admins only)", body: domain.SystemState{}, status: http.StatusOK, result: objectSchema(schema{"message": stringSchema, "tasks": integerSchema, "users": integerSchema})},
+
+	{method: "GET", path: "/health", summary: "Liveness probe", status: http.StatusOK, result: objectSchema(schema{"status": stringSchema})},
+	{method: "GET", path: "/ready", summary: "Readiness probe; 503 while not ready", status: http.StatusOK, result: objectSchema(schema{"status": stringSchema})},
+	{method: "GET", path: "/metrics", summary: "Prometheus metrics", status: http.StatusOK},
+	{method: "GET", path: "/openapi.json", summary: "This OpenAPI document", status: http.StatusOK, result: schema{"type": "object"}},
+}
+
+// openAPISpec is built once; the routes and types it describes are fixed at compile time
+var openAPISpec = buildOpenAPISpec()
+
+// OpenAPISpec returns the OpenAPI 3 document describing the API
+func OpenAPISpec() map[string]interface{} {
+	return openAPISpec
+}
+
+// OpenAPI handles GET /openapi.json
+func (h *TaskHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	h.sendJSON(w, http.StatusOK, openAPISpec)
+}
+
+func buildOpenAPISpec() map[string]interface{} {
+	builder := &schemaBuilder{components: map[string]schema{}}
+	errorRef := builder.schemaOf(reflect.TypeOf(ErrorResponse{}))
+
+	paths := map[string]schema{}
+	for _, op := range apiOperations {
+		operation := schema{
+			"summary":     op.summary,
+			"operationId": operationID(op),
+		}
+
+		var params []schema
+		for _, name := range pathParams(op.path) {
+			paramSchema := integerSchema
+			if name == "id" && strings.HasPrefix(op.path, "/users/") {
+				paramSchema = stringSchema
+			}
+			params = append(params, schema{"name": name, "in": "path", "required": true, "schema": paramSchema})
+		}
+		for _, param := range op.query {
+			params = append(params, schema{"name": param.name, "in": "query", "description": param.description, "schema": param.schema})
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+
+		if op.body != nil {
+			operation["requestBody"] = schema{
+				"required": true,
+				"content":  schema{"application/json": schema{"schema": builder.schemaOf(reflect.TypeOf(op.body))}},
+			}
+		}
+
+		success := schema{"description": http.StatusText(op.status)}
+		switch result := op.result.(type) {
+		case nil:
+		case schema:
+			success["content"] = schema{"application/json": schema{"schema": result}}
+		default:
+			success["content"] = schema{"application/json": schema{"schema": builder.schemaOf(reflect.TypeOf(result))}}
+		}
+		operation["responses"] = schema{
+			strconv.Itoa(op.status): success,
+			"default": schema{
+				"description": "Error",
+				"content":     schema{"application/json": schema{"schema": errorRef}},
+			},
+		}
+
+		if paths[op.path] == nil {
+			paths[op.path] = schema{}
+		}
+		paths[op.path][strings.ToLower(op.method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": schema{
+			"title":       "Task Management API",
+			"description": "Task management system implementing the TLA+ specification",
+			"version":     "1.0.0",
+		},
+		"paths":      paths,
+		"components": schema{"schemas": builder.components},
+	}
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// pathParams returns the names of the {placeholders} in a route path
+func pathParams(path string) []string {
+	var names []string
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// operationID derives a stable identifier such as putTasksIdStatus
+func operationID(op apiOperation) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(op.method))
+	for _, word := range strings.FieldsFunc(op.path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '-' || r == '.' || r == '_'
+	}) {
+		id.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return id.String()
+}
+
+// objectSchema describes a JSON object with the given properties
+func objectSchema(properties schema) schema {
+	return schema{"type": "object", "properties": properties}
+}
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	optionalTimeType = reflect.TypeOf(OptionalTime{})
+)
+
+// schemaBuilder derives schemas from Go types, collecting named structs and
+// enums into components so they are described once and referenced by name
+// enumValues returns the values of a closed string type
+func enumValues(t reflect.Type) ([]string, bool) {
+	switch t {
+	case reflect.TypeOf(domain.Priority("")):
+		values := []string{}
+		for _, priority := range domain.CurrentVocabulary().Priorities {
+			values = append(values, string(priority))
+		}
+		return values, true
+	case reflect.TypeOf(domain.Tag("")):
+		values := []string{}
+		for _, tag := range domain.CurrentVocabulary().Tags {
+			values = append(values, string(tag))
+		}
+		return values, true
+	}
+	values, ok := openAPIEnums[t]
+	return values, ok
+}
+
+type schemaBuilder struct {
+	components map[string]schema
+}
+
+func (b *schemaBuilder) schemaOf(t reflect.Type) schema {
+	if values, ok := enumValues(t); ok {
+		if _, exists := b.components[t.Name()]; !exists {
+			b.components[t.Name()] = schema{"type": "string", "enum": values}
+		}
+		return schema{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	switch t {
+	case timeType:
+		return schema{"type": "string", "format": "date-time"}
+	case optionalTimeType:
+		return schema{"type": "string", "format": "date-time", "nullable": true}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.schemaOf(t.Elem())
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return schema{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return schema{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return schema{"type": "array", "items": b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return schema{"type": "object", "additionalProperties": b.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		if _, exists := b.components[t.Name()]; !exists {
+			// Reserve the name first so recursive types terminate
+			b.components[t.Name()] = schema{}
+			b.components[t.Name()] = b.structSchema(t)
+		}
+		return schema{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return schema{}
+	}
+}
+
+// structSchema describes a struct by its JSON fields, flattening embedded structs
+func (b *schemaBuilder) structSchema(t reflect.Type) schema {
+	properties := schema{}
+	b.addFields(t, properties)
+	return objectSchema(properties)
+}
+
+func (b *schemaBuilder) addFields(t reflect.Type, properties schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.addFields(embedded, properties)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = b.schemaOf(field.Type)
+	}
+}
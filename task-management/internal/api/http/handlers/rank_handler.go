@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// RankRequest represents the request body setting a task's backlog rank
+type RankRequest struct {
+	Rank float64 `json:"rank"`
+}
+
+// MoveRequest represents a request moving a task within its assignee's
+// backlog: either one place in a direction ("up" or "down"), or between the
+// tasks after and before, one of which may be left out
+type MoveRequest struct {
+	Direction string        `json:"direction,omitempty"`
+	After     domain.TaskID `json:"after,omitempty"`
+	Before    domain.TaskID `json:"before,omitempty"`
+}
+
+// SetRank handles PUT /tasks/{id}/rank
+func (h *TaskHandler) SetRank(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	var req RankRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+
+	task, err := h.taskUseCase.SetTaskRank(r.Context(), domain.TaskID(taskID), req.Rank)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to set rank", err)
+		return
+	}
+
+	h.sendTask(w, r, http.StatusOK, task)
+}
+
+// MoveTask handles POST /tasks/{id}/move
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	var req MoveRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+
+	var task *domain.Task
+	switch {
+	case req.Direction != "" && (req.After != 0 || req.Before != 0):
+		h.sendError(w, http.StatusBadRequest, "Invalid move", "give either 'direction' or 'after'/'before', not both")
+		return
+	case req.Direction != "":
+		task, err = h.taskUseCase.MoveTask(r.Context(), domain.TaskID(taskID), req.Direction)
+	default:
+		task, err = h.taskUseCase.MoveTaskBetween(r.Context(), domain.TaskID(taskID), req.After, req.Before)
+	}
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to move task", err)
+		return
+	}
+
+	h.sendTask(w, r, http.StatusOK, task)
+}
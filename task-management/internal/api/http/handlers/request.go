@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxRequestBodyBytes caps the size of JSON request bodies
+const maxRequestBodyBytes = 1 << 20
+
+// decodeJSON decodes a JSON request body into dst, replying with a precise
+// error and returning false if the body cannot be used: 415 unless the
+// Content-Type is application/json, 413 if the body exceeds
+// maxRequestBodyBytes, and 400 for malformed JSON, fields of the wrong type,
+// unknown fields (so typos such as "priorty" are not silently ignored) or
+// anything after the first JSON value.
+func (h *TaskHandler) decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		h.sendErrorResponse(w, http.StatusUnsupportedMediaType, ErrorResponse{
+			Error:   "Unsupported content type",
+			Code:    "unsupported_media_type",
+			Details: fmt.Sprintf("Content-Type must be application/json, got %q", r.Header.Get("Content-Type")),
+		})
+		return false
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		h.sendBodyError(w, err)
+		return false
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		if err == nil {
+			err = errors.New("body must contain a single JSON value")
+		}
+		h.sendBodyError(w, err)
+		return false
+	}
+	return true
+}
+
+// sendBodyError replies to a request body that failed to decode
+func (h *TaskHandler) sendBodyError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	status, code, details := http.StatusBadRequest, "invalid_body", err.Error()
+	switch {
+	case errors.As(err, &maxBytesErr):
+		status, code = http.StatusRequestEntityTooLarge, "body_too_large"
+		details = fmt.Sprintf("request body must not exceed %d bytes", maxBytesErr.Limit)
+	case errors.As(err, &syntaxErr):
+		code = "malformed_json"
+		details = fmt.Sprintf("malformed JSON at offset %d: %v", syntaxErr.Offset, err)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		code = "malformed_json"
+		details = "malformed JSON: unexpected end of body"
+	case errors.As(err, &typeErr):
+		code = "invalid_field_type"
+		details = fmt.Sprintf("field %q must be of type %s", typeErr.Field, typeErr.Type)
+	case errors.Is(err, io.EOF):
+		code = "empty_body"
+		details = "request body must not be empty"
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no typed error for unknown fields
+		code = "unknown_field"
+		details = strings.TrimPrefix(err.Error(), "json: ")
+	}
+
+	h.sendErrorResponse(w, status, ErrorResponse{
+		Error:   "Invalid request body",
+		Code:    code,
+		Details: details,
+	})
+}
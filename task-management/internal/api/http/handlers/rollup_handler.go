@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// TaskRollupResponse is a task with the effort of its subtasks rolled up
+type TaskRollupResponse struct {
+	TaskResponse
+	Rollup RollupResponse `json:"rollup"`
+}
+
+// RollupResponse sums the hours of a task and all its subtasks and counts the
+// completed subtasks
+type RollupResponse struct {
+	EstimatedHours    float64 `json:"estimated_hours"`
+	ActualHours       float64 `json:"actual_hours"`
+	CompletedSubtasks int     `json:"completed_subtasks"`
+	TotalSubtasks     int     `json:"total_subtasks"`
+}
+
+// GetTaskRollup handles GET /tasks/{id}/rollup
+func (h *TaskHandler) GetTaskRollup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	rollup, err := h.taskUseCase.GetTaskWithRollup(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusNotFound, "Failed to get task rollup", err)
+		return
+	}
+
+	responses, err := h.taskResponses(r.Context(), []*domain.Task{rollup.Task})
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to build task response", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, TaskRollupResponse{
+		TaskResponse: responses[0],
+		Rollup: RollupResponse{
+			EstimatedHours:    rollup.EstimatedHours,
+			ActualHours:       rollup.ActualHours,
+			CompletedSubtasks: rollup.CompletedSubtasks,
+			TotalSubtasks:     rollup.TotalSubtasks,
+		},
+	})
+}
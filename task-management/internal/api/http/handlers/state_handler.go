@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// ExportState handles GET /admin/export, returning the whole system state
+func (h *TaskHandler) ExportState(w http.ResponseWriter, r *http.Request) {
+	state, err := h.taskUseCase.ExportState(r.Context())
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusForbidden, "Failed to export system state", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, state)
+}
+
+// ImportState handles POST /admin/import, replacing the whole system state
+// with one previously returned by GET /admin/export
+func (h *TaskHandler) ImportState(w http.ResponseWriter, r *http.Request) {
+	var state domain.SystemState
+	if !h.decodeJSON(w, r, &state) {
+		return
+	}
+
+	if err := h.taskUseCase.ImportState(r.Context(), &state); err != nil {
+		h.sendUseCaseError(w, http.StatusForbidden, "Failed to import system state", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "System state imported",
+		"tasks":   len(state.Tasks),
+		"users":   len(state.Users),
+	})
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// StatsResponse holds the headline task and session counts for a dashboard
+type StatsResponse struct {
+	Total          int                       `json:"total"`
+	ByStatus       map[domain.TaskStatus]int `json:"by_status"`
+	ByPriority     map[domain.Priority]int   `json:"by_priority"`
+	Overdue        int                       `json:"overdue"`
+	Archived       int                       `json:"archived"`
+	ActiveSessions int                       `json:"active_sessions"`
+}
+
+// GetStats handles GET /stats
+func (h *TaskHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.taskUseCase.GetStats(r.Context())
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to get stats", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, StatsResponse{
+		Total:          stats.Total,
+		ByStatus:       stats.ByStatus,
+		ByPriority:     stats.ByPriority,
+		Overdue:        stats.Overdue,
+		Archived:       stats.Archived,
+		ActiveSessions: stats.ActiveSessions,
+	})
+}
@@ -3,8 +3,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 	
 	"github.com/gorilla/mux"
@@ -33,6 +36,82 @@ type CreateTaskRequest struct {
 	DueDate      *time.Time        `json:"due_date,omitempty"`
 	Tags         []domain.Tag      `json:"tags"`
 	Dependencies []domain.TaskID   `json:"dependencies"`
+	
+	EstimatedHours float64           `json:"estimated_hours,omitempty"`
+	Recurrence     domain.Recurrence `json:"recurrence,omitempty"`
+	ParentID       domain.TaskID     `json:"parent_id,omitempty"`
+	
+	// DueInDays and DueIn set the due date relative to the task's creation,
+	// as a number of days or a duration such as "36h"; at most one of them
+	// and DueDate may be given
+	DueInDays *int   `json:"due_in_days,omitempty"`
+	DueIn     string `json:"due_in,omitempty"`
+}
+
+// options returns the use case options for the request's optional fields
+func (req CreateTaskRequest) options() ([]usecase.TaskOption, error) {
+	opts := []usecase.TaskOption{
+		usecase.WithEstimatedHours(req.EstimatedHours),
+		usecase.WithRecurrence(req.Recurrence),
+		usecase.WithParent(req.ParentID),
+	}
+	dueIn, relative, err := req.relativeDueDate()
+	if err != nil {
+		return nil, err
+	}
+	if relative {
+		opts = append(opts, usecase.WithDueIn(dueIn))
+	}
+	return opts, nil
+}
+
+// relativeDueDate returns the due date offset from creation the request asks
+// for, if any
+func (req CreateTaskRequest) relativeDueDate() (time.Duration, bool, error) {
+	given := 0
+	for _, set := range []bool{req.DueDate != nil, req.DueInDays != nil, req.DueIn != ""} {
+		if set {
+			given++
+		}
+	}
+	if given > 1 {
+		return 0, false, fmt.Errorf("give only one of due_date, due_in_days and due_in")
+	}
+	
+	switch {
+	case req.DueInDays != nil:
+		if *req.DueInDays < 0 {
+			return 0, false, fmt.Errorf("due_in_days must not be negative")
+		}
+		return time.Duration(*req.DueInDays) * 24 * time.Hour, true, nil
+	case req.DueIn != "":
+		d, err := time.ParseDuration(req.DueIn)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid due_in: %w", err)
+		}
+		if d < 0 {
+			return 0, false, fmt.Errorf("due_in must not be negative")
+		}
+		return d, true, nil
+	}
+	return 0, false, nil
+}
+
+// PurgeRequest represents the request body for purging old tasks; OlderThan
+// is a Go duration such as "720h"
+type PurgeRequest struct {
+	OlderThan string `json:"older_than"`
+}
+
+// StatusDurationsResponse reports the seconds a task has spent in each status
+type StatusDurationsResponse struct {
+	TaskID  domain.TaskID                 `json:"task_id"`
+	Seconds map[domain.TaskStatus]float64 `json:"seconds_in_status"`
+}
+
+// LogTimeRequest represents the request body for logging time against a task
+type LogTimeRequest struct {
+	Hours float64 `json:"hours"`
 }
 
 // UpdateStatusRequest represents the request body for updating task status
@@ -40,6 +119,17 @@ type UpdateStatusRequest struct {
 	Status domain.TaskStatus `json:"status"`
 }
 
+// ReopenTaskRequest represents the request body for reopening a task
+type ReopenTaskRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SnoozeTaskRequest represents the request body for snoozing a task;
+// "until": null wakes the task
+type SnoozeTaskRequest struct {
+	Until *time.Time `json:"until"`
+}
+
 // UpdatePriorityRequest represents the request body for updating task priority
 type UpdatePriorityRequest struct {
 	Priority domain.Priority `json:"priority"`
@@ -57,32 +147,185 @@ type UpdateDetailsRequest struct {
 	DueDate     *time.Time `json:"due_date,omitempty"`
 }
 
+// PatchTaskRequest represents the request body for a partial task update;
+// omitted fields are left unchanged and "due_date": null clears the due date
+type PatchTaskRequest struct {
+	Title          *string          `json:"title,omitempty"`
+	Description    *string          `json:"description,omitempty"`
+	Priority       *domain.Priority `json:"priority,omitempty"`
+	Tags           *[]domain.Tag    `json:"tags,omitempty"`
+	EstimatedHours *float64         `json:"estimated_hours,omitempty"`
+	DueDate        OptionalTime     `json:"due_date"`
+}
+
+// OptionalTime distinguishes an omitted JSON field (Set is false) from an
+// explicit null (Set is true, Value is nil)
+type OptionalTime struct {
+	Set   bool
+	Value *time.Time
+}
+
+// UnmarshalJSON is only called when the field is present, including for null
+func (o *OptionalTime) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// BulkReassignRequest represents the request body for moving every task of
+// one user to another; either user may be given by ID or email
+type BulkReassignRequest struct {
+	From domain.UserID `json:"from"`
+	To   domain.UserID `json:"to"`
+}
+
+// BulkReassignResponse reports how many tasks a bulk reassignment moved
+type BulkReassignResponse struct {
+	Message string `json:"message"`
+	Moved   int    `json:"moved"`
+}
+
+// DeleteCheckResponse is the verdict of a dry-run delete: whether the task
+// could be deleted and, if not, why
+type DeleteCheckResponse struct {
+	TaskID    domain.TaskID `json:"task_id"`
+	Deletable bool          `json:"deletable"`
+	Reason    string        `json:"reason,omitempty"`
+}
+
 // BulkUpdateRequest represents the request body for bulk status updates
 type BulkUpdateRequest struct {
 	TaskIDs []domain.TaskID   `json:"task_ids"`
 	Status  domain.TaskStatus `json:"status"`
 }
 
+// CreateTaskResponse is the created task; StatusReason is set when the
+// task was created blocked rather than pending
+type CreateTaskResponse struct {
+	TaskResponse
+	StatusReason *StatusReasonResponse `json:"status_reason,omitempty"`
+}
+
+// StatusReasonResponse explains the initial status of a new task
+type StatusReasonResponse struct {
+	Message   string          `json:"message"`
+	WaitingOn []domain.TaskID `json:"waiting_on"`
+}
+
+// ValidateTaskResponse is the verdict on a task creation: a valid one carries
+// the task as it would be created, an invalid one the Code and Error that
+// POST /tasks would fail with
+type ValidateTaskResponse struct {
+	Valid bool          `json:"valid"`
+	Task  *TaskResponse `json:"task,omitempty"`
+	Code  string        `json:"code,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// BulkValidateResult is the outcome of one task of a bulk status change;
+// Code and Error say why an invalid task would be rejected
+type BulkValidateResult struct {
+	TaskID domain.TaskID `json:"task_id"`
+	Valid  bool          `json:"valid"`
+	Code   string        `json:"code,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// BulkValidateResponse previews a bulk status change; Valid is set when every
+// task would be accepted
+type BulkValidateResponse struct {
+	Valid   bool                 `json:"valid"`
+	Results []BulkValidateResult `json:"results"`
+}
+
 // LoginRequest represents the request body for authentication
 type LoginRequest struct {
-	UserID domain.UserID `json:"user_id"`
+	UserID     domain.UserID `json:"user_id"`
+	Password   string        `json:"password"`
+	RememberMe bool          `json:"remember_me,omitempty"`
+}
+
+// SessionInfoResponse describes the session behind a token
+type SessionInfoResponse struct {
+	UserID    domain.UserID `json:"user_id"`
+	ExpiresAt time.Time     `json:"expires_at"`
 }
 
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"`
 	Details string `json:"details,omitempty"`
 }
 
 // CreateTask handles POST /tasks
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	var req CreateTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	opts, err := req.options()
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid due date", err.Error())
 		return
 	}
 	
 	task, err := h.taskUseCase.CreateTask(
+		r.Context(),
+		req.Title,
+		req.Description,
+		req.Priority,
+		req.Assignee,
+		req.DueDate,
+		req.Tags,
+		req.Dependencies,
+		opts...,
+	)
+	
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to create task", err)
+		return
+	}
+	
+	responses, err := h.taskResponses(r.Context(), []*domain.Task{task})
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to build task response", err)
+		return
+	}
+	response := CreateTaskResponse{TaskResponse: responses[0]}
+	
+	// A task with incomplete dependencies starts out blocked
+	if task.Status == domain.StatusBlocked {
+		waiting, err := h.taskUseCase.GetIncompleteDependencies(r.Context(), task.ID)
+		if err != nil {
+			h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to build task response", err)
+			return
+		}
+		response.StatusReason = &StatusReasonResponse{
+			Message:   "blocked until its incomplete dependencies are completed",
+			WaitingOn: waiting,
+		}
+	}
+	
+	h.sendJSON(w, http.StatusCreated, response)
+}
+
+// ValidateTask handles POST /tasks/validate: it checks a CreateTaskRequest as
+// POST /tasks would without creating the task
+func (h *TaskHandler) ValidateTask(w http.ResponseWriter, r *http.Request) {
+	var req CreateTaskRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	opts, err := req.options()
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid due date", err.Error())
+		return
+	}
+	
+	task, err := h.taskUseCase.DryRunCreateTask(
+		r.Context(),
 		req.Title,
 		req.Description,
 		req.Priority,
@@ -90,14 +333,43 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		req.DueDate,
 		req.Tags,
 		req.Dependencies,
+		opts...,
 	)
+	if errors.Is(err, domain.ErrUnauthenticated) {
+		h.sendUseCaseError(w, http.StatusUnauthorized, "Failed to validate task", err)
+		return
+	}
+	if err != nil {
+		_, code := classifyError(err, http.StatusBadRequest)
+		h.sendJSON(w, http.StatusOK, ValidateTaskResponse{Code: code, Error: err.Error()})
+		return
+	}
+	
+	responses, err := h.taskResponses(r.Context(), []*domain.Task{task})
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to build task response", err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, ValidateTaskResponse{Valid: true, Task: &responses[0]})
+}
+
+// GetTask handles GET /tasks/{id}. The response carries an ETag, and a
+// request whose If-None-Match already holds it gets 304 Not Modified.
+func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
 	
+	task, err := h.taskUseCase.GetTask(r.Context(), domain.TaskID(taskID))
 	if err != nil {
-		h.sendError(w, http.StatusBadRequest, "Failed to create task", err.Error())
+		h.sendUseCaseError(w, http.StatusNotFound, "Failed to get task", err)
 		return
 	}
 	
-	h.sendJSON(w, http.StatusCreated, task)
+	h.sendTaskWithETag(w, r, task)
 }
 
 // UpdateTaskStatus handles PUT /tasks/{id}/status
@@ -110,19 +382,64 @@ func (h *TaskHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	var req UpdateStatusRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+	if !h.decodeJSON(w, r, &req) {
 		return
 	}
 	
-	if err := h.taskUseCase.UpdateTaskStatus(domain.TaskID(taskID), req.Status); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Failed to update task status", err.Error())
+	if err := h.taskUseCase.UpdateTaskStatus(r.Context(), domain.TaskID(taskID), req.Status); err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to update task status", err)
 		return
 	}
 	
 	h.sendJSON(w, http.StatusOK, map[string]string{"message": "Task status updated successfully"})
 }
 
+// ReopenTask handles POST /tasks/{id}/reopen
+func (h *TaskHandler) ReopenTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+	
+	var req ReopenTaskRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	task, err := h.taskUseCase.ReopenTask(r.Context(), domain.TaskID(taskID), req.Reason)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusForbidden, "Failed to reopen task", err)
+		return
+	}
+	
+	h.sendTask(w, r, http.StatusOK, task)
+}
+
+// SnoozeTask handles POST /tasks/{id}/snooze
+func (h *TaskHandler) SnoozeTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+	
+	var req SnoozeTaskRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	task, err := h.taskUseCase.SnoozeTask(r.Context(), domain.TaskID(taskID), req.Until)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusForbidden, "Failed to snooze task", err)
+		return
+	}
+	
+	h.sendTask(w, r, http.StatusOK, task)
+}
+
 // UpdateTaskPriority handles PUT /tasks/{id}/priority
 func (h *TaskHandler) UpdateTaskPriority(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -133,13 +450,12 @@ func (h *TaskHandler) UpdateTaskPriority(w http.ResponseWriter, r *http.Request)
 	}
 	
 	var req UpdatePriorityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+	if !h.decodeJSON(w, r, &req) {
 		return
 	}
 	
-	if err := h.taskUseCase.UpdateTaskPriority(domain.TaskID(taskID), req.Priority); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Failed to update task priority", err.Error())
+	if err := h.taskUseCase.UpdateTaskPriority(r.Context(), domain.TaskID(taskID), req.Priority); err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to update task priority", err)
 		return
 	}
 	
@@ -156,13 +472,12 @@ func (h *TaskHandler) ReassignTask(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	var req ReassignTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+	if !h.decodeJSON(w, r, &req) {
 		return
 	}
 	
-	if err := h.taskUseCase.ReassignTask(domain.TaskID(taskID), req.Assignee); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Failed to reassign task", err.Error())
+	if err := h.taskUseCase.ReassignTask(r.Context(), domain.TaskID(taskID), req.Assignee); err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to reassign task", err)
 		return
 	}
 	
@@ -179,25 +494,60 @@ func (h *TaskHandler) UpdateTaskDetails(w http.ResponseWriter, r *http.Request)
 	}
 	
 	var req UpdateDetailsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+	if !h.decodeJSON(w, r, &req) {
 		return
 	}
 	
 	if err := h.taskUseCase.UpdateTaskDetails(
+		r.Context(),
 		domain.TaskID(taskID),
 		req.Title,
 		req.Description,
 		req.DueDate,
 	); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Failed to update task details", err.Error())
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to update task details", err)
 		return
 	}
 	
 	h.sendJSON(w, http.StatusOK, map[string]string{"message": "Task details updated successfully"})
 }
 
-// DeleteTask handles DELETE /tasks/{id}
+// PatchTask handles PATCH /tasks/{id}
+func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+	
+	var req PatchTaskRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	patch := usecase.TaskPatch{
+		Title:          req.Title,
+		Description:    req.Description,
+		Priority:       req.Priority,
+		Tags:           req.Tags,
+		EstimatedHours: req.EstimatedHours,
+		DueDate:        req.DueDate.Value,
+		ClearDueDate:   req.DueDate.Set && req.DueDate.Value == nil,
+	}
+	
+	task, err := h.taskUseCase.PatchTask(r.Context(), domain.TaskID(taskID), patch)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to update task", err)
+		return
+	}
+	
+	h.sendTask(w, r, http.StatusOK, task)
+}
+
+// DeleteTask handles DELETE /tasks/{id}; the task is archived unless
+// ?hard=true is given, which permanently deletes it (admins only). With
+// ?dry_run=true nothing is deleted and the verdict is returned instead.
 func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	taskID, err := strconv.Atoi(vars["id"])
@@ -205,25 +555,256 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
 		return
 	}
+	hard := r.URL.Query().Get("hard") == "true"
+	
+	if r.URL.Query().Get("dry_run") == "true" {
+		check := h.taskUseCase.CanDeleteTask
+		if hard {
+			check = h.taskUseCase.CanPurgeTask
+		}
+		deletable, reason, err := check(r.Context(), domain.TaskID(taskID))
+		if err != nil {
+			h.sendUseCaseError(w, http.StatusBadRequest, "Failed to check task deletion", err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, DeleteCheckResponse{
+			TaskID:    domain.TaskID(taskID),
+			Deletable: deletable,
+			Reason:    reason,
+		})
+		return
+	}
+	
+	if hard {
+		if err := h.taskUseCase.PurgeTask(r.Context(), domain.TaskID(taskID)); err != nil {
+			h.sendUseCaseError(w, http.StatusBadRequest, "Failed to delete task", err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]string{"message": "Task deleted permanently"})
+		return
+	}
+	
+	if err := h.taskUseCase.DeleteTask(r.Context(), domain.TaskID(taskID)); err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to delete task", err)
+		return
+	}
+	
+	h.sendJSON(w, http.StatusOK, map[string]string{"message": "Task archived successfully"})
+}
+
+// RestoreTask handles PUT /tasks/{id}/restore
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+	
+	task, err := h.taskUseCase.RestoreTask(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to restore task", err)
+		return
+	}
+	
+	h.sendTask(w, r, http.StatusOK, task)
+}
+
+// CancelTask handles PUT /tasks/{id}/cancel
+func (h *TaskHandler) CancelTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
 	
-	if err := h.taskUseCase.DeleteTask(domain.TaskID(taskID)); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Failed to delete task", err.Error())
+	blocked, err := h.taskUseCase.CancelTask(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to cancel task", err)
 		return
 	}
 	
-	h.sendJSON(w, http.StatusOK, map[string]string{"message": "Task deleted successfully"})
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"message":            "Task cancelled successfully",
+		"blocked_dependents": blocked,
+	})
+}
+
+// ListTasks handles GET /tasks?sort=priority|due_date|created_at|status&include_archived=&include_done=&include_snoozed=&tags=&tag_match=all|any.
+// Completed and cancelled tasks are only listed with include_done=true.
+func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	order := usecase.TaskSort(r.URL.Query().Get("sort"))
+	filter := usecase.ListFilter{
+		IncludeArchived: includeArchived(r),
+		IncludeDone:     r.URL.Query().Get("include_done") == "true",
+		IncludeSnoozed:  includeSnoozed(r),
+	}
+	for _, tag := range strings.Split(r.URL.Query().Get("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			filter.Tags = append(filter.Tags, domain.Tag(tag))
+		}
+	}
+	switch match := r.URL.Query().Get("tag_match"); match {
+	case "", "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		h.sendError(w, http.StatusBadRequest, "Invalid tag_match", "tag_match must be all or any, not "+match)
+		return
+	}
+	if from := r.URL.Query().Get("created_from"); from != "" {
+		created, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid 'created_from' timestamp", err.Error())
+			return
+		}
+		filter.CreatedFrom = created
+	}
+	if to := r.URL.Query().Get("created_to"); to != "" {
+		created, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid 'created_to' timestamp", err.Error())
+			return
+		}
+		filter.CreatedTo = created
+	}
+	
+	tasks, err := h.taskUseCase.ListTasks(r.Context(), order, filter)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to list tasks", err)
+		return
+	}
+	
+	h.sendTasks(w, r, tasks)
+}
+
+// GetTasksDueBetween handles GET /tasks/due?from=&to=&include_archived= with RFC3339 timestamps
+func (h *TaskHandler) GetTasksDueBetween(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	
+	from, err := time.Parse(time.RFC3339, query.Get("from"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid 'from' timestamp", err.Error())
+		return
+	}
+	
+	to, err := time.Parse(time.RFC3339, query.Get("to"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid 'to' timestamp", err.Error())
+		return
+	}
+	
+	tasks, err := h.taskUseCase.GetTasksDueBetween(r.Context(), from, to, includeArchived(r))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to get due tasks", err)
+		return
+	}
+	
+	h.sendTasks(w, r, tasks)
+}
+
+// LogTime handles POST /tasks/{id}/time
+func (h *TaskHandler) LogTime(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+	
+	var req LogTimeRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	task, err := h.taskUseCase.LogTime(r.Context(), domain.TaskID(taskID), req.Hours)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to log time", err)
+		return
+	}
+	
+	h.sendTask(w, r, http.StatusOK, task)
+}
+
+// GetStatusDurations handles GET /tasks/{id}/status-durations
+func (h *TaskHandler) GetStatusDurations(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+	
+	durations, err := h.taskUseCase.GetStatusDurations(r.Context(), domain.TaskID(taskID))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusNotFound, "Failed to get status durations", err)
+		return
+	}
+	
+	seconds := make(map[domain.TaskStatus]float64, len(durations))
+	for status, duration := range durations {
+		seconds[status] = duration.Seconds()
+	}
+	h.sendJSON(w, http.StatusOK, StatusDurationsResponse{
+		TaskID:  domain.TaskID(taskID),
+		Seconds: seconds,
+	})
+}
+
+// GetTasksByUser handles GET /users/{id}/tasks?status=&include_archived=&include_snoozed=&sort=
+func (h *TaskHandler) GetTasksByUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := domain.UserID(vars["id"])
+	status := domain.TaskStatus(r.URL.Query().Get("status"))
+	order := usecase.TaskSort(r.URL.Query().Get("sort"))
+	
+	tasks, err := h.taskUseCase.GetTasksByUser(r.Context(), userID, status, includeArchived(r), includeSnoozed(r), order)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to get user tasks", err)
+		return
+	}
+	
+	h.sendTasks(w, r, tasks)
+}
+
+// GetTasksByCreator handles GET /users/{id}/created-tasks
+func (h *TaskHandler) GetTasksByCreator(w http.ResponseWriter, r *http.Request) {
+	userID := domain.UserID(mux.Vars(r)["id"])
+	status := domain.TaskStatus(r.URL.Query().Get("status"))
+	order := usecase.TaskSort(r.URL.Query().Get("sort"))
+	
+	tasks, err := h.taskUseCase.GetTasksByCreator(r.Context(), userID, status, includeArchived(r), includeSnoozed(r), order)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to get created tasks", err)
+		return
+	}
+	
+	h.sendTasks(w, r, tasks)
+}
+
+// GetOverdueTasksForUser handles GET /users/{id}/tasks/overdue
+func (h *TaskHandler) GetOverdueTasksForUser(w http.ResponseWriter, r *http.Request) {
+	userID := domain.UserID(mux.Vars(r)["id"])
+	
+	tasks, err := h.taskUseCase.GetOverdueTasksForUser(r.Context(), userID)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to get overdue tasks", err)
+		return
+	}
+	
+	h.sendTasks(w, r, tasks)
 }
 
 // BulkUpdateStatus handles POST /tasks/bulk-update
 func (h *TaskHandler) BulkUpdateStatus(w http.ResponseWriter, r *http.Request) {
 	var req BulkUpdateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+	if !h.decodeJSON(w, r, &req) {
 		return
 	}
 	
-	if err := h.taskUseCase.BulkUpdateStatus(req.TaskIDs, req.Status); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Failed to bulk update tasks", err.Error())
+	if err := h.taskUseCase.BulkUpdateStatus(r.Context(), req.TaskIDs, req.Status); err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to bulk update tasks", err)
 		return
 	}
 	
@@ -233,55 +814,251 @@ func (h *TaskHandler) BulkUpdateStatus(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ValidateBulkTransition handles POST /tasks/bulk-validate, reporting which
+// tasks a bulk-update request with the same body would reject, in request order
+func (h *TaskHandler) ValidateBulkTransition(w http.ResponseWriter, r *http.Request) {
+	var req BulkUpdateRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	errs, err := h.taskUseCase.ValidateBulkTransition(r.Context(), req.TaskIDs, req.Status)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to validate bulk update", err)
+		return
+	}
+	
+	response := BulkValidateResponse{Valid: true, Results: []BulkValidateResult{}}
+	seen := make(map[domain.TaskID]bool, len(req.TaskIDs))
+	for _, taskID := range req.TaskIDs {
+		if seen[taskID] {
+			continue
+		}
+		seen[taskID] = true
+		
+		result := BulkValidateResult{TaskID: taskID, Valid: errs[taskID] == nil}
+		if !result.Valid {
+			response.Valid = false
+			_, result.Code = classifyError(errs[taskID], http.StatusForbidden)
+			result.Error = errs[taskID].Error()
+		}
+		response.Results = append(response.Results, result)
+	}
+	
+	h.sendJSON(w, http.StatusOK, response)
+}
+
+// BulkReassign handles POST /tasks/bulk-reassign
+func (h *TaskHandler) BulkReassign(w http.ResponseWriter, r *http.Request) {
+	var req BulkReassignRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	moved, err := h.taskUseCase.BulkReassign(r.Context(), req.From, req.To)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to bulk reassign tasks", err)
+		return
+	}
+	
+	h.sendJSON(w, http.StatusOK, BulkReassignResponse{
+		Message: "Tasks reassigned successfully",
+		Moved:   moved,
+	})
+}
+
+// CheckDependenciesResponse reports the outcome of a dependency check
+type CheckDependenciesResponse struct {
+	Message        string                `json:"message"`
+	UnblockedCount int                   `json:"unblocked_count"`
+	Unblocked      []domain.TaskID       `json:"unblocked"`
+	StillBlocked   []BlockedTaskResponse `json:"still_blocked"`
+	Escalated      []domain.TaskID       `json:"escalated"`
+}
+
+// BlockedTaskResponse is a task left blocked and the dependencies it waits on
+type BlockedTaskResponse struct {
+	TaskID    domain.TaskID   `json:"task_id"`
+	WaitingOn []domain.TaskID `json:"waiting_on"`
+}
+
 // CheckDependencies handles POST /tasks/check-dependencies
 func (h *TaskHandler) CheckDependencies(w http.ResponseWriter, r *http.Request) {
-	count, err := h.taskUseCase.CheckDependencies()
+	report, err := h.taskUseCase.CheckDependenciesReport(r.Context())
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "Failed to check dependencies", err.Error())
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to check dependencies", err)
+		return
+	}
+	
+	stillBlocked := make([]BlockedTaskResponse, 0, len(report.StillBlocked))
+	for _, blocked := range report.StillBlocked {
+		stillBlocked = append(stillBlocked, BlockedTaskResponse{
+			TaskID:    blocked.TaskID,
+			WaitingOn: blocked.WaitingOn,
+		})
+	}
+	
+	h.sendJSON(w, http.StatusOK, CheckDependenciesResponse{
+		Message:        "Dependencies checked",
+		UnblockedCount: len(report.Unblocked),
+		Unblocked:      report.Unblocked,
+		StillBlocked:   stillBlocked,
+		Escalated:      report.Escalated,
+	})
+}
+
+// RepairIndex handles POST /admin/repair-index
+func (h *TaskHandler) RepairIndex(w http.ResponseWriter, r *http.Request) {
+	count, err := h.taskUseCase.RepairIndex(r.Context())
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusForbidden, "Failed to repair task index", err)
 		return
 	}
 	
 	h.sendJSON(w, http.StatusOK, map[string]interface{}{
-		"message":         "Dependencies checked",
-		"unblocked_count": count,
+		"message":           "Task index rebuilt",
+		"corrected_entries": count,
 	})
 }
 
+// PurgeOldTasks handles POST /tasks/purge
+func (h *TaskHandler) PurgeOldTasks(w http.ResponseWriter, r *http.Request) {
+	var req PurgeRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	
+	olderThan, err := time.ParseDuration(req.OlderThan)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid 'older_than' duration", err.Error())
+		return
+	}
+	
+	count, err := h.taskUseCase.PurgeOldTasks(r.Context(), olderThan)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusForbidden, "Failed to purge old tasks", err)
+		return
+	}
+	
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Old tasks purged",
+		"purged":  count,
+	})
+}
+
+// Ready handles GET /ready, a readiness probe that fails with 503 and the
+// cause while the repository is unreachable or an invariant is violated
+func (h *TaskHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if err := h.taskUseCase.CheckReadiness(r.Context()); err != nil {
+		h.sendErrorResponse(w, http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "Not ready",
+			Code:    "not_ready",
+			Details: err.Error(),
+		})
+		return
+	}
+	
+	h.sendJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
 // Login handles POST /auth/login
 func (h *TaskHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+	if !h.decodeJSON(w, r, &req) {
 		return
 	}
 	
-	session, err := h.taskUseCase.Authenticate(req.UserID)
+	session, err := h.taskUseCase.Login(r.Context(), req.UserID, req.Password, req.RememberMe)
 	if err != nil {
-		h.sendError(w, http.StatusUnauthorized, "Authentication failed", err.Error())
+		h.sendUseCaseError(w, http.StatusUnauthorized, "Authentication failed", err)
 		return
 	}
 	
 	h.sendJSON(w, http.StatusOK, session)
 }
 
-// Logout handles POST /auth/logout
+// Me handles GET /auth/me, describing the session of the
+// "Authorization: Bearer <token>" header
+func (h *TaskHandler) Me(w http.ResponseWriter, r *http.Request) {
+	session, err := h.taskUseCase.GetSession(r.Context(), bearerToken(r))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusUnauthorized, "Invalid session", err)
+		return
+	}
+	
+	h.sendJSON(w, http.StatusOK, SessionInfoResponse{
+		UserID:    session.UserID,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+// Logout handles POST /auth/logout. With an "Authorization: Bearer <token>"
+// header only that session is revoked, and repeating the request still
+// succeeds; otherwise the X-User-ID header names the current user to log out.
 func (h *TaskHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if token := bearerToken(r); token != "" {
+		if err := h.taskUseCase.LogoutSession(r.Context(), token); err != nil {
+			h.sendUseCaseError(w, http.StatusUnauthorized, "Logout failed", err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, map[string]string{"message": "Logged out successfully"})
+		return
+	}
+	
 	userID := r.Header.Get("X-User-ID")
 	if userID == "" {
 		h.sendError(w, http.StatusBadRequest, "User ID required", "")
 		return
 	}
 	
-	if err := h.taskUseCase.Logout(domain.UserID(userID)); err != nil {
-		h.sendError(w, http.StatusBadRequest, "Logout failed", err.Error())
+	if err := h.taskUseCase.Logout(r.Context(), domain.UserID(userID)); err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Logout failed", err)
 		return
 	}
 	
 	h.sendJSON(w, http.StatusOK, map[string]string{"message": "Logged out successfully"})
 }
 
+// LogoutAll handles POST /auth/logout-all, revoking every session of the
+// user holding the "Authorization: Bearer <token>" header
+func (h *TaskHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	if err := h.taskUseCase.LogoutAll(r.Context(), bearerToken(r)); err != nil {
+		h.sendUseCaseError(w, http.StatusUnauthorized, "Logout failed", err)
+		return
+	}
+	
+	h.sendJSON(w, http.StatusOK, map[string]string{"message": "Logged out of all sessions"})
+}
+
+// RefreshSession handles POST /auth/refresh, extending the session of the
+// "Authorization: Bearer <token>" header
+func (h *TaskHandler) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.taskUseCase.RefreshSession(r.Context(), bearerToken(r))
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusUnauthorized, "Refresh failed", err)
+		return
+	}
+	
+	h.sendJSON(w, http.StatusOK, session)
+}
+
 // Helper methods
 
+// bearerToken returns the token of an "Authorization: Bearer <token>" header
+func bearerToken(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// includeArchived reports whether a list request asked for archived tasks
+func includeArchived(r *http.Request) bool {
+	return r.URL.Query().Get("include_archived") == "true"
+}
+
+// includeSnoozed reports whether a list request asked for snoozed tasks
+func includeSnoozed(r *http.Request) bool {
+	return r.URL.Query().Get("include_snoozed") == "true"
+}
+
 func (h *TaskHandler) sendJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -289,10 +1066,25 @@ func (h *TaskHandler) sendJSON(w http.ResponseWriter, status int, data interface
 }
 
 func (h *TaskHandler) sendError(w http.ResponseWriter, status int, message, details string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(ErrorResponse{
+	h.sendErrorResponse(w, status, ErrorResponse{
 		Error:   message,
 		Details: details,
 	})
 }
+
+// sendUseCaseError maps a use case error to its HTTP status and error code,
+// using fallbackStatus for errors without a known mapping
+func (h *TaskHandler) sendUseCaseError(w http.ResponseWriter, fallbackStatus int, message string, err error) {
+	status, code := classifyError(err, fallbackStatus)
+	h.sendErrorResponse(w, status, ErrorResponse{
+		Error:   message,
+		Code:    code,
+		Details: err.Error(),
+	})
+}
+
+func (h *TaskHandler) sendErrorResponse(w http.ResponseWriter, status int, resp ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// TaskResponse is the JSON representation of a task together with values
+// computed from the rest of the system
+type TaskResponse struct {
+	*domain.Task
+	DependencyProgress float64 `json:"dependency_progress"`
+	// PriorityWeight orders priorities numerically, higher meaning more urgent
+	PriorityWeight int `json:"priority_weight"`
+}
+
+// taskResponses wraps the given tasks with their computed fields
+func (h *TaskHandler) taskResponses(ctx context.Context, tasks []*domain.Task) ([]TaskResponse, error) {
+	progress, err := h.taskUseCase.GetDependencyProgress(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]TaskResponse, len(tasks))
+	for i, task := range tasks {
+		responses[i] = TaskResponse{
+			Task:               task,
+			DependencyProgress: progress[task.ID],
+			PriorityWeight:     task.Priority.Weight(),
+		}
+	}
+	return responses, nil
+}
+
+// sendTask writes a single task as a TaskResponse
+func (h *TaskHandler) sendTask(w http.ResponseWriter, r *http.Request, status int, task *domain.Task) {
+	responses, err := h.taskResponses(r.Context(), []*domain.Task{task})
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to build task response", err)
+		return
+	}
+	h.sendJSON(w, status, responses[0])
+}
+
+// sendTasks writes a list of tasks as TaskResponses
+func (h *TaskHandler) sendTasks(w http.ResponseWriter, r *http.Request, tasks []*domain.Task) {
+	responses, err := h.taskResponses(r.Context(), tasks)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to build task response", err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, responses)
+}
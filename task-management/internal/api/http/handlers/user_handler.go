@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// RegisterUserRequest represents a user registration request
+type RegisterUserRequest struct {
+	ID       domain.UserID `json:"id"`
+	Name     string        `json:"name"`
+	Email    string        `json:"email"`
+	Password string        `json:"password,omitempty"`
+}
+
+// RegisterUser handles POST /users. New users are members; repeating a
+// registration returns the existing user with 200 instead of 201.
+func (h *TaskHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
+	var req RegisterUserRequest
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+
+	user, created, err := h.taskUseCase.RegisterUser(r.Context(), req.ID, req.Name, req.Email, req.Password)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to register user", err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	h.sendJSON(w, status, user)
+}
+
+// ListUsers handles GET /users?role=&joined_after=&sort=name|joined_at&offset=&limit=,
+// returning a page of users with the number of matching users in the
+// X-Total-Count header
+func (h *TaskHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.UserFilter{
+		Role: domain.Role(query.Get("role")),
+		Sort: domain.UserSort(query.Get("sort")),
+	}
+	if value := query.Get("joined_after"); value != "" {
+		joinedAfter, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "Invalid 'joined_after' timestamp", err.Error())
+			return
+		}
+		filter.JoinedAfter = joinedAfter
+	}
+
+	offset, err := intParam(r, "offset")
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query parameter", err.Error())
+		return
+	}
+	limit, err := intParam(r, "limit")
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid query parameter", err.Error())
+		return
+	}
+
+	users, total, err := h.taskUseCase.ListUsers(r.Context(), filter, offset, limit)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to list users", err)
+		return
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	h.sendJSON(w, http.StatusOK, users)
+}
+
+// FindUserByEmail handles GET /users/by-email?email=
+func (h *TaskHandler) FindUserByEmail(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if email == "" {
+		h.sendError(w, http.StatusBadRequest, "Missing email", "the email query parameter is required")
+		return
+	}
+
+	user, err := h.taskUseCase.FindUserByEmail(r.Context(), email)
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to find user", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, user)
+}
+
+// SuggestAssigneeResponse names the suggested assignee for a new task
+type SuggestAssigneeResponse struct {
+	Assignee domain.UserID `json:"assignee"`
+}
+
+// SuggestAssignee handles GET /users/suggest-assignee
+func (h *TaskHandler) SuggestAssignee(w http.ResponseWriter, r *http.Request) {
+	assignee, err := h.taskUseCase.SuggestAssignee(r.Context())
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusInternalServerError, "Failed to suggest an assignee", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, SuggestAssigneeResponse{Assignee: assignee})
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// WatcherRequest represents the request body for watching or unwatching a task.
+// An empty user ID refers to the current user.
+type WatcherRequest struct {
+	UserID domain.UserID `json:"user_id"`
+}
+
+// AddWatcher handles POST /tasks/{id}/watchers
+func (h *TaskHandler) AddWatcher(w http.ResponseWriter, r *http.Request) {
+	h.updateWatchers(w, r, true)
+}
+
+// RemoveWatcher handles DELETE /tasks/{id}/watchers
+func (h *TaskHandler) RemoveWatcher(w http.ResponseWriter, r *http.Request) {
+	h.updateWatchers(w, r, false)
+}
+
+func (h *TaskHandler) updateWatchers(w http.ResponseWriter, r *http.Request, watch bool) {
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	var req WatcherRequest
+	if r.ContentLength != 0 {
+		if !h.decodeJSON(w, r, &req) {
+			return
+		}
+	}
+
+	var task *domain.Task
+	if watch {
+		task, err = h.taskUseCase.AddWatcher(r.Context(), domain.TaskID(taskID), req.UserID)
+	} else {
+		task, err = h.taskUseCase.RemoveWatcher(r.Context(), domain.TaskID(taskID), req.UserID)
+	}
+	if err != nil {
+		h.sendUseCaseError(w, http.StatusBadRequest, "Failed to update watchers", err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]interface{}{
+		"task_id":  task.ID,
+		"watchers": task.WatcherIDs(),
+	})
+}
@@ -0,0 +1,92 @@
+// Package middleware contains HTTP middleware shared by the API server
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig lists what cross-origin browser requests may do. Origins must
+// match exactly; there is no wildcard, so with no origins configured every
+// cross-origin request is denied.
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// CORS returns middleware that answers preflight OPTIONS requests and adds
+// CORS headers to requests from allowed origins. It must wrap the router
+// rather than be added to it, as preflight requests match no route.
+// Preflight requests from other origins, or asking for a method or header
+// that is not allowed, are rejected with 403; other requests are served
+// without CORS headers, which makes browsers withhold the response.
+func CORS(config CORSConfig) func(http.Handler) http.Handler {
+	origins := make(map[string]bool, len(config.AllowedOrigins))
+	for _, origin := range config.AllowedOrigins {
+		origins[origin] = true
+	}
+	methods := make(map[string]bool, len(config.AllowedMethods))
+	for _, method := range config.AllowedMethods {
+		methods[strings.ToUpper(method)] = true
+	}
+	headers := make(map[string]bool, len(config.AllowedHeaders))
+	for _, header := range config.AllowedHeaders {
+		headers[http.CanonicalHeaderKey(header)] = true
+	}
+	allowMethods := strings.Join(config.AllowedMethods, ", ")
+	allowHeaders := strings.Join(config.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(config.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Origin")
+
+			requestedMethod := r.Header.Get("Access-Control-Request-Method")
+			if r.Method != http.MethodOptions || requestedMethod == "" {
+				if origins[origin] {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Preflight request
+			if !origins[origin] || !methods[strings.ToUpper(requestedMethod)] ||
+				!allHeadersAllowed(r.Header.Get("Access-Control-Request-Headers"), headers) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+			if allowHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			}
+			if config.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// allHeadersAllowed checks every header of a comma-separated
+// Access-Control-Request-Headers value against the allowed set
+func allHeadersAllowed(requested string, allowed map[string]bool) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !allowed[http.CanonicalHeaderKey(header)] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/metrics"
+	"github.com/bhatti/sample-task-management/internal/repository"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// Invariant guard modes
+const (
+	InvariantModeFailOpen   = "fail-open"
+	InvariantModeFailClosed = "fail-closed"
+)
+
+// InvariantGuard checks the invariants after every request.
+//
+// In fail-open mode a violation is only logged and counted; the response has
+// already been sent. In fail-closed mode each mutating request runs in a scope
+// of the unit of work and its response is held back until the invariants have
+// been checked: on a violation the scope is rolled back and the client gets a
+// 500 instead. Operations outside the scope, such as the retention sweeper or
+// other requests, wait until it ends, so the rollback discards nothing of
+// theirs. The rollback is only meaningful with a transactional UnitOfWork.
+type InvariantGuard struct {
+	uow     repository.UnitOfWork
+	checker *invariants.InvariantChecker
+	mode    string
+}
+
+// NewInvariantGuard creates a guard in the given mode
+func NewInvariantGuard(uow repository.UnitOfWork, checker *invariants.InvariantChecker, mode string) *InvariantGuard {
+	return &InvariantGuard{uow: uow, checker: checker, mode: mode}
+}
+
+// rollbackResponse is the body of a response to a rolled back request
+type rollbackResponse struct {
+	Error   string `json:"error"`
+	Code    string `json:"code"`
+	Details string `json:"details"`
+}
+
+// Middleware checks the invariants after the request, rolling it back in
+// fail-closed mode as described on InvariantGuard
+func (g *InvariantGuard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if g.mode != InvariantModeFailClosed || !isMutating(r) {
+			next.ServeHTTP(w, r)
+			g.check(r.Context())
+			return
+		}
+
+		ctx := g.uow.BeginScope(r.Context())
+		buffered := newBufferedResponseWriter()
+		next.ServeHTTP(buffered, r.WithContext(ctx))
+
+		err := g.check(ctx)
+		g.uow.EndScope(ctx, err != nil)
+		if err != nil {
+			slog.WarnContext(ctx, "request rolled back after invariant violation",
+				"method", r.Method,
+				"path", r.URL.Path)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(rollbackResponse{
+				Error:   "Request rolled back",
+				Code:    "invariant_violation",
+				Details: err.Error(),
+			})
+			return
+		}
+
+		buffered.flushTo(w)
+	})
+}
+
+// check checks the current state, logging violations and liveness warnings
+// with the request ID of ctx, and returns the first violation. Invariants are
+// checked even if the request was cancelled.
+func (g *InvariantGuard) check(ctx context.Context) error {
+	g.uow.RLock(ctx)
+	state, err := g.uow.SystemState().GetSystemState(context.WithoutCancel(ctx))
+	g.uow.RUnlock(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get system state", "error", err)
+		return nil
+	}
+
+	metrics.SetActiveSessions(len(state.Sessions))
+
+	started := time.Now()
+	violation := g.checker.CheckAllInvariants(state)
+	metrics.RecordInvariantCheck(time.Since(started))
+	if violation != nil {
+		metrics.RecordInvariantViolation()
+		slog.ErrorContext(ctx, "invariant violation", "error", violation)
+		// In production, you might want to trigger alerts here
+	}
+
+	// Check liveness properties for monitoring
+	for _, warning := range g.checker.LivenessWarnings(state) {
+		slog.WarnContext(ctx, "liveness warning",
+			"kind", warning.Kind,
+			"task_id", warning.TaskID,
+			"message", warning.Message)
+	}
+
+	return violation
+}
+
+// isMutating reports whether the request may change state
+func isMutating(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// bufferedResponseWriter holds a response back until it is flushed
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: make(http.Header), status: http.StatusOK}
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return b.body.Write(data)
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	b.status = status
+}
+
+// flushTo sends the buffered response to w
+func (b *bufferedResponseWriter) flushTo(w http.ResponseWriter) {
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(b.status)
+	w.Write(b.body.Bytes())
+}
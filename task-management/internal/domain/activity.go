@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// ActivityID identifies an activity entry. IDs are assigned in recording
+// order and never reused, so a larger ID is always a later entry.
+type ActivityID int64
+
+// Activity is an entry of the activity log, the persisted form of a task event
+type Activity struct {
+	ID        ActivityID        `json:"id"`
+	Action    EventType         `json:"action"`
+	TaskID    TaskID            `json:"task_id"`
+	Actor     UserID            `json:"actor,omitempty"`
+	Data      map[string]string `json:"data,omitempty"`
+	At        time.Time         `json:"at"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+// NewActivity returns the activity entry recording an event; the ID is
+// assigned when it is recorded
+func NewActivity(event Event) *Activity {
+	activity := &Activity{
+		Action:    event.Type,
+		TaskID:    event.TaskID,
+		Actor:     event.Actor,
+		At:        event.Timestamp,
+		RequestID: event.RequestID,
+	}
+	if len(event.Data) > 0 {
+		activity.Data = make(map[string]string, len(event.Data))
+		for key, value := range event.Data {
+			activity.Data[key] = value
+		}
+	}
+	return activity
+}
+
+// ActivityFilter selects activity entries; zero fields match every entry
+type ActivityFilter struct {
+	Actor  UserID
+	TaskID TaskID
+	Action EventType
+	// Before keeps only entries older than the given one. Paging with the
+	// last ID of the previous page is not thrown off by newer entries.
+	Before ActivityID
+}
+
+// Matches reports whether the entry passes the filter
+func (f ActivityFilter) Matches(activity *Activity) bool {
+	if f.Actor != "" && activity.Actor != f.Actor {
+		return false
+	}
+	if f.TaskID != 0 && activity.TaskID != f.TaskID {
+		return false
+	}
+	if f.Action != "" && activity.Action != f.Action {
+		return false
+	}
+	return f.Before == 0 || activity.ID < f.Before
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// AssignmentChange records a task being reassigned from one user to another
+type AssignmentChange struct {
+	From UserID    `json:"from"`
+	To   UserID    `json:"to"`
+	By   UserID    `json:"by"`
+	At   time.Time `json:"at"`
+}
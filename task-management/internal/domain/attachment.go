@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AttachmentID represents a unique attachment identifier
+type AttachmentID int
+
+// Attachment links a file stored elsewhere to a task; only its metadata is kept
+type Attachment struct {
+	ID         AttachmentID `json:"id"`
+	TaskID     TaskID       `json:"task_id"`
+	Filename   string       `json:"filename"`
+	URL        string       `json:"url"`
+	Size       int64        `json:"size"`
+	UploadedBy UserID       `json:"uploaded_by"`
+	UploadedAt time.Time    `json:"uploaded_at"`
+}
+
+// Validate performs domain validation on the attachment
+func (a *Attachment) Validate() error {
+	if a.TaskID < 1 {
+		return fmt.Errorf("attachment must reference a task")
+	}
+	if a.UploadedBy == "" {
+		return fmt.Errorf("attachment must have an uploader")
+	}
+	if a.Filename == "" {
+		return fmt.Errorf("attachment filename cannot be empty")
+	}
+	if a.URL == "" {
+		return fmt.Errorf("attachment URL cannot be empty")
+	}
+	if a.Size < 0 {
+		return fmt.Errorf("attachment size cannot be negative")
+	}
+	return nil
+}
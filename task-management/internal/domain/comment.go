@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CommentID represents a unique comment identifier
+type CommentID int
+
+// Comment represents a discussion entry on a task
+type Comment struct {
+	ID        CommentID `json:"id"`
+	TaskID    TaskID    `json:"task_id"`
+	Author    UserID    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate performs domain validation on the comment
+func (c *Comment) Validate() error {
+	if c.TaskID < 1 {
+		return fmt.Errorf("comment must reference a task")
+	}
+	if c.Author == "" {
+		return fmt.Errorf("comment must have an author")
+	}
+	if c.Body == "" {
+		return fmt.Errorf("comment body cannot be empty")
+	}
+	return nil
+}
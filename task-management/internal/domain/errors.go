@@ -0,0 +1,48 @@
+package domain
+
+import "errors"
+
+// Sentinel errors returned (possibly wrapped) by domain validation and use cases.
+// Callers should match them with errors.Is.
+var (
+	// Task validation
+	ErrTitleEmpty         = errors.New("task title cannot be empty")
+	ErrDescriptionEmpty   = errors.New("task description cannot be empty")
+	ErrTitleTooLong       = errors.New("task title too long")
+	ErrDescriptionTooLong = errors.New("task description too long")
+	ErrInvalidStatus      = errors.New("invalid task status")
+	ErrInvalidPriority    = errors.New("invalid task priority")
+	ErrAssigneeRequired   = errors.New("task must have an assignee")
+	ErrCreatorRequired    = errors.New("task must have a creator")
+	ErrInvalidTimestamps  = errors.New("created time cannot be after updated time")
+	ErrInvalidTag         = errors.New("invalid tag")
+	ErrInvalidLabel       = errors.New("invalid label")
+	ErrInvalidRank        = errors.New("invalid rank")
+	ErrNegativeHours      = errors.New("hours cannot be negative")
+	ErrInvalidRecurrence  = errors.New("invalid recurrence")
+	ErrDueDateInPast      = errors.New("due date is in the past")
+	ErrSnoozeInPast       = errors.New("snooze date is not in the future")
+
+	// Dependencies
+	ErrInvalidDependency = errors.New("invalid dependency")
+	ErrCyclicDependency  = errors.New("cyclic dependency detected")
+	ErrDependencyTooDeep = errors.New("dependency chain too deep")
+	ErrInvalidParent     = errors.New("invalid parent task")
+
+	// Operations
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrInvalidCredentials = errors.New("invalid user ID or password")
+	ErrTaskNotFound       = errors.New("task not found")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrDuplicateEmail     = errors.New("email already in use")
+	ErrDuplicateUser      = errors.New("user already exists")
+	ErrMaxTasksReached    = errors.New("maximum number of tasks")
+	ErrInvalidTransition  = errors.New("invalid transition")
+	ErrInvariantViolation = errors.New("invariant violation")
+	ErrInvalidSortKey     = errors.New("invalid sort key")
+	ErrReasonRequired     = errors.New("a reason is required")
+	ErrInvalidPage        = errors.New("invalid page")
+
+	// Persistence
+	ErrReadOnly = errors.New("repository is read-only")
+)
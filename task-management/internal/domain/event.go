@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// EventType identifies the kind of a domain event
+type EventType string
+
+const (
+	EventTaskCreated       EventType = "task.created"
+	EventTaskStatusChanged EventType = "task.status_changed"
+	EventTaskReassigned    EventType = "task.reassigned"
+	EventTaskEscalated     EventType = "task.escalated"
+	EventTaskReopened      EventType = "task.reopened"
+	EventTaskSnoozed       EventType = "task.snoozed"
+	// EventTaskUnblocked follows the status change of a blocked task whose
+	// dependencies are all completed; its "dependency" names the one that
+	// made it ready
+	EventTaskUnblocked EventType = "task.unblocked"
+)
+
+// Event represents something that happened to a task that users may be notified about
+type Event struct {
+	Type       EventType         `json:"type"`
+	TaskID     TaskID            `json:"task_id"`
+	Assignee   UserID            `json:"assignee"`
+	Actor      UserID            `json:"actor,omitempty"`
+	Recipients []UserID          `json:"recipients"`
+	Data       map[string]string `json:"data,omitempty"`
+	Timestamp  time.Time         `json:"timestamp"`
+
+	// RequestID correlates the event with the request that caused it; it is
+	// empty for events raised outside a request
+	RequestID string `json:"request_id,omitempty"`
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+// Default field length limits, in characters
+const (
+	DefaultMaxTitleLength       = 200
+	DefaultMaxDescriptionLength = 10000
+)
+
+// FieldLimits bounds the length, in characters, of task text fields.
+// Deployments may replace the defaults at startup with SetFieldLimits.
+type FieldLimits struct {
+	MaxTitleLength       int `json:"max_title_length"`
+	MaxDescriptionLength int `json:"max_description_length"`
+}
+
+// DefaultFieldLimits returns the built-in field length limits
+func DefaultFieldLimits() FieldLimits {
+	return FieldLimits{
+		MaxTitleLength:       DefaultMaxTitleLength,
+		MaxDescriptionLength: DefaultMaxDescriptionLength,
+	}
+}
+
+// Validate checks every limit is positive
+func (l FieldLimits) Validate() error {
+	if l.MaxTitleLength < 1 {
+		return fmt.Errorf("maximum title length must be positive, got %d", l.MaxTitleLength)
+	}
+	if l.MaxDescriptionLength < 1 {
+		return fmt.Errorf("maximum description length must be positive, got %d", l.MaxDescriptionLength)
+	}
+	return nil
+}
+
+var fieldLimits = struct {
+	mu     sync.RWMutex
+	limits FieldLimits
+}{limits: DefaultFieldLimits()}
+
+// SetFieldLimits replaces the field length limits. Like SetVocabulary it is
+// meant to be called once at startup; stored tasks exceeding lower limits
+// fail validation afterwards.
+func SetFieldLimits(limits FieldLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	fieldLimits.mu.Lock()
+	defer fieldLimits.mu.Unlock()
+	fieldLimits.limits = limits
+	return nil
+}
+
+// CurrentFieldLimits returns the field length limits in effect
+func CurrentFieldLimits() FieldLimits {
+	fieldLimits.mu.RLock()
+	defer fieldLimits.mu.RUnlock()
+	return fieldLimits.limits
+}
+
+// validateTextLengths checks the title and description against the limits
+// in effect
+func validateTextLengths(title, description string) error {
+	limits := CurrentFieldLimits()
+	if n := utf8.RuneCountInString(title); n > limits.MaxTitleLength {
+		return fmt.Errorf("%w: %d characters exceed the limit of %d", ErrTitleTooLong, n, limits.MaxTitleLength)
+	}
+	if n := utf8.RuneCountInString(description); n > limits.MaxDescriptionLength {
+		return fmt.Errorf("%w: %d characters exceed the limit of %d", ErrDescriptionTooLong, n, limits.MaxDescriptionLength)
+	}
+	return nil
+}
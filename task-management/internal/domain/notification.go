@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// Notification is a message addressed to a single user
+type Notification struct {
+	UserID    UserID    `json:"user_id"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
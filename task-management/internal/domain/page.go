@@ -0,0 +1,8 @@
+package domain
+
+// Page selects part of a listing: up to Limit entries after skipping Offset
+// of them
+type Page struct {
+	Offset int
+	Limit  int
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// StatusChange records a task entering a status
+type StatusChange struct {
+	Status TaskStatus `json:"status"`
+	At     time.Time  `json:"at"`
+}
+
+// StatusDurations sums the time spent in each status over a chronological
+// status history. The last status counts until now; statuses the task never
+// entered are absent.
+func StatusDurations(history []StatusChange, now time.Time) map[TaskStatus]time.Duration {
+	durations := make(map[TaskStatus]time.Duration)
+	for i, change := range history {
+		end := now
+		if i+1 < len(history) {
+			end = history[i+1].At
+		}
+		if end.After(change.At) {
+			durations[change.Status] += end.Sub(change.At)
+		} else if _, seen := durations[change.Status]; !seen {
+			durations[change.Status] = 0
+		}
+	}
+	return durations
+}
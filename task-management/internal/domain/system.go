@@ -10,6 +10,7 @@ type SystemState struct {
 	CurrentUser *UserID               `json:"current_user"` // Maps to TLA+ currentUser
 	Clock       time.Time             `json:"clock"`        // Maps to TLA+ clock
 	Sessions    map[UserID]*Session   `json:"sessions"`     // Maps to TLA+ sessions
+	Users       map[UserID]*User      `json:"users"`        // Maps to TLA+ Users
 }
 
 // NewSystemState creates a new initial system state (maps to TLA+ Init)
@@ -21,6 +22,7 @@ func NewSystemState() *SystemState {
 		CurrentUser: nil,
 		Clock:       time.Now(),
 		Sessions:    make(map[UserID]*Session),
+		Users:       make(map[UserID]*User),
 	}
 }
 
@@ -38,6 +40,12 @@ func (s *SystemState) TaskExists(taskID TaskID) bool {
 	return exists
 }
 
+// UserExists checks if a user is known to the system (maps to TLA+ u \in Users)
+func (s *SystemState) UserExists(userID UserID) bool {
+	_, exists := s.Users[userID]
+	return exists
+}
+
 // IsAuthenticated checks if there's a current authenticated user
 func (s *SystemState) IsAuthenticated() bool {
 	return s.CurrentUser != nil
@@ -51,13 +59,14 @@ func (s *SystemState) GetCurrentUser() UserID {
 	return *s.CurrentUser
 }
 
-// AdvanceClock advances the system clock (maps to TLA+ AdvanceTime)
-func (s *SystemState) AdvanceClock() {
-	s.Clock = time.Now()
+// AdvanceClock advances the system clock to the clock's current time
+// (maps to TLA+ AdvanceTime)
+func (s *SystemState) AdvanceClock(clock Clock) {
+	s.Clock = clock.Now()
 }
 
 // Constants matching TLA+ CONSTANTS
 const (
-	MaxTasks = 1000 // Maximum number of tasks in the system
+	MaxTasks = 1000 // Default maximum number of tasks in the system
 	MaxTime  = 365  // Maximum time in days for simulation
 )
\ No newline at end of file
@@ -3,6 +3,10 @@ package domain
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -33,6 +37,38 @@ const (
 	PriorityCritical Priority = "critical"
 )
 
+// Rank orders priorities by their position in the vocabulary, from the lowest
+// (0) up; with the defaults low ranks 0 and critical 3. Unknown priorities rank -1.
+func (p Priority) Rank() int {
+	rank, exists := priorityRank(p)
+	if !exists {
+		return -1
+	}
+	return rank
+}
+
+// Weight is a numeric score for ordering priorities, higher meaning more
+// urgent: the rank counted from 1, so with the defaults low weighs 1 and
+// critical 4. Unknown priorities weigh 0.
+func (p Priority) Weight() int {
+	return p.Rank() + 1
+}
+
+// Escalated returns the next priority level up; the highest priority, and any
+// unknown priority, escalates to the highest
+func (p Priority) Escalated() Priority {
+	rank, exists := priorityRank(p)
+	if !exists {
+		return highestPriority()
+	}
+	return priorityAt(rank + 1)
+}
+
+// IsHighest reports whether p is the highest priority of the vocabulary
+func (p Priority) IsHighest() bool {
+	return p == highestPriority()
+}
+
 // Tag represents task categories (maps to TLA+ tags subset)
 type Tag string
 
@@ -43,6 +79,30 @@ const (
 	TagDocumentation Tag = "documentation"
 )
 
+// Recurrence describes how often a task regenerates once completed
+type Recurrence string
+
+const (
+	RecurrenceNone    Recurrence = "none"
+	RecurrenceDaily   Recurrence = "daily"
+	RecurrenceWeekly  Recurrence = "weekly"
+	RecurrenceMonthly Recurrence = "monthly"
+)
+
+// Next returns the time one recurrence interval after t
+func (r Recurrence) Next(t time.Time) time.Time {
+	switch r {
+	case RecurrenceDaily:
+		return t.AddDate(0, 0, 1)
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7)
+	case RecurrenceMonthly:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t
+	}
+}
+
 // Task represents a task entity (maps to TLA+ task record)
 type Task struct {
 	ID           TaskID            `json:"id"`
@@ -57,6 +117,58 @@ type Task struct {
 	DueDate      *time.Time        `json:"due_date,omitempty"`
 	Tags         []Tag             `json:"tags"`
 	Dependencies map[TaskID]bool   `json:"dependencies"`
+	Watchers     map[UserID]bool   `json:"watchers,omitempty"`
+	
+	// Collaborators share ownership of the task with the assignee
+	Collaborators []UserID `json:"collaborators,omitempty"`
+	
+	EstimatedHours float64 `json:"estimated_hours"`
+	ActualHours    float64 `json:"actual_hours"`
+	
+	// ParentID makes the task a subtask of another, whose effort rolls up
+	// its subtasks'; zero for a top-level task
+	ParentID TaskID `json:"parent_id,omitempty"`
+	
+	// Archived tasks are soft-deleted: retained but hidden from listings
+	// and removed from their owners' task lists
+	Archived bool `json:"archived,omitempty"`
+	
+	// Recurrence regenerates the task on completion; RecurredFrom links a
+	// regenerated task to the one it replaced
+	Recurrence   Recurrence `json:"recurrence,omitempty"`
+	RecurredFrom TaskID     `json:"recurred_from,omitempty"`
+	
+	// Labels are free-form key-value metadata such as sprint=12, alongside
+	// the fixed set of tags
+	Labels map[string]string `json:"labels,omitempty"`
+	
+	// Rank orders the task within its assignee's backlog, lowest first
+	Rank float64 `json:"rank"`
+	
+	// SnoozedUntil hides the task from listings until the given time
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+}
+
+// RankGap separates the ranks of consecutive tasks in a backlog. Moving a task
+// between two others takes the midpoint of their ranks, so many moves fit
+// before the backlog has to be renumbered.
+const RankGap = 1024.0
+
+// MaxLabels bounds the number of labels on a task
+const MaxLabels = 20
+
+// ValidateLabels checks that every label key is non-empty and that there
+// are at most MaxLabels labels
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("%w: %d labels exceed the limit of %d", ErrInvalidLabel, len(labels), MaxLabels)
+	}
+	for key := range labels {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("%w: label key cannot be empty", ErrInvalidLabel)
+		}
+	}
+	return nil
 }
 
 // ValidTransition represents a valid state transition (maps to TLA+ ValidTransitions)
@@ -79,9 +191,135 @@ var ValidTransitions = map[ValidTransition]bool{
 	{StatusBlocked, StatusCancelled}:     true,
 }
 
-// IsValidTransition checks if a state transition is valid (maps to TLA+ IsValidTransition)
+// IsValidTransition checks if a state transition is valid under the default policy
+// (maps to TLA+ IsValidTransition)
 func IsValidTransition(from, to TaskStatus) bool {
-	return ValidTransitions[ValidTransition{From: from, To: to}]
+	return DefaultTransitionPolicy().IsValid(from, to)
+}
+
+// WatcherIDs returns the users following the task
+func (t *Task) WatcherIDs() []UserID {
+	watchers := make([]UserID, 0, len(t.Watchers))
+	for userID := range t.Watchers {
+		watchers = append(watchers, userID)
+	}
+	sort.Slice(watchers, func(i, j int) bool { return watchers[i] < watchers[j] })
+	return watchers
+}
+
+// Owners returns the assignee followed by the collaborators, without duplicates
+func (t *Task) Owners() []UserID {
+	owners := make([]UserID, 0, len(t.Collaborators)+1)
+	owners = append(owners, t.Assignee)
+	for _, userID := range t.Collaborators {
+		if !containsUser(owners, userID) {
+			owners = append(owners, userID)
+		}
+	}
+	return owners
+}
+
+// IsOwner checks if the user is the task's assignee or one of its collaborators
+func (t *Task) IsOwner(userID UserID) bool {
+	return t.Assignee == userID || containsUser(t.Collaborators, userID)
+}
+
+func containsUser(users []UserID, userID UserID) bool {
+	for _, id := range users {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag reports whether the task carries the tag
+func (t *Task) HasTag(tag Tag) bool {
+	for _, own := range t.Tags {
+		if own == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRecurring checks if the task regenerates on completion
+func (t *Task) IsRecurring() bool {
+	return t.Recurrence != "" && t.Recurrence != RecurrenceNone
+}
+
+// NextOccurrence returns a fresh pending copy of a recurring task with the
+// given ID, keeping its owners, priority, tags and watchers and shifting
+// the due date by one recurrence interval
+func (t *Task) NextOccurrence(id TaskID, now time.Time) *Task {
+	next := &Task{
+		ID:             id,
+		Title:          t.Title,
+		Description:    t.Description,
+		Status:         StatusPending,
+		Priority:       t.Priority,
+		Assignee:       t.Assignee,
+		CreatedBy:      t.CreatedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		Tags:           append([]Tag(nil), t.Tags...),
+		Dependencies:   make(map[TaskID]bool),
+		Collaborators:  append([]UserID(nil), t.Collaborators...),
+		EstimatedHours: t.EstimatedHours,
+		Recurrence:     t.Recurrence,
+		RecurredFrom:   t.ID,
+		Rank:           t.Rank,
+	}
+	if len(t.Labels) > 0 {
+		next.Labels = make(map[string]string, len(t.Labels))
+		for key, value := range t.Labels {
+			next.Labels[key] = value
+		}
+	}
+	if t.DueDate != nil {
+		dueDate := t.Recurrence.Next(*t.DueDate)
+		next.DueDate = &dueDate
+	}
+	if len(t.Watchers) > 0 {
+		next.Watchers = make(map[UserID]bool, len(t.Watchers))
+		for userID := range t.Watchers {
+			next.Watchers[userID] = true
+		}
+	}
+	return next
+}
+
+// IsDone checks if the task has reached a final status (completed or cancelled)
+func (t *Task) IsDone() bool {
+	return t.Status == StatusCompleted || t.Status == StatusCancelled
+}
+
+// IsOverdueAt checks if the task was due before now and is not done yet.
+// Both sides are compared in UTC, so the zone of now does not matter.
+func (t *Task) IsOverdueAt(now time.Time) bool {
+	return t.DueDate != nil && now.UTC().After(t.DueDate.UTC()) && !t.IsDone()
+}
+
+// NormalizeDueDate returns a copy of the due date in UTC, the zone due dates
+// are stored in whatever offset they were submitted with; nil stays nil
+func NormalizeDueDate(dueDate *time.Time) *time.Time {
+	if dueDate == nil {
+		return nil
+	}
+	utc := dueDate.UTC()
+	return &utc
+}
+
+// IsSnoozedAt checks if the task is hidden from listings at the given time
+func (t *Task) IsSnoozedAt(now time.Time) bool {
+	return t.SnoozedUntil != nil && now.Before(*t.SnoozedUntil)
+}
+
+// SnoozeElapsedAt checks if the task's snooze has run out by the given time
+// without the task being updated since, so nobody has picked it up again
+func (t *Task) SnoozeElapsedAt(now time.Time) bool {
+	return t.SnoozedUntil != nil && !t.IsSnoozedAt(now) &&
+		t.UpdatedAt.Before(*t.SnoozedUntil) && !t.IsDone()
 }
 
 // CanDelete checks if a task can be deleted (only completed or cancelled)
@@ -105,6 +343,60 @@ func (t *Task) IsBlocked(allTasks map[TaskID]*Task) bool {
 	return false
 }
 
+// DependencyProgress returns the fraction of dependencies that are completed.
+// A task without dependencies is fully ready (1.0); dependencies missing from
+// allTasks count as incomplete.
+func (t *Task) DependencyProgress(allTasks map[TaskID]*Task) float64 {
+	if len(t.Dependencies) == 0 {
+		return 1.0
+	}
+
+	completed := 0
+	for depID := range t.Dependencies {
+		if dep, exists := allTasks[depID]; exists && dep.Status == StatusCompleted {
+			completed++
+		}
+	}
+	return float64(completed) / float64(len(t.Dependencies))
+}
+
+// IncompleteDependencies returns the sorted IDs of the task's dependencies that
+// are not yet completed; dependencies missing from allTasks are skipped, as in
+// ShouldUnblock
+func (t *Task) IncompleteDependencies(allTasks map[TaskID]*Task) []TaskID {
+	var incomplete []TaskID
+	for depID := range t.Dependencies {
+		if dep, exists := allTasks[depID]; exists && dep.Status != StatusCompleted {
+			incomplete = append(incomplete, depID)
+		}
+	}
+	sort.Slice(incomplete, func(i, j int) bool { return incomplete[i] < incomplete[j] })
+	return incomplete
+}
+
+// SortedTaskIDs returns the IDs in a task ID set in ascending order
+func SortedTaskIDs(ids map[TaskID]bool) []TaskID {
+	sorted := make([]TaskID, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// DependencyCycle is a loop in the dependency graph: each task depends on the
+// next, and the last task is the first again
+type DependencyCycle []TaskID
+
+// String renders the cycle as "3 → 5 → 3"
+func (c DependencyCycle) String() string {
+	ids := make([]string, len(c))
+	for i, id := range c {
+		ids[i] = strconv.Itoa(int(id))
+	}
+	return strings.Join(ids, " → ")
+}
+
 // ShouldUnblock checks if a blocked task can be unblocked
 func (t *Task) ShouldUnblock(allTasks map[TaskID]*Task) bool {
 	if t.Status != StatusBlocked {
@@ -121,34 +413,58 @@ func (t *Task) ShouldUnblock(allTasks map[TaskID]*Task) bool {
 	return true
 }
 
-// Validate performs domain validation on the task
+// Validate performs domain validation on the task using the default transition policy
 func (t *Task) Validate() error {
+	return t.ValidateWithPolicy(DefaultTransitionPolicy())
+}
+
+// ValidateWithPolicy performs domain validation on the task, accepting the
+// statuses introduced by the given transition policy
+func (t *Task) ValidateWithPolicy(policy *TransitionPolicy) error {
 	if t.Title == "" {
-		return fmt.Errorf("task title cannot be empty")
+		return ErrTitleEmpty
 	}
 	if t.Description == "" {
-		return fmt.Errorf("task description cannot be empty")
+		return ErrDescriptionEmpty
 	}
-	if !isValidStatus(t.Status) {
-		return fmt.Errorf("invalid task status: %s", t.Status)
+	if err := validateTextLengths(t.Title, t.Description); err != nil {
+		return err
+	}
+	if !policy.HasStatus(t.Status) {
+		return fmt.Errorf("%w: %s", ErrInvalidStatus, t.Status)
 	}
 	if !isValidPriority(t.Priority) {
-		return fmt.Errorf("invalid task priority: %s", t.Priority)
+		return fmt.Errorf("%w: %s", ErrInvalidPriority, t.Priority)
 	}
 	if t.Assignee == "" {
-		return fmt.Errorf("task must have an assignee")
+		return ErrAssigneeRequired
 	}
 	if t.CreatedBy == "" {
-		return fmt.Errorf("task must have a creator")
+		return ErrCreatorRequired
 	}
 	if t.CreatedAt.After(t.UpdatedAt) {
-		return fmt.Errorf("created time cannot be after updated time")
+		return ErrInvalidTimestamps
+	}
+	if t.EstimatedHours < 0 {
+		return fmt.Errorf("estimated %w", ErrNegativeHours)
+	}
+	if t.ActualHours < 0 {
+		return fmt.Errorf("actual %w", ErrNegativeHours)
+	}
+	if !isValidRecurrence(t.Recurrence) {
+		return fmt.Errorf("%w: %s", ErrInvalidRecurrence, t.Recurrence)
 	}
 	for _, tag := range t.Tags {
 		if !isValidTag(tag) {
-			return fmt.Errorf("invalid tag: %s", tag)
+			return fmt.Errorf("%w: %s", ErrInvalidTag, tag)
 		}
 	}
+	if err := ValidateLabels(t.Labels); err != nil {
+		return err
+	}
+	if math.IsNaN(t.Rank) || math.IsInf(t.Rank, 0) {
+		return fmt.Errorf("%w: %v", ErrInvalidRank, t.Rank)
+	}
 	return nil
 }
 
@@ -161,20 +477,11 @@ func isValidStatus(status TaskStatus) bool {
 	}
 }
 
-func isValidPriority(priority Priority) bool {
-	switch priority {
-	case PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical:
+func isValidRecurrence(recurrence Recurrence) bool {
+	switch recurrence {
+	case "", RecurrenceNone, RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
 		return true
 	default:
 		return false
 	}
 }
-
-func isValidTag(tag Tag) bool {
-	switch tag {
-	case TagBug, TagFeature, TagEnhancement, TagDocumentation:
-		return true
-	default:
-		return false
-	}
-}
\ No newline at end of file
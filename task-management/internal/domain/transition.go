@@ -0,0 +1,40 @@
+package domain
+
+// TransitionPolicy defines the status workflow of tasks: which transitions are
+// allowed and, through them, which statuses exist beyond the built-in ones
+type TransitionPolicy struct {
+	transitions map[ValidTransition]bool
+	statuses    map[TaskStatus]bool
+}
+
+// NewTransitionPolicy creates a policy allowing exactly the given transitions
+func NewTransitionPolicy(transitions ...ValidTransition) *TransitionPolicy {
+	policy := &TransitionPolicy{
+		transitions: make(map[ValidTransition]bool, len(transitions)),
+		statuses:    make(map[TaskStatus]bool),
+	}
+	for _, t := range transitions {
+		policy.transitions[t] = true
+		policy.statuses[t.From] = true
+		policy.statuses[t.To] = true
+	}
+	return policy
+}
+
+// defaultTransitionPolicy wraps ValidTransitions directly so it reflects the global map
+var defaultTransitionPolicy = &TransitionPolicy{transitions: ValidTransitions}
+
+// DefaultTransitionPolicy returns the policy matching the TLA+ ValidTransitions
+func DefaultTransitionPolicy() *TransitionPolicy {
+	return defaultTransitionPolicy
+}
+
+// IsValid checks if the policy allows moving from one status to another
+func (p *TransitionPolicy) IsValid(from, to TaskStatus) bool {
+	return p.transitions[ValidTransition{From: from, To: to}]
+}
+
+// HasStatus checks if a status is known, either built-in or introduced by the policy
+func (p *TransitionPolicy) HasStatus(status TaskStatus) bool {
+	return isValidStatus(status) || p.statuses[status]
+}
@@ -2,7 +2,18 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Role represents the authorization level of a user
+type Role string
+
+const (
+	RoleMember Role = "member"
+	RoleAdmin  Role = "admin"
 )
 
 // User represents a system user (maps to TLA+ Users)
@@ -10,7 +21,41 @@ type User struct {
 	ID       UserID    `json:"id"`
 	Name     string    `json:"name"`
 	Email    string    `json:"email"`
+	Role     Role      `json:"role,omitempty"`
 	JoinedAt time.Time `json:"joined_at"`
+	// PasswordHash is the bcrypt hash of the user's password, empty when the
+	// user has none. It is never serialized, so neither API responses nor
+	// exported state carry credentials.
+	PasswordHash string `json:"-"`
+}
+
+// HashPassword returns the bcrypt hash of a password for User.PasswordHash
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether the password matches the user's hash. A user
+// without a password matches none.
+func (u *User) CheckPassword(password string) bool {
+	if u.PasswordHash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// NormalizeEmail returns the canonical form of an email address used to
+// look users up: surrounding whitespace removed and lower-cased
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsAdmin checks if the user can manage any task regardless of ownership
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
 }
 
 // Session represents an active user session (maps to TLA+ sessions)
@@ -22,14 +67,24 @@ type Session struct {
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
-// IsExpired checks if the session has expired
+// IsExpired checks if the session has expired by the current system time
 func (s *Session) IsExpired() bool {
-	return time.Now().After(s.ExpiresAt)
+	return s.IsExpiredAt(time.Now())
 }
 
-// IsValid checks if the session is valid
+// IsExpiredAt checks if the session has expired at the given time
+func (s *Session) IsExpiredAt(now time.Time) bool {
+	return now.After(s.ExpiresAt)
+}
+
+// IsValid checks if the session is valid at the current system time
 func (s *Session) IsValid() bool {
-	return s.Active && !s.IsExpired()
+	return s.IsValidAt(time.Now())
+}
+
+// IsValidAt checks if the session is active and unexpired at the given time
+func (s *Session) IsValidAt(now time.Time) bool {
+	return s.Active && !s.IsExpiredAt(now)
 }
 
 // Validate performs domain validation on the user
@@ -43,5 +98,78 @@ func (u *User) Validate() error {
 	if u.Email == "" {
 		return fmt.Errorf("user email cannot be empty")
 	}
+	if u.Role != "" && !isValidRole(u.Role) {
+		return fmt.Errorf("invalid user role: %s", u.Role)
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+func isValidRole(role Role) bool {
+	switch role {
+	case RoleMember, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// UserSort selects the order of user listings. Users tied on the sort key,
+// and all users by default, are ordered by ID.
+type UserSort string
+
+const (
+	UserSortByID       UserSort = ""
+	UserSortByName     UserSort = "name"
+	UserSortByJoinedAt UserSort = "joined_at"
+)
+
+// UserFilter selects and orders the users of a listing
+type UserFilter struct {
+	// Role keeps only users with the role; users without one are members
+	Role Role
+	// JoinedAfter, when set, keeps only users who joined after it
+	JoinedAfter time.Time
+	Sort        UserSort
+}
+
+// Validate rejects unknown roles and sort keys
+func (f UserFilter) Validate() error {
+	if f.Role != "" && !isValidRole(f.Role) {
+		return fmt.Errorf("invalid user role: %s", f.Role)
+	}
+	switch f.Sort {
+	case UserSortByID, UserSortByName, UserSortByJoinedAt:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidSortKey, f.Sort)
+	}
+}
+
+// Matches reports whether the user passes the filter
+func (f UserFilter) Matches(user *User) bool {
+	if f.Role != "" {
+		role := user.Role
+		if role == "" {
+			role = RoleMember
+		}
+		if role != f.Role {
+			return false
+		}
+	}
+	return f.JoinedAfter.IsZero() || user.JoinedAt.After(f.JoinedAfter)
+}
+
+// Less reports whether a is listed before b. Names compare case-insensitively.
+func (f UserFilter) Less(a, b *User) bool {
+	switch f.Sort {
+	case UserSortByName:
+		if nameA, nameB := strings.ToLower(a.Name), strings.ToLower(b.Name); nameA != nameB {
+			return nameA < nameB
+		}
+	case UserSortByJoinedAt:
+		if !a.JoinedAt.Equal(b.JoinedAt) {
+			return a.JoinedAt.Before(b.JoinedAt)
+		}
+	}
+	return a.ID < b.ID
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Vocabulary defines the priorities, ordered from lowest to highest, and the
+// tags tasks may use. Deployments may replace the defaults at startup with
+// SetVocabulary.
+type Vocabulary struct {
+	Priorities []Priority `json:"priorities"`
+	Tags       []Tag      `json:"tags"`
+}
+
+// DefaultVocabulary returns the built-in priorities and tags
+func DefaultVocabulary() Vocabulary {
+	return Vocabulary{
+		Priorities: []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical},
+		Tags:       []Tag{TagBug, TagFeature, TagEnhancement, TagDocumentation},
+	}
+}
+
+// Validate checks the vocabulary has at least one priority and no empty or
+// duplicate entries; an empty tag list allows no tags at all
+func (v Vocabulary) Validate() error {
+	if len(v.Priorities) == 0 {
+		return fmt.Errorf("%w: at least one priority is required", ErrInvalidPriority)
+	}
+	seenPriorities := make(map[Priority]bool, len(v.Priorities))
+	for _, priority := range v.Priorities {
+		if priority == "" || seenPriorities[priority] {
+			return fmt.Errorf("%w: empty or duplicate priority %q", ErrInvalidPriority, priority)
+		}
+		seenPriorities[priority] = true
+	}
+	seenTags := make(map[Tag]bool, len(v.Tags))
+	for _, tag := range v.Tags {
+		if tag == "" || seenTags[tag] {
+			return fmt.Errorf("%w: empty or duplicate tag %q", ErrInvalidTag, tag)
+		}
+		seenTags[tag] = true
+	}
+	return nil
+}
+
+// vocabularyRegistry holds the vocabulary in effect, indexed for lookups
+type vocabularyRegistry struct {
+	mu         sync.RWMutex
+	vocabulary Vocabulary
+	ranks      map[Priority]int
+	tags       map[Tag]bool
+}
+
+var vocabulary = newVocabularyRegistry(DefaultVocabulary())
+
+func newVocabularyRegistry(v Vocabulary) *vocabularyRegistry {
+	registry := &vocabularyRegistry{}
+	registry.set(v)
+	return registry
+}
+
+func (r *vocabularyRegistry) set(v Vocabulary) {
+	v = Vocabulary{
+		Priorities: append([]Priority(nil), v.Priorities...),
+		Tags:       append([]Tag(nil), v.Tags...),
+	}
+	ranks := make(map[Priority]int, len(v.Priorities))
+	for i, priority := range v.Priorities {
+		ranks[priority] = i
+	}
+	tags := make(map[Tag]bool, len(v.Tags))
+	for _, tag := range v.Tags {
+		tags[tag] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.vocabulary = v
+	r.ranks = ranks
+	r.tags = tags
+}
+
+// SetVocabulary replaces the priorities and tags tasks may use. It is meant to
+// be called once at startup, before any task is created; tasks stored with a
+// priority or tag the new vocabulary lacks fail validation afterwards.
+func SetVocabulary(v Vocabulary) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	vocabulary.set(v)
+	return nil
+}
+
+// CurrentVocabulary returns a copy of the vocabulary in effect
+func CurrentVocabulary() Vocabulary {
+	vocabulary.mu.RLock()
+	defer vocabulary.mu.RUnlock()
+	return Vocabulary{
+		Priorities: append([]Priority(nil), vocabulary.vocabulary.Priorities...),
+		Tags:       append([]Tag(nil), vocabulary.vocabulary.Tags...),
+	}
+}
+
+// priorityRank returns the position of the priority in the vocabulary
+func priorityRank(priority Priority) (int, bool) {
+	vocabulary.mu.RLock()
+	defer vocabulary.mu.RUnlock()
+	rank, exists := vocabulary.ranks[priority]
+	return rank, exists
+}
+
+// priorityAt returns the priority at the given rank, clamped to the highest
+func priorityAt(rank int) Priority {
+	vocabulary.mu.RLock()
+	defer vocabulary.mu.RUnlock()
+	priorities := vocabulary.vocabulary.Priorities
+	if rank >= len(priorities) {
+		rank = len(priorities) - 1
+	}
+	return priorities[rank]
+}
+
+// highestPriority returns the last priority of the vocabulary
+func highestPriority() Priority {
+	vocabulary.mu.RLock()
+	defer vocabulary.mu.RUnlock()
+	priorities := vocabulary.vocabulary.Priorities
+	return priorities[len(priorities)-1]
+}
+
+func isValidPriority(priority Priority) bool {
+	_, exists := priorityRank(priority)
+	return exists
+}
+
+// IsValid reports whether the tag is in the current vocabulary
+func (t Tag) IsValid() bool {
+	return isValidTag(t)
+}
+
+func isValidTag(tag Tag) bool {
+	vocabulary.mu.RLock()
+	defer vocabulary.mu.RUnlock()
+	return vocabulary.tags[tag]
+}
@@ -0,0 +1,100 @@
+// Package events fans domain events out to in-process subscribers
+package events
+
+import (
+	"sync"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// Publisher receives domain events (satisfies usecase.EventPublisher)
+type Publisher interface {
+	Publish(event domain.Event)
+}
+
+// Broker delivers published events to every subscriber. Delivery never
+// blocks the publisher: events are dropped for subscribers whose buffer is full.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan domain.Event
+	nextID      int
+	closed      bool
+}
+
+// NewBroker creates a broker with no subscribers
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[int]chan domain.Event)}
+}
+
+// Publish delivers the event to all current subscribers
+func (b *Broker) Publish(event domain.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a subscriber with the given buffer size. The returned
+// function unsubscribes and closes the channel; it is safe to call more than once.
+// After Close the returned channel is already closed.
+func (b *Broker) Subscribe(buffer int) (<-chan domain.Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan domain.Event, buffer)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = ch
+
+	return ch, func() { b.unsubscribe(id) }
+}
+
+// Subscribers returns the number of active subscribers
+func (b *Broker) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// Close unsubscribes everyone, closing their channels; later subscriptions
+// receive a closed channel
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, ch := range b.subscribers {
+		close(ch)
+		delete(b.subscribers, id)
+	}
+	b.closed = true
+}
+
+func (b *Broker) unsubscribe(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ch, ok := b.subscribers[id]; ok {
+		close(ch)
+		delete(b.subscribers, id)
+	}
+}
+
+// Fanout publishes each event to all of its publishers in order
+type Fanout []Publisher
+
+// Publish delivers the event to every publisher
+func (f Fanout) Publish(event domain.Event) {
+	for _, p := range f {
+		p.Publish(event)
+	}
+}
@@ -2,7 +2,9 @@
 package memory
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 	
@@ -12,31 +14,59 @@ import (
 
 // MemoryRepository is an in-memory implementation with thread-safety
 type MemoryRepository struct {
-	mu          sync.RWMutex
-	tasks       map[domain.TaskID]*domain.Task
-	users       map[domain.UserID]*domain.User
-	sessions    map[string]*domain.Session
-	userTasks   map[domain.UserID]map[domain.TaskID]bool
-	nextTaskID  domain.TaskID
-	currentUser *domain.UserID
-	clock       time.Time
+	mu             sync.RWMutex
+	tasks          map[domain.TaskID]*domain.Task
+	order          []domain.TaskID // task IDs in creation order
+	users          map[domain.UserID]*domain.User
+	emails         map[string]domain.UserID // normalized email -> user
+	sessions       map[string]*domain.Session
+	userTasks      map[domain.UserID]map[domain.TaskID]bool
+	statusHistory  map[domain.TaskID][]domain.StatusChange
+	comments       map[domain.CommentID]*domain.Comment
+	attachments    map[domain.AttachmentID]*domain.Attachment
+	activity       []*domain.Activity // in ID order
+	nextTaskID     domain.TaskID
+	nextComment    domain.CommentID
+	nextAttachment domain.AttachmentID
+	nextActivity   domain.ActivityID
+	currentUser    *domain.UserID
+	clock          time.Time
+	timeSource     domain.Clock
 }
 
 // NewMemoryRepository creates a new in-memory repository
 func NewMemoryRepository() *MemoryRepository {
+	return NewMemoryRepositoryWithClock(domain.RealClock{})
+}
+
+// NewMemoryRepositoryWithClock creates a new in-memory repository that reads
+// the current time, for session expiry and the system clock, from clock
+func NewMemoryRepositoryWithClock(clock domain.Clock) *MemoryRepository {
 	return &MemoryRepository{
-		tasks:      make(map[domain.TaskID]*domain.Task),
-		users:      make(map[domain.UserID]*domain.User),
-		sessions:   make(map[string]*domain.Session),
-		userTasks:  make(map[domain.UserID]map[domain.TaskID]bool),
-		nextTaskID: 1,
-		clock:      time.Now(),
+		tasks:          make(map[domain.TaskID]*domain.Task),
+		users:          make(map[domain.UserID]*domain.User),
+		emails:         make(map[string]domain.UserID),
+		sessions:       make(map[string]*domain.Session),
+		userTasks:      make(map[domain.UserID]map[domain.TaskID]bool),
+		statusHistory:  make(map[domain.TaskID][]domain.StatusChange),
+		comments:       make(map[domain.CommentID]*domain.Comment),
+		attachments:    make(map[domain.AttachmentID]*domain.Attachment),
+		nextTaskID:     1,
+		nextComment:    1,
+		nextAttachment: 1,
+		nextActivity:   1,
+		clock:          clock.Now(),
+		timeSource:     clock,
 	}
 }
 
 // Task Repository Implementation
 
-func (r *MemoryRepository) CreateTask(task *domain.Task) error {
+func (r *MemoryRepository) CreateTask(ctx context.Context, task *domain.Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -50,17 +80,22 @@ func (r *MemoryRepository) CreateTask(task *domain.Task) error {
 	}
 	
 	r.tasks[task.ID] = task
+	r.order = append(r.order, task.ID)
+	r.recordStatus(task.ID, task.Status, task.CreatedAt)
 	
 	// Update user tasks mapping
-	if r.userTasks[task.Assignee] == nil {
-		r.userTasks[task.Assignee] = make(map[domain.TaskID]bool)
+	if !task.Archived {
+		r.indexTask(task)
 	}
-	r.userTasks[task.Assignee][task.ID] = true
 	
 	return nil
 }
 
-func (r *MemoryRepository) GetTask(id domain.TaskID) (*domain.Task, error) {
+func (r *MemoryRepository) GetTask(ctx context.Context, id domain.TaskID) (*domain.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
@@ -74,7 +109,11 @@ func (r *MemoryRepository) GetTask(id domain.TaskID) (*domain.Task, error) {
 	return &taskCopy, nil
 }
 
-func (r *MemoryRepository) UpdateTask(task *domain.Task) error {
+func (r *MemoryRepository) UpdateTask(ctx context.Context, task *domain.Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -83,25 +122,49 @@ func (r *MemoryRepository) UpdateTask(task *domain.Task) error {
 		return fmt.Errorf("task with ID %d not found", task.ID)
 	}
 	
-	// Handle assignee change
-	if existing.Assignee != task.Assignee {
-		// Remove from old assignee
-		if r.userTasks[existing.Assignee] != nil {
-			delete(r.userTasks[existing.Assignee], task.ID)
-		}
-		
-		// Add to new assignee
-		if r.userTasks[task.Assignee] == nil {
-			r.userTasks[task.Assignee] = make(map[domain.TaskID]bool)
-		}
-		r.userTasks[task.Assignee][task.ID] = true
+	// Remove from old owners
+	r.unindexTask(existing)
+	
+	// Add to new owners unless the task is archived
+	if !task.Archived {
+		r.indexTask(task)
 	}
 	
+	if task.Status != existing.Status {
+		r.recordStatus(task.ID, task.Status, task.UpdatedAt)
+	}
 	r.tasks[task.ID] = task
 	return nil
 }
 
-func (r *MemoryRepository) DeleteTask(id domain.TaskID) error {
+func (r *MemoryRepository) GetStatusHistory(ctx context.Context, id domain.TaskID) ([]domain.StatusChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	if _, exists := r.tasks[id]; !exists {
+		return nil, fmt.Errorf("task with ID %d not found", id)
+	}
+	
+	return append([]domain.StatusChange{}, r.statusHistory[id]...), nil
+}
+
+// recordStatus appends a status change to the task's history. The slice is
+// always reallocated so histories shared with a snapshot are never mutated.
+func (r *MemoryRepository) recordStatus(id domain.TaskID, status domain.TaskStatus, at time.Time) {
+	history := make([]domain.StatusChange, len(r.statusHistory[id]), len(r.statusHistory[id])+1)
+	copy(history, r.statusHistory[id])
+	r.statusHistory[id] = append(history, domain.StatusChange{Status: status, At: at})
+}
+
+func (r *MemoryRepository) DeleteTask(ctx context.Context, id domain.TaskID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -111,15 +174,79 @@ func (r *MemoryRepository) DeleteTask(id domain.TaskID) error {
 	}
 	
 	// Remove from user tasks
-	if r.userTasks[task.Assignee] != nil {
-		delete(r.userTasks[task.Assignee], id)
-	}
+	r.unindexTask(task)
 	
 	delete(r.tasks, id)
+	delete(r.statusHistory, id)
+	r.order = withoutTaskID(r.order, id)
+	
+	// Drop references from the remaining tasks so no dependency dangles. The
+	// maps are replaced rather than edited as task copies may share them.
+	for _, other := range r.tasks {
+		if !other.Dependencies[id] {
+			continue
+		}
+		deps := make(map[domain.TaskID]bool, len(other.Dependencies)-1)
+		for depID := range other.Dependencies {
+			if depID != id {
+				deps[depID] = true
+			}
+		}
+		other.Dependencies = deps
+	}
 	return nil
 }
 
-func (r *MemoryRepository) GetAllTasks() (map[domain.TaskID]*domain.Task, error) {
+// withoutTaskID returns a copy of ids without id
+func withoutTaskID(ids []domain.TaskID, id domain.TaskID) []domain.TaskID {
+	kept := make([]domain.TaskID, 0, len(ids))
+	for _, other := range ids {
+		if other != id {
+			kept = append(kept, other)
+		}
+	}
+	return kept
+}
+
+// orderedTasks returns copies of the tasks that satisfy keep, in creation
+// order, so that listings are reproducible
+func (r *MemoryRepository) orderedTasks(keep func(task *domain.Task) bool) []*domain.Task {
+	var tasks []*domain.Task
+	for _, id := range r.order {
+		if task := r.tasks[id]; keep(task) {
+			taskCopy := *task
+			tasks = append(tasks, &taskCopy)
+		}
+	}
+	return tasks
+}
+
+// indexTask adds the task to the task list of each of its owners
+func (r *MemoryRepository) indexTask(task *domain.Task) {
+	for _, userID := range task.Owners() {
+		if r.userTasks[userID] == nil {
+			r.userTasks[userID] = make(map[domain.TaskID]bool)
+		}
+		r.userTasks[userID][task.ID] = true
+	}
+}
+
+// unindexTask removes the task from the task list of each of its owners
+func (r *MemoryRepository) unindexTask(task *domain.Task) {
+	for _, userID := range task.Owners() {
+		if r.userTasks[userID] != nil {
+			delete(r.userTasks[userID], task.ID)
+		}
+	}
+}
+
+// GetAllTasks returns every task keyed by ID. A map has no order; the queries
+// returning slices list tasks in creation order.
+func (r *MemoryRepository) GetAllTasks(ctx context.Context) (map[domain.TaskID]*domain.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
@@ -133,62 +260,98 @@ func (r *MemoryRepository) GetAllTasks() (map[domain.TaskID]*domain.Task, error)
 	return tasksCopy, nil
 }
 
-func (r *MemoryRepository) GetTasksByUser(userID domain.UserID) ([]*domain.Task, error) {
+func (r *MemoryRepository) GetTasksByUser(ctx context.Context, userID domain.UserID) ([]*domain.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
-	var userTaskList []*domain.Task
-	
-	if taskIDs, exists := r.userTasks[userID]; exists {
-		for taskID := range taskIDs {
-			if task, taskExists := r.tasks[taskID]; taskExists {
-				taskCopy := *task
-				userTaskList = append(userTaskList, &taskCopy)
-			}
-		}
+	taskIDs := r.userTasks[userID]
+	return r.orderedTasks(func(task *domain.Task) bool { return taskIDs[task.ID] }), nil
+}
+
+func (r *MemoryRepository) GetTasksByCreator(ctx context.Context, userID domain.UserID) ([]*domain.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 	
-	return userTaskList, nil
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	return r.orderedTasks(func(task *domain.Task) bool { return task.CreatedBy == userID }), nil
 }
 
-func (r *MemoryRepository) GetTasksByStatus(status domain.TaskStatus) ([]*domain.Task, error) {
+func (r *MemoryRepository) GetTasksByStatus(ctx context.Context, status domain.TaskStatus) ([]*domain.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
-	var statusTasks []*domain.Task
-	for _, task := range r.tasks {
-		if task.Status == status {
-			taskCopy := *task
-			statusTasks = append(statusTasks, &taskCopy)
-		}
+	return r.orderedTasks(func(task *domain.Task) bool { return task.Status == status }), nil
+}
+
+func (r *MemoryRepository) GetTasksByDependency(ctx context.Context, taskID domain.TaskID) ([]*domain.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 	
-	return statusTasks, nil
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	return r.orderedTasks(func(task *domain.Task) bool { return task.Dependencies[taskID] }), nil
 }
 
-func (r *MemoryRepository) GetTasksByDependency(taskID domain.TaskID) ([]*domain.Task, error) {
+func (r *MemoryRepository) GetTasksDueBetween(ctx context.Context, start, end time.Time) ([]*domain.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
-	var dependentTasks []*domain.Task
+	dueTasks := []*domain.Task{}
 	for _, task := range r.tasks {
-		if _, hasDep := task.Dependencies[taskID]; hasDep {
-			taskCopy := *task
-			dependentTasks = append(dependentTasks, &taskCopy)
+		if task.DueDate == nil {
+			continue
 		}
+		if task.DueDate.Before(start) || task.DueDate.After(end) {
+			continue
+		}
+		taskCopy := *task
+		dueTasks = append(dueTasks, &taskCopy)
 	}
 	
-	return dependentTasks, nil
+	// Earliest due first
+	sort.Slice(dueTasks, func(i, j int) bool {
+		if dueTasks[i].DueDate.Equal(*dueTasks[j].DueDate) {
+			return dueTasks[i].ID < dueTasks[j].ID
+		}
+		return dueTasks[i].DueDate.Before(*dueTasks[j].DueDate)
+	})
+	
+	return dueTasks, nil
 }
 
-func (r *MemoryRepository) BulkUpdateStatus(taskIDs []domain.TaskID, status domain.TaskStatus) error {
+func (r *MemoryRepository) BulkUpdateStatus(ctx context.Context, taskIDs []domain.TaskID, status domain.TaskStatus) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	for _, id := range taskIDs {
 		if task, exists := r.tasks[id]; exists {
+			changed := task.Status != status
 			task.Status = status
-			task.UpdatedAt = time.Now()
+			task.UpdatedAt = r.timeSource.Now()
+			if changed {
+				r.recordStatus(id, status, task.UpdatedAt)
+			}
 		}
 	}
 	
@@ -197,19 +360,78 @@ func (r *MemoryRepository) BulkUpdateStatus(taskIDs []domain.TaskID, status doma
 
 // User Repository Implementation
 
-func (r *MemoryRepository) CreateUser(user *domain.User) error {
+func (r *MemoryRepository) CreateUser(ctx context.Context, user *domain.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	if _, exists := r.users[user.ID]; exists {
-		return fmt.Errorf("user with ID %s already exists", user.ID)
+		return fmt.Errorf("%w: user with ID %s", domain.ErrDuplicateUser, user.ID)
+	}
+	if err := r.checkEmailAvailable(user); err != nil {
+		return err
+	}
+	
+	// Store a copy so callers reusing the same variable don't alias users
+	userCopy := *user
+	r.users[user.ID] = &userCopy
+	r.indexEmail(&userCopy)
+	return nil
+}
+
+func (r *MemoryRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	userID, exists := r.emails[domain.NormalizeEmail(email)]
+	if !exists || email == "" {
+		return nil, fmt.Errorf("user with email %s not found", email)
 	}
 	
-	r.users[user.ID] = user
+	userCopy := *r.users[userID]
+	return &userCopy, nil
+}
+
+// checkEmailAvailable rejects an email already used by another user; users
+// without an email are not indexed
+func (r *MemoryRepository) checkEmailAvailable(user *domain.User) error {
+	email := domain.NormalizeEmail(user.Email)
+	if email == "" {
+		return nil
+	}
+	if owner, exists := r.emails[email]; exists && owner != user.ID {
+		return fmt.Errorf("%w: %s is used by %s", domain.ErrDuplicateEmail, user.Email, owner)
+	}
 	return nil
 }
 
-func (r *MemoryRepository) GetUser(id domain.UserID) (*domain.User, error) {
+// indexEmail adds the user's email to the email index
+func (r *MemoryRepository) indexEmail(user *domain.User) {
+	if email := domain.NormalizeEmail(user.Email); email != "" {
+		r.emails[email] = user.ID
+	}
+}
+
+// unindexEmail removes the user's email from the email index
+func (r *MemoryRepository) unindexEmail(user *domain.User) {
+	email := domain.NormalizeEmail(user.Email)
+	if r.emails[email] == user.ID {
+		delete(r.emails, email)
+	}
+}
+
+func (r *MemoryRepository) GetUser(ctx context.Context, id domain.UserID) (*domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
@@ -222,7 +444,11 @@ func (r *MemoryRepository) GetUser(id domain.UserID) (*domain.User, error) {
 	return &userCopy, nil
 }
 
-func (r *MemoryRepository) GetAllUsers() ([]*domain.User, error) {
+func (r *MemoryRepository) GetAllUsers(ctx context.Context) ([]*domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
@@ -235,33 +461,79 @@ func (r *MemoryRepository) GetAllUsers() ([]*domain.User, error) {
 	return userList, nil
 }
 
-func (r *MemoryRepository) UpdateUser(user *domain.User) error {
+func (r *MemoryRepository) QueryUsers(ctx context.Context, filter domain.UserFilter, page domain.Page) ([]*domain.User, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	var matching []*domain.User
+	for _, user := range r.users {
+		if filter.Matches(user) {
+			matching = append(matching, user)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool { return filter.Less(matching[i], matching[j]) })
+	
+	users := []*domain.User{}
+	for i := max(page.Offset, 0); i < len(matching) && len(users) < page.Limit; i++ {
+		userCopy := *matching[i]
+		users = append(users, &userCopy)
+	}
+	
+	return users, len(matching), nil
+}
+
+func (r *MemoryRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
-	if _, exists := r.users[user.ID]; !exists {
+	existing, exists := r.users[user.ID]
+	if !exists {
 		return fmt.Errorf("user with ID %s not found", user.ID)
 	}
+	if err := r.checkEmailAvailable(user); err != nil {
+		return err
+	}
 	
-	r.users[user.ID] = user
+	userCopy := *user
+	r.unindexEmail(existing)
+	r.users[user.ID] = &userCopy
+	r.indexEmail(&userCopy)
 	return nil
 }
 
-func (r *MemoryRepository) DeleteUser(id domain.UserID) error {
+func (r *MemoryRepository) DeleteUser(ctx context.Context, id domain.UserID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
-	if _, exists := r.users[id]; !exists {
+	user, exists := r.users[id]
+	if !exists {
 		return fmt.Errorf("user with ID %s not found", id)
 	}
 	
+	r.unindexEmail(user)
 	delete(r.users, id)
 	return nil
 }
 
 // Session Repository Implementation
 
-func (r *MemoryRepository) CreateSession(session *domain.Session) error {
+func (r *MemoryRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -273,7 +545,11 @@ func (r *MemoryRepository) CreateSession(session *domain.Session) error {
 	return nil
 }
 
-func (r *MemoryRepository) GetSession(token string) (*domain.Session, error) {
+func (r *MemoryRepository) GetSession(ctx context.Context, token string) (*domain.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
@@ -286,12 +562,16 @@ func (r *MemoryRepository) GetSession(token string) (*domain.Session, error) {
 	return &sessionCopy, nil
 }
 
-func (r *MemoryRepository) GetSessionByUser(userID domain.UserID) (*domain.Session, error) {
+func (r *MemoryRepository) GetSessionByUser(ctx context.Context, userID domain.UserID) (*domain.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
 	for _, session := range r.sessions {
-		if session.UserID == userID && session.IsValid() {
+		if session.UserID == userID && session.IsValidAt(r.timeSource.Now()) {
 			sessionCopy := *session
 			return &sessionCopy, nil
 		}
@@ -300,7 +580,11 @@ func (r *MemoryRepository) GetSessionByUser(userID domain.UserID) (*domain.Sessi
 	return nil, fmt.Errorf("no active session for user %s", userID)
 }
 
-func (r *MemoryRepository) UpdateSession(session *domain.Session) error {
+func (r *MemoryRepository) UpdateSession(ctx context.Context, session *domain.Session) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -312,7 +596,11 @@ func (r *MemoryRepository) UpdateSession(session *domain.Session) error {
 	return nil
 }
 
-func (r *MemoryRepository) DeleteSession(token string) error {
+func (r *MemoryRepository) DeleteSession(ctx context.Context, token string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -324,7 +612,11 @@ func (r *MemoryRepository) DeleteSession(token string) error {
 	return nil
 }
 
-func (r *MemoryRepository) DeleteUserSessions(userID domain.UserID) error {
+func (r *MemoryRepository) DeleteUserSessions(ctx context.Context, userID domain.UserID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -337,13 +629,18 @@ func (r *MemoryRepository) DeleteUserSessions(userID domain.UserID) error {
 	return nil
 }
 
-func (r *MemoryRepository) GetActiveSessions() ([]*domain.Session, error) {
+func (r *MemoryRepository) GetActiveSessions(ctx context.Context) ([]*domain.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
+	now := r.timeSource.Now()
 	var activeSessions []*domain.Session
 	for _, session := range r.sessions {
-		if session.IsValid() {
+		if session.IsValidAt(now) {
 			sessionCopy := *session
 			activeSessions = append(activeSessions, &sessionCopy)
 		}
@@ -352,19 +649,201 @@ func (r *MemoryRepository) GetActiveSessions() ([]*domain.Session, error) {
 	return activeSessions, nil
 }
 
+// Comment Repository Implementation
+
+func (r *MemoryRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	if _, exists := r.tasks[comment.TaskID]; !exists {
+		return fmt.Errorf("task with ID %d not found", comment.TaskID)
+	}
+	
+	comment.ID = r.nextComment
+	r.nextComment++
+	
+	commentCopy := *comment
+	r.comments[comment.ID] = &commentCopy
+	return nil
+}
+
+func (r *MemoryRepository) GetCommentsByTask(ctx context.Context, taskID domain.TaskID) ([]*domain.Comment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	taskComments := []*domain.Comment{}
+	for _, comment := range r.comments {
+		if comment.TaskID == taskID {
+			commentCopy := *comment
+			taskComments = append(taskComments, &commentCopy)
+		}
+	}
+	
+	// Comment IDs are assigned in creation order
+	sort.Slice(taskComments, func(i, j int) bool {
+		return taskComments[i].ID < taskComments[j].ID
+	})
+	
+	return taskComments, nil
+}
+
+func (r *MemoryRepository) DeleteTaskComments(ctx context.Context, taskID domain.TaskID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	for id, comment := range r.comments {
+		if comment.TaskID == taskID {
+			delete(r.comments, id)
+		}
+	}
+	
+	return nil
+}
+
+// Activity Repository Implementation
+
+func (r *MemoryRepository) RecordActivity(ctx context.Context, activity *domain.Activity) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	activity.ID = r.nextActivity
+	r.nextActivity++
+	
+	activityCopy := *activity
+	r.activity = append(r.activity, &activityCopy)
+	return nil
+}
+
+func (r *MemoryRepository) QueryActivity(ctx context.Context, filter domain.ActivityFilter, offset, limit int) ([]*domain.Activity, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	page := []*domain.Activity{}
+	total := 0
+	for i := len(r.activity) - 1; i >= 0; i-- {
+		activity := r.activity[i]
+		if !filter.Matches(activity) {
+			continue
+		}
+		if total >= offset && len(page) < limit {
+			activityCopy := *activity
+			page = append(page, &activityCopy)
+		}
+		total++
+	}
+	
+	return page, total, nil
+}
+
+// Attachment Repository Implementation
+
+func (r *MemoryRepository) CreateAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	if _, exists := r.tasks[attachment.TaskID]; !exists {
+		return fmt.Errorf("task with ID %d not found", attachment.TaskID)
+	}
+	
+	attachment.ID = r.nextAttachment
+	r.nextAttachment++
+	
+	attachmentCopy := *attachment
+	r.attachments[attachment.ID] = &attachmentCopy
+	return nil
+}
+
+func (r *MemoryRepository) GetAttachmentsByTask(ctx context.Context, taskID domain.TaskID) ([]*domain.Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	taskAttachments := []*domain.Attachment{}
+	for _, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			attachmentCopy := *attachment
+			taskAttachments = append(taskAttachments, &attachmentCopy)
+		}
+	}
+	
+	// Attachment IDs are assigned in upload order
+	sort.Slice(taskAttachments, func(i, j int) bool {
+		return taskAttachments[i].ID < taskAttachments[j].ID
+	})
+	
+	return taskAttachments, nil
+}
+
+func (r *MemoryRepository) DeleteTaskAttachments(ctx context.Context, taskID domain.TaskID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	for id, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			delete(r.attachments, id)
+		}
+	}
+	
+	return nil
+}
+
 // System State Repository Implementation
 
-func (r *MemoryRepository) GetSystemState() (*domain.SystemState, error) {
+func (r *MemoryRepository) GetSystemState(ctx context.Context) (*domain.SystemState, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
+	// The clock reads as the current time, but never earlier than a clock
+	// saved with SaveSystemState
+	now := r.timeSource.Now()
+	clock := r.clock
+	if now.After(clock) {
+		clock = now
+	}
+	
 	state := &domain.SystemState{
 		Tasks:       make(map[domain.TaskID]*domain.Task),
 		UserTasks:   make(map[domain.UserID][]domain.TaskID),
 		NextTaskID:  r.nextTaskID,
 		CurrentUser: r.currentUser,
-		Clock:       r.clock,
+		Clock:       clock,
 		Sessions:    make(map[domain.UserID]*domain.Session),
+		Users:       make(map[domain.UserID]*domain.User),
 	}
 	
 	// Copy tasks
@@ -382,28 +861,55 @@ func (r *MemoryRepository) GetSystemState() (*domain.SystemState, error) {
 	
 	// Copy sessions
 	for _, session := range r.sessions {
-		if session.IsValid() {
+		if session.IsValidAt(now) {
 			sessionCopy := *session
 			state.Sessions[session.UserID] = &sessionCopy
 		}
 	}
 	
+	// A current user whose session has lapsed is reported as logged out, like
+	// the session itself
+	if state.CurrentUser != nil {
+		if _, exists := state.Sessions[*state.CurrentUser]; !exists {
+			state.CurrentUser = nil
+		}
+	}
+	
+	// Copy users
+	for id, user := range r.users {
+		userCopy := *user
+		state.Users[id] = &userCopy
+	}
+	
 	return state, nil
 }
 
-func (r *MemoryRepository) SaveSystemState(state *domain.SystemState) error {
+func (r *MemoryRepository) SaveSystemState(ctx context.Context, state *domain.SystemState) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	// Clear and rebuild state
 	r.tasks = make(map[domain.TaskID]*domain.Task)
 	r.userTasks = make(map[domain.UserID]map[domain.TaskID]bool)
-	r.sessions = make(map[string]*domain.Session)
 	
-	// Copy tasks
+	// Copy tasks; the state does not record creation order, so task IDs,
+	// which are handed out in creation order, stand in for it
+	r.order = make([]domain.TaskID, 0, len(state.Tasks))
 	for id, task := range state.Tasks {
-		taskCopy := *task
-		r.tasks[id] = &taskCopy
+		r.tasks[id] = cloneTask(task)
+		r.order = append(r.order, id)
+	}
+	sort.Slice(r.order, func(i, j int) bool { return r.order[i] < r.order[j] })
+	
+	// Keep the status history only of tasks that are still present
+	for id := range r.statusHistory {
+		if _, exists := r.tasks[id]; !exists {
+			delete(r.statusHistory, id)
+		}
 	}
 	
 	// Rebuild user tasks
@@ -414,10 +920,24 @@ func (r *MemoryRepository) SaveSystemState(state *domain.SystemState) error {
 		}
 	}
 	
-	// Copy sessions
-	for _, session := range state.Sessions {
-		sessionCopy := *session
-		r.sessions[session.Token] = &sessionCopy
+	// Replace sessions only when the state carries them
+	if state.Sessions != nil {
+		r.sessions = make(map[string]*domain.Session)
+		for _, session := range state.Sessions {
+			sessionCopy := *session
+			r.sessions[session.Token] = &sessionCopy
+		}
+	}
+	
+	// Replace users only when the state carries them
+	if state.Users != nil {
+		r.users = make(map[domain.UserID]*domain.User)
+		r.emails = make(map[string]domain.UserID)
+		for id, user := range state.Users {
+			userCopy := *user
+			r.users[id] = &userCopy
+			r.indexEmail(&userCopy)
+		}
 	}
 	
 	r.nextTaskID = state.NextTaskID
@@ -427,14 +947,22 @@ func (r *MemoryRepository) SaveSystemState(state *domain.SystemState) error {
 	return nil
 }
 
-func (r *MemoryRepository) GetNextTaskID() (domain.TaskID, error) {
+func (r *MemoryRepository) GetNextTaskID(ctx context.Context) (domain.TaskID, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
 	return r.nextTaskID, nil
 }
 
-func (r *MemoryRepository) IncrementNextTaskID() (domain.TaskID, error) {
+func (r *MemoryRepository) IncrementNextTaskID(ctx context.Context) (domain.TaskID, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -443,14 +971,22 @@ func (r *MemoryRepository) IncrementNextTaskID() (domain.TaskID, error) {
 	return currentID, nil
 }
 
-func (r *MemoryRepository) GetCurrentUser() (*domain.UserID, error) {
+func (r *MemoryRepository) GetCurrentUser(ctx context.Context) (*domain.UserID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
 	return r.currentUser, nil
 }
 
-func (r *MemoryRepository) SetCurrentUser(userID *domain.UserID) error {
+func (r *MemoryRepository) SetCurrentUser(ctx context.Context, userID *domain.UserID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -458,7 +994,11 @@ func (r *MemoryRepository) SetCurrentUser(userID *domain.UserID) error {
 	return nil
 }
 
-func (r *MemoryRepository) GetUserTasks(userID domain.UserID) ([]domain.TaskID, error) {
+func (r *MemoryRepository) GetUserTasks(ctx context.Context, userID domain.UserID) ([]domain.TaskID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
@@ -472,7 +1012,11 @@ func (r *MemoryRepository) GetUserTasks(userID domain.UserID) ([]domain.TaskID,
 	return taskList, nil
 }
 
-func (r *MemoryRepository) AddUserTask(userID domain.UserID, taskID domain.TaskID) error {
+func (r *MemoryRepository) AddUserTask(ctx context.Context, userID domain.UserID, taskID domain.TaskID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -484,7 +1028,11 @@ func (r *MemoryRepository) AddUserTask(userID domain.UserID, taskID domain.TaskI
 	return nil
 }
 
-func (r *MemoryRepository) RemoveUserTask(userID domain.UserID, taskID domain.TaskID) error {
+func (r *MemoryRepository) RemoveUserTask(ctx context.Context, userID domain.UserID, taskID domain.TaskID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -495,42 +1043,349 @@ func (r *MemoryRepository) RemoveUserTask(userID domain.UserID, taskID domain.Ta
 	return nil
 }
 
-// UnitOfWork implementation
+// RebuildUserTaskIndex recomputes userTasks from the owners of the tasks,
+// dropping entries for missing or archived tasks and re-adding missing ones.
+// It returns the number of entries that were added or removed.
+func (r *MemoryRepository) RebuildUserTaskIndex(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	index := make(map[domain.UserID]map[domain.TaskID]bool)
+	for id, task := range r.tasks {
+		if task.Archived {
+			continue
+		}
+		for _, userID := range task.Owners() {
+			if index[userID] == nil {
+				index[userID] = make(map[domain.TaskID]bool)
+			}
+			index[userID][id] = true
+		}
+	}
+	
+	changed := 0
+	for userID, taskIDs := range r.userTasks {
+		for taskID := range taskIDs {
+			if !index[userID][taskID] {
+				changed++
+			}
+		}
+	}
+	for userID, taskIDs := range index {
+		for taskID := range taskIDs {
+			if !r.userTasks[userID][taskID] {
+				changed++
+			}
+		}
+	}
+	
+	r.userTasks = index
+	return changed, nil
+}
+
+// clone returns a deep copy of the repository state for use as a transaction
+// or as the snapshot of a scope
+func (r *MemoryRepository) clone() *MemoryRepository {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	c := &MemoryRepository{
+		tasks:          make(map[domain.TaskID]*domain.Task, len(r.tasks)),
+		order:          append([]domain.TaskID(nil), r.order...),
+		users:          make(map[domain.UserID]*domain.User, len(r.users)),
+		emails:         make(map[string]domain.UserID, len(r.emails)),
+		sessions:       make(map[string]*domain.Session, len(r.sessions)),
+		userTasks:      make(map[domain.UserID]map[domain.TaskID]bool, len(r.userTasks)),
+		statusHistory:  make(map[domain.TaskID][]domain.StatusChange, len(r.statusHistory)),
+		comments:       make(map[domain.CommentID]*domain.Comment, len(r.comments)),
+		attachments:    make(map[domain.AttachmentID]*domain.Attachment, len(r.attachments)),
+		nextTaskID:     r.nextTaskID,
+		nextComment:    r.nextComment,
+		nextAttachment: r.nextAttachment,
+		nextActivity:   r.nextActivity,
+		currentUser:    r.currentUser,
+		clock:          r.clock,
+		timeSource:     r.timeSource,
+	}
+	
+	for id, task := range r.tasks {
+		c.tasks[id] = cloneTask(task)
+	}
+	for id, user := range r.users {
+		userCopy := *user
+		c.users[id] = &userCopy
+	}
+	for email, userID := range r.emails {
+		c.emails[email] = userID
+	}
+	for token, session := range r.sessions {
+		sessionCopy := *session
+		c.sessions[token] = &sessionCopy
+	}
+	for userID, taskIDs := range r.userTasks {
+		c.userTasks[userID] = make(map[domain.TaskID]bool, len(taskIDs))
+		for taskID := range taskIDs {
+			c.userTasks[userID][taskID] = true
+		}
+	}
+	for id, history := range r.statusHistory {
+		// recordStatus never mutates a history in place, so sharing is safe
+		c.statusHistory[id] = history
+	}
+	for id, comment := range r.comments {
+		commentCopy := *comment
+		c.comments[id] = &commentCopy
+	}
+	for id, attachment := range r.attachments {
+		attachmentCopy := *attachment
+		c.attachments[id] = &attachmentCopy
+	}
+	// Entries are never modified and the capped slice makes appends
+	// reallocate, so sharing is safe
+	c.activity = r.activity[:len(r.activity):len(r.activity)]
+	
+	return c
+}
+
+// replaceWith swaps in the state of a committed transaction
+func (r *MemoryRepository) replaceWith(tx *MemoryRepository) {
+	tx.mu.RLock()
+	defer tx.mu.RUnlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	
+	r.tasks = tx.tasks
+	r.order = tx.order
+	r.users = tx.users
+	r.emails = tx.emails
+	r.sessions = tx.sessions
+	r.userTasks = tx.userTasks
+	r.statusHistory = tx.statusHistory
+	r.comments = tx.comments
+	r.attachments = tx.attachments
+	r.activity = tx.activity
+	r.nextTaskID = tx.nextTaskID
+	r.nextComment = tx.nextComment
+	r.nextAttachment = tx.nextAttachment
+	r.nextActivity = tx.nextActivity
+	r.currentUser = tx.currentUser
+	r.clock = tx.clock
+}
+
+func cloneTask(task *domain.Task) *domain.Task {
+	taskCopy := *task
+	if task.Tags != nil {
+		taskCopy.Tags = append([]domain.Tag(nil), task.Tags...)
+	}
+	if task.Dependencies != nil {
+		taskCopy.Dependencies = make(map[domain.TaskID]bool, len(task.Dependencies))
+		for depID, v := range task.Dependencies {
+			taskCopy.Dependencies[depID] = v
+		}
+	}
+	if task.Watchers != nil {
+		taskCopy.Watchers = make(map[domain.UserID]bool, len(task.Watchers))
+		for userID, v := range task.Watchers {
+			taskCopy.Watchers[userID] = v
+		}
+	}
+	if task.Collaborators != nil {
+		taskCopy.Collaborators = append([]domain.UserID(nil), task.Collaborators...)
+	}
+	if task.Labels != nil {
+		taskCopy.Labels = make(map[string]string, len(task.Labels))
+		for key, value := range task.Labels {
+			taskCopy.Labels[key] = value
+		}
+	}
+	if task.DueDate != nil {
+		dueDate := *task.DueDate
+		taskCopy.DueDate = &dueDate
+	}
+	return &taskCopy
+}
+
+// MemoryUnitOfWork implements snapshot-based transactions over a MemoryRepository.
+// Begin clones the repository state, all repository access goes to the clone
+// until Commit swaps it in or Rollback discards it. Transactions are serialized;
+// Begin gives up waiting for the running transaction when ctx is done.
+//
+// While a transaction is open every caller sees its uncommitted state, so
+// concurrent use-case operations must also hold the operation lock.
+//
+// A scope is a snapshot of the repository taken by BeginScope, which
+// EndScope restores to roll the scope back.
 type MemoryUnitOfWork struct {
-	repo *MemoryRepository
+	repo   *MemoryRepository
+	ops    sync.RWMutex  // held by use-case operations, see UnitOfWork
+	scopes sync.RWMutex  // held by an open scope, shared by operations outside it
+	txSem  chan struct{} // holds a token for the lifetime of a transaction
+	mu     sync.RWMutex  // guards tx and scope
+	tx     *MemoryRepository
+	scope  *memoryScope
+}
+
+// memoryScope is an open scope with the state it rolls back to
+type memoryScope struct {
+	snapshot *MemoryRepository
 }
 
+// scopeKey is the context key of the scope an operation belongs to
+type scopeKey struct{}
+
 func NewMemoryUnitOfWork(repo *MemoryRepository) repository.UnitOfWork {
-	return &MemoryUnitOfWork{repo: repo}
+	return &MemoryUnitOfWork{repo: repo, txSem: make(chan struct{}, 1)}
 }
 
-func (u *MemoryUnitOfWork) Begin() error {
-	// No-op for in-memory implementation
+func (u *MemoryUnitOfWork) Begin(ctx context.Context) error {
+	select {
+	case u.txSem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.tx = u.repo.clone()
 	return nil
 }
 
 func (u *MemoryUnitOfWork) Commit() error {
-	// No-op for in-memory implementation
+	u.mu.Lock()
+	if u.tx == nil {
+		u.mu.Unlock()
+		return fmt.Errorf("no active transaction")
+	}
+	u.repo.replaceWith(u.tx)
+	u.tx = nil
+	u.mu.Unlock()
+	
+	<-u.txSem
 	return nil
 }
 
 func (u *MemoryUnitOfWork) Rollback() error {
-	// No-op for in-memory implementation
+	u.mu.Lock()
+	if u.tx == nil {
+		// Nothing to discard
+		u.mu.Unlock()
+		return nil
+	}
+	u.tx = nil
+	u.mu.Unlock()
+	
+	<-u.txSem
 	return nil
 }
 
-func (u *MemoryUnitOfWork) Tasks() repository.TaskRepository {
+// Lock holds the unit of work exclusively for a mutating operation
+func (u *MemoryUnitOfWork) Lock(ctx context.Context) {
+	if !u.inScope(ctx) {
+		u.scopes.RLock()
+	}
+	u.ops.Lock()
+}
+
+// Unlock releases Lock
+func (u *MemoryUnitOfWork) Unlock(ctx context.Context) {
+	u.ops.Unlock()
+	if !u.inScope(ctx) {
+		u.scopes.RUnlock()
+	}
+}
+
+// RLock holds the unit of work shared with other read-only operations
+func (u *MemoryUnitOfWork) RLock(ctx context.Context) {
+	if !u.inScope(ctx) {
+		u.scopes.RLock()
+	}
+	u.ops.RLock()
+}
+
+// RUnlock releases RLock
+func (u *MemoryUnitOfWork) RUnlock(ctx context.Context) {
+	u.ops.RUnlock()
+	if !u.inScope(ctx) {
+		u.scopes.RUnlock()
+	}
+}
+
+// BeginScope opens a scope once every operation outside it has finished
+func (u *MemoryUnitOfWork) BeginScope(ctx context.Context) context.Context {
+	u.scopes.Lock()
+	
+	scope := &memoryScope{snapshot: u.repo.clone()}
+	u.mu.Lock()
+	u.scope = scope
+	u.mu.Unlock()
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// EndScope closes the scope, restoring its snapshot on a rollback. A context
+// without an open scope is ignored.
+func (u *MemoryUnitOfWork) EndScope(ctx context.Context, rollback bool) {
+	u.mu.Lock()
+	scope := u.scope
+	if scope == nil || ctx.Value(scopeKey{}) != scope {
+		u.mu.Unlock()
+		return
+	}
+	u.scope = nil
+	u.mu.Unlock()
+	
+	if rollback {
+		u.repo.replaceWith(scope.snapshot)
+	}
+	u.scopes.Unlock()
+}
+
+// inScope reports whether ctx belongs to the open scope
+func (u *MemoryUnitOfWork) inScope(ctx context.Context) bool {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	
+	return u.scope != nil && ctx.Value(scopeKey{}) == u.scope
+}
+
+// current returns the transaction repository if one is active
+func (u *MemoryUnitOfWork) current() *MemoryRepository {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	
+	if u.tx != nil {
+		return u.tx
+	}
 	return u.repo
 }
 
+func (u *MemoryUnitOfWork) Tasks() repository.TaskRepository {
+	return u.current()
+}
+
 func (u *MemoryUnitOfWork) Users() repository.UserRepository {
-	return u.repo
+	return u.current()
 }
 
 func (u *MemoryUnitOfWork) Sessions() repository.SessionRepository {
-	return u.repo
+	return u.current()
+}
+
+func (u *MemoryUnitOfWork) Comments() repository.CommentRepository {
+	return u.current()
+}
+
+func (u *MemoryUnitOfWork) Attachments() repository.AttachmentRepository {
+	return u.current()
+}
+
+func (u *MemoryUnitOfWork) Activity() repository.ActivityRepository {
+	return u.current()
 }
 
 func (u *MemoryUnitOfWork) SystemState() repository.SystemStateRepository {
-	return u.repo
+	return u.current()
 }
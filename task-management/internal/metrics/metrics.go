@@ -0,0 +1,58 @@
+// Package metrics exposes operational counters for monitoring via expvar
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+var (
+	tasksCreated        = expvar.NewInt("tasks_created_total")
+	statusTransitions   = expvar.NewMap("status_transitions_total")
+	invariantViolations = expvar.NewInt("invariant_violations_total")
+	invariantChecks     = expvar.NewInt("invariant_checks_total")
+	invariantCheckTime  = expvar.NewFloat("invariant_check_seconds_total")
+	activeSessions      = expvar.NewInt("active_sessions")
+	activityDropped     = expvar.NewInt("activity_dropped_total")
+)
+
+// RecordTaskCreated counts a successfully created task
+func RecordTaskCreated() {
+	tasksCreated.Add(1)
+}
+
+// RecordTransition counts a status transition keyed by its from/to pair
+func RecordTransition(from, to domain.TaskStatus) {
+	statusTransitions.Add(string(from)+"->"+string(to), 1)
+}
+
+// RecordInvariantViolation counts a detected invariant violation
+func RecordInvariantViolation() {
+	invariantViolations.Add(1)
+}
+
+// RecordInvariantCheck counts a full invariant check of the state and the
+// time it took
+func RecordInvariantCheck(elapsed time.Duration) {
+	invariantChecks.Add(1)
+	invariantCheckTime.Add(elapsed.Seconds())
+}
+
+// RecordActivityDropped counts a task event that could not be added to the
+// activity log
+func RecordActivityDropped() {
+	activityDropped.Add(1)
+}
+
+// SetActiveSessions sets the active sessions gauge
+func SetActiveSessions(count int) {
+	activeSessions.Set(int64(count))
+}
+
+// Handler serves all published metrics as JSON
+func Handler() http.Handler {
+	return expvar.Handler()
+}
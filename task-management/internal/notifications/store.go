@@ -0,0 +1,40 @@
+// Package notifications keeps user notifications in memory
+package notifications
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// Store records notifications per user (satisfies usecase.NotificationService)
+type Store struct {
+	mu     sync.RWMutex
+	byUser map[domain.UserID][]domain.Notification
+}
+
+// NewStore creates an empty notification store
+func NewStore() *Store {
+	return &Store{byUser: make(map[domain.UserID][]domain.Notification)}
+}
+
+// Notify records a notification for the user
+func (s *Store) Notify(userID domain.UserID, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byUser[userID] = append(s.byUser[userID], domain.Notification{
+		UserID:    userID,
+		Message:   message,
+		CreatedAt: time.Now(),
+	})
+}
+
+// Notifications returns the user's notifications, oldest first
+func (s *Store) Notifications(userID domain.UserID) []domain.Notification {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]domain.Notification{}, s.byUser[userID]...)
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// Repository is everything a single backing store provides, as implemented
+// by memory.MemoryRepository
+type Repository interface {
+	TaskRepository
+	UserRepository
+	SessionRepository
+	CommentRepository
+	AttachmentRepository
+	SystemStateRepository
+	ActivityRepository
+}
+
+// ReadOnlyRepository is a view of a Repository that passes reads through and
+// rejects every write with domain.ErrReadOnly, for pointing reporting traffic
+// at a store it must not change. Each method is forwarded explicitly, so a
+// write added to the interfaces cannot slip through unnoticed.
+type ReadOnlyRepository struct {
+	inner Repository
+}
+
+var _ Repository = (*ReadOnlyRepository)(nil)
+
+// NewReadOnlyRepository wraps inner in a read-only view
+func NewReadOnlyRepository(inner Repository) *ReadOnlyRepository {
+	return &ReadOnlyRepository{inner: inner}
+}
+
+func readOnly(operation string) error {
+	return fmt.Errorf("%w: cannot %s", domain.ErrReadOnly, operation)
+}
+
+// Task reads
+
+func (r *ReadOnlyRepository) GetTask(ctx context.Context, id domain.TaskID) (*domain.Task, error) {
+	return r.inner.GetTask(ctx, id)
+}
+
+func (r *ReadOnlyRepository) GetAllTasks(ctx context.Context) (map[domain.TaskID]*domain.Task, error) {
+	return r.inner.GetAllTasks(ctx)
+}
+
+func (r *ReadOnlyRepository) GetTasksByUser(ctx context.Context, userID domain.UserID) ([]*domain.Task, error) {
+	return r.inner.GetTasksByUser(ctx, userID)
+}
+
+func (r *ReadOnlyRepository) GetTasksByCreator(ctx context.Context, userID domain.UserID) ([]*domain.Task, error) {
+	return r.inner.GetTasksByCreator(ctx, userID)
+}
+
+func (r *ReadOnlyRepository) GetTasksByStatus(ctx context.Context, status domain.TaskStatus) ([]*domain.Task, error) {
+	return r.inner.GetTasksByStatus(ctx, status)
+}
+
+func (r *ReadOnlyRepository) GetTasksByDependency(ctx context.Context, taskID domain.TaskID) ([]*domain.Task, error) {
+	return r.inner.GetTasksByDependency(ctx, taskID)
+}
+
+func (r *ReadOnlyRepository) GetTasksDueBetween(ctx context.Context, start, end time.Time) ([]*domain.Task, error) {
+	return r.inner.GetTasksDueBetween(ctx, start, end)
+}
+
+func (r *ReadOnlyRepository) GetStatusHistory(ctx context.Context, id domain.TaskID) ([]domain.StatusChange, error) {
+	return r.inner.GetStatusHistory(ctx, id)
+}
+
+// Task writes
+
+func (r *ReadOnlyRepository) CreateTask(ctx context.Context, task *domain.Task) error {
+	return readOnly("create task")
+}
+
+func (r *ReadOnlyRepository) UpdateTask(ctx context.Context, task *domain.Task) error {
+	return readOnly("update task")
+}
+
+func (r *ReadOnlyRepository) DeleteTask(ctx context.Context, id domain.TaskID) error {
+	return readOnly("delete task")
+}
+
+func (r *ReadOnlyRepository) BulkUpdateStatus(ctx context.Context, taskIDs []domain.TaskID, status domain.TaskStatus) error {
+	return readOnly("update task statuses")
+}
+
+// User reads
+
+func (r *ReadOnlyRepository) GetUser(ctx context.Context, id domain.UserID) (*domain.User, error) {
+	return r.inner.GetUser(ctx, id)
+}
+
+func (r *ReadOnlyRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.inner.GetByEmail(ctx, email)
+}
+
+func (r *ReadOnlyRepository) GetAllUsers(ctx context.Context) ([]*domain.User, error) {
+	return r.inner.GetAllUsers(ctx)
+}
+
+func (r *ReadOnlyRepository) QueryUsers(ctx context.Context, filter domain.UserFilter, page domain.Page) ([]*domain.User, int, error) {
+	return r.inner.QueryUsers(ctx, filter, page)
+}
+
+// User writes
+
+func (r *ReadOnlyRepository) CreateUser(ctx context.Context, user *domain.User) error {
+	return readOnly("create user")
+}
+
+func (r *ReadOnlyRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	return readOnly("update user")
+}
+
+func (r *ReadOnlyRepository) DeleteUser(ctx context.Context, id domain.UserID) error {
+	return readOnly("delete user")
+}
+
+// Session reads
+
+func (r *ReadOnlyRepository) GetSession(ctx context.Context, token string) (*domain.Session, error) {
+	return r.inner.GetSession(ctx, token)
+}
+
+func (r *ReadOnlyRepository) GetSessionByUser(ctx context.Context, userID domain.UserID) (*domain.Session, error) {
+	return r.inner.GetSessionByUser(ctx, userID)
+}
+
+func (r *ReadOnlyRepository) GetActiveSessions(ctx context.Context) ([]*domain.Session, error) {
+	return r.inner.GetActiveSessions(ctx)
+}
+
+// Session writes
+
+func (r *ReadOnlyRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	return readOnly("create session")
+}
+
+func (r *ReadOnlyRepository) UpdateSession(ctx context.Context, session *domain.Session) error {
+	return readOnly("update session")
+}
+
+func (r *ReadOnlyRepository) DeleteSession(ctx context.Context, token string) error {
+	return readOnly("delete session")
+}
+
+func (r *ReadOnlyRepository) DeleteUserSessions(ctx context.Context, userID domain.UserID) error {
+	return readOnly("delete user sessions")
+}
+
+// Comments and attachments
+
+func (r *ReadOnlyRepository) GetCommentsByTask(ctx context.Context, taskID domain.TaskID) ([]*domain.Comment, error) {
+	return r.inner.GetCommentsByTask(ctx, taskID)
+}
+
+func (r *ReadOnlyRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	return readOnly("create comment")
+}
+
+func (r *ReadOnlyRepository) DeleteTaskComments(ctx context.Context, taskID domain.TaskID) error {
+	return readOnly("delete comments")
+}
+
+func (r *ReadOnlyRepository) GetAttachmentsByTask(ctx context.Context, taskID domain.TaskID) ([]*domain.Attachment, error) {
+	return r.inner.GetAttachmentsByTask(ctx, taskID)
+}
+
+func (r *ReadOnlyRepository) CreateAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	return readOnly("create attachment")
+}
+
+func (r *ReadOnlyRepository) DeleteTaskAttachments(ctx context.Context, taskID domain.TaskID) error {
+	return readOnly("delete attachments")
+}
+
+// System state reads
+
+func (r *ReadOnlyRepository) GetSystemState(ctx context.Context) (*domain.SystemState, error) {
+	return r.inner.GetSystemState(ctx)
+}
+
+func (r *ReadOnlyRepository) GetNextTaskID(ctx context.Context) (domain.TaskID, error) {
+	return r.inner.GetNextTaskID(ctx)
+}
+
+func (r *ReadOnlyRepository) GetCurrentUser(ctx context.Context) (*domain.UserID, error) {
+	return r.inner.GetCurrentUser(ctx)
+}
+
+func (r *ReadOnlyRepository) GetUserTasks(ctx context.Context, userID domain.UserID) ([]domain.TaskID, error) {
+	return r.inner.GetUserTasks(ctx, userID)
+}
+
+// System state writes
+
+func (r *ReadOnlyRepository) SaveSystemState(ctx context.Context, state *domain.SystemState) error {
+	return readOnly("save system state")
+}
+
+func (r *ReadOnlyRepository) IncrementNextTaskID(ctx context.Context) (domain.TaskID, error) {
+	return 0, readOnly("allocate task ID")
+}
+
+func (r *ReadOnlyRepository) SetCurrentUser(ctx context.Context, userID *domain.UserID) error {
+	return readOnly("set current user")
+}
+
+func (r *ReadOnlyRepository) AddUserTask(ctx context.Context, userID domain.UserID, taskID domain.TaskID) error {
+	return readOnly("add user task")
+}
+
+func (r *ReadOnlyRepository) RemoveUserTask(ctx context.Context, userID domain.UserID, taskID domain.TaskID) error {
+	return readOnly("remove user task")
+}
+
+func (r *ReadOnlyRepository) RebuildUserTaskIndex(ctx context.Context) (int, error) {
+	return 0, readOnly("rebuild user task index")
+}
+
+// Activity
+
+func (r *ReadOnlyRepository) QueryActivity(ctx context.Context, filter domain.ActivityFilter, offset, limit int) ([]*domain.Activity, int, error) {
+	return r.inner.QueryActivity(ctx, filter, offset, limit)
+}
+
+func (r *ReadOnlyRepository) RecordActivity(ctx context.Context, activity *domain.Activity) error {
+	return readOnly("record activity")
+}
@@ -2,65 +2,132 @@
 package repository
 
 import (
+	"context"
+	"time"
+	
 	"github.com/bhatti/sample-task-management/internal/domain"
 )
 
-// TaskRepository defines the interface for task persistence
+// TaskRepository defines the interface for task persistence.
+// Implementations own the userTasks mapping: CreateTask, UpdateTask and
+// DeleteTask keep each task in exactly its assignee's task list, and archived
+// tasks in none.
 type TaskRepository interface {
 	// Task operations
-	CreateTask(task *domain.Task) error
-	GetTask(id domain.TaskID) (*domain.Task, error)
-	UpdateTask(task *domain.Task) error
-	DeleteTask(id domain.TaskID) error
-	GetAllTasks() (map[domain.TaskID]*domain.Task, error)
-	GetTasksByUser(userID domain.UserID) ([]*domain.Task, error)
-	GetTasksByStatus(status domain.TaskStatus) ([]*domain.Task, error)
-	GetTasksByDependency(taskID domain.TaskID) ([]*domain.Task, error)
+	CreateTask(ctx context.Context, task *domain.Task) error
+	GetTask(ctx context.Context, id domain.TaskID) (*domain.Task, error)
+	UpdateTask(ctx context.Context, task *domain.Task) error
+	DeleteTask(ctx context.Context, id domain.TaskID) error
+	GetAllTasks(ctx context.Context) (map[domain.TaskID]*domain.Task, error)
+	GetTasksByUser(ctx context.Context, userID domain.UserID) ([]*domain.Task, error)
+	// GetTasksByCreator returns the tasks created by a user, archived or not
+	GetTasksByCreator(ctx context.Context, userID domain.UserID) ([]*domain.Task, error)
+	GetTasksByStatus(ctx context.Context, status domain.TaskStatus) ([]*domain.Task, error)
+	GetTasksByDependency(ctx context.Context, taskID domain.TaskID) ([]*domain.Task, error)
+	GetTasksDueBetween(ctx context.Context, start, end time.Time) ([]*domain.Task, error)
+	
+	// GetStatusHistory returns the statuses a task has entered, oldest first.
+	// Implementations record the initial status on CreateTask and every status
+	// change on UpdateTask and BulkUpdateStatus, at the task's UpdatedAt.
+	GetStatusHistory(ctx context.Context, id domain.TaskID) ([]domain.StatusChange, error)
 	
 	// Bulk operations
-	BulkUpdateStatus(taskIDs []domain.TaskID, status domain.TaskStatus) error
+	BulkUpdateStatus(ctx context.Context, taskIDs []domain.TaskID, status domain.TaskStatus) error
 }
 
 // UserRepository defines the interface for user persistence
 type UserRepository interface {
-	CreateUser(user *domain.User) error
-	GetUser(id domain.UserID) (*domain.User, error)
-	GetAllUsers() ([]*domain.User, error)
-	UpdateUser(user *domain.User) error
-	DeleteUser(id domain.UserID) error
+	CreateUser(ctx context.Context, user *domain.User) error
+	GetUser(ctx context.Context, id domain.UserID) (*domain.User, error)
+	// GetByEmail looks a user up by email, compared after domain.NormalizeEmail
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetAllUsers(ctx context.Context) ([]*domain.User, error)
+	// QueryUsers returns the page of users matching the filter, in filter
+	// order, along with the number of matching users
+	QueryUsers(ctx context.Context, filter domain.UserFilter, page domain.Page) ([]*domain.User, int, error)
+	UpdateUser(ctx context.Context, user *domain.User) error
+	DeleteUser(ctx context.Context, id domain.UserID) error
 }
 
 // SessionRepository defines the interface for session management
 type SessionRepository interface {
-	CreateSession(session *domain.Session) error
-	GetSession(token string) (*domain.Session, error)
-	GetSessionByUser(userID domain.UserID) (*domain.Session, error)
-	UpdateSession(session *domain.Session) error
-	DeleteSession(token string) error
-	DeleteUserSessions(userID domain.UserID) error
-	GetActiveSessions() ([]*domain.Session, error)
+	CreateSession(ctx context.Context, session *domain.Session) error
+	GetSession(ctx context.Context, token string) (*domain.Session, error)
+	GetSessionByUser(ctx context.Context, userID domain.UserID) (*domain.Session, error)
+	UpdateSession(ctx context.Context, session *domain.Session) error
+	DeleteSession(ctx context.Context, token string) error
+	DeleteUserSessions(ctx context.Context, userID domain.UserID) error
+	GetActiveSessions(ctx context.Context) ([]*domain.Session, error)
+}
+
+// CommentRepository defines the interface for task comment persistence
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *domain.Comment) error
+	GetCommentsByTask(ctx context.Context, taskID domain.TaskID) ([]*domain.Comment, error)
+	DeleteTaskComments(ctx context.Context, taskID domain.TaskID) error
+}
+
+// AttachmentRepository defines the interface for task attachment metadata persistence
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *domain.Attachment) error
+	GetAttachmentsByTask(ctx context.Context, taskID domain.TaskID) ([]*domain.Attachment, error)
+	DeleteTaskAttachments(ctx context.Context, taskID domain.TaskID) error
 }
 
 // SystemStateRepository defines the interface for system state persistence
 type SystemStateRepository interface {
-	GetSystemState() (*domain.SystemState, error)
-	SaveSystemState(state *domain.SystemState) error
-	GetNextTaskID() (domain.TaskID, error)
-	IncrementNextTaskID() (domain.TaskID, error)
-	GetCurrentUser() (*domain.UserID, error)
-	SetCurrentUser(userID *domain.UserID) error
-	GetUserTasks(userID domain.UserID) ([]domain.TaskID, error)
-	AddUserTask(userID domain.UserID, taskID domain.TaskID) error
-	RemoveUserTask(userID domain.UserID, taskID domain.TaskID) error
+	GetSystemState(ctx context.Context) (*domain.SystemState, error)
+	SaveSystemState(ctx context.Context, state *domain.SystemState) error
+	GetNextTaskID(ctx context.Context) (domain.TaskID, error)
+	IncrementNextTaskID(ctx context.Context) (domain.TaskID, error)
+	GetCurrentUser(ctx context.Context) (*domain.UserID, error)
+	SetCurrentUser(ctx context.Context, userID *domain.UserID) error
+	GetUserTasks(ctx context.Context, userID domain.UserID) ([]domain.TaskID, error)
+	AddUserTask(ctx context.Context, userID domain.UserID, taskID domain.TaskID) error
+	RemoveUserTask(ctx context.Context, userID domain.UserID, taskID domain.TaskID) error
+	RebuildUserTaskIndex(ctx context.Context) (int, error)
+}
+
+// ActivityRepository defines the interface for the append-only activity log
+type ActivityRepository interface {
+	// RecordActivity appends an entry, assigning its ID
+	RecordActivity(ctx context.Context, activity *domain.Activity) error
+	// QueryActivity returns up to limit entries matching the filter, newest
+	// first, after skipping offset of them, along with the number of
+	// matching entries
+	QueryActivity(ctx context.Context, filter domain.ActivityFilter, offset, limit int) ([]*domain.Activity, int, error)
 }
 
-// UnitOfWork defines a transaction boundary for operations
+// UnitOfWork defines a transaction boundary for operations.
+//
+// Lock and RLock bracket a whole use-case operation, from its first read to
+// its commit: an operation holding Lock sees no concurrent change between
+// checking its preconditions and writing, and one holding RLock sees no
+// partial, uncommitted change. Neither lock is reentrant. Each is called and
+// released with the context of the operation.
+//
+// A scope groups the operations run with its context, such as those of one
+// request, so they can be rolled back together. BeginScope waits until no
+// other scope is open and no operation is running, and returns the context
+// of the new scope. Until EndScope, operations with any other context wait
+// in Lock and RLock, so rolling the scope back discards nothing else.
 type UnitOfWork interface {
-	Begin() error
+	Begin(ctx context.Context) error
 	Commit() error
 	Rollback() error
+	Lock(ctx context.Context)
+	Unlock(ctx context.Context)
+	RLock(ctx context.Context)
+	RUnlock(ctx context.Context)
+	BeginScope(ctx context.Context) context.Context
+	// EndScope closes the scope of ctx, first rolling back every change
+	// committed since BeginScope if rollback is set
+	EndScope(ctx context.Context, rollback bool)
 	Tasks() TaskRepository
 	Users() UserRepository
 	Sessions() SessionRepository
+	Comments() CommentRepository
+	Attachments() AttachmentRepository
+	Activity() ActivityRepository
 	SystemState() SystemStateRepository
 }
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+const (
+	// DefaultActivityLimit is the page size of the activity feed when none is given
+	DefaultActivityLimit = 50
+	// MaxActivityLimit is the largest page size of the activity feed
+	MaxActivityLimit = 200
+)
+
+// GetActivity returns a page of the activity log, newest first: up to limit
+// entries matching the filter after skipping offset of them, along with the
+// number of matching entries. A zero limit selects DefaultActivityLimit.
+func (uc *TaskUseCase) GetActivity(ctx context.Context, filter domain.ActivityFilter, offset, limit int) ([]*domain.Activity, int, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	if limit == 0 {
+		limit = DefaultActivityLimit
+	}
+	if limit < 0 || limit > MaxActivityLimit {
+		return nil, 0, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidPage, MaxActivityLimit)
+	}
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("%w: offset cannot be negative", domain.ErrInvalidPage)
+	}
+	if filter.Before < 0 {
+		return nil, 0, fmt.Errorf("%w: before cannot be negative", domain.ErrInvalidPage)
+	}
+
+	activities, total, err := uc.uow.Activity().QueryActivity(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query activity: %w", err)
+	}
+
+	return activities, total, nil
+}
+
+// GetAssignmentHistory returns the reassignments of a task, oldest first, as
+// recorded in the activity log. Reassigning a task to its current assignee
+// changes nothing and is left out, so a long history marks a task that keeps
+// being handed on.
+func (uc *TaskUseCase) GetAssignmentHistory(ctx context.Context, taskID domain.TaskID) ([]domain.AssignmentChange, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	if _, err := uc.uow.Tasks().GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	filter := domain.ActivityFilter{TaskID: taskID, Action: domain.EventTaskReassigned}
+	_, total, err := uc.uow.Activity().QueryActivity(ctx, filter, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query activity: %w", err)
+	}
+	activities, _, err := uc.uow.Activity().QueryActivity(ctx, filter, 0, total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query activity: %w", err)
+	}
+
+	// The log is newest first
+	history := make([]domain.AssignmentChange, 0, len(activities))
+	for i := len(activities) - 1; i >= 0; i-- {
+		activity := activities[i]
+		from, to := domain.UserID(activity.Data["from"]), domain.UserID(activity.Data["to"])
+		if from == to {
+			continue
+		}
+		history = append(history, domain.AssignmentChange{From: from, To: to, By: activity.Actor, At: activity.At})
+	}
+
+	return history, nil
+}
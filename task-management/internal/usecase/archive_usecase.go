@@ -0,0 +1,382 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+)
+
+// RestoreTask un-archives a soft-deleted task and returns it to its
+// assignee's task list
+func (uc *TaskUseCase) RestoreTask(ctx context.Context, taskID domain.TaskID) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have permission to restore task %d", taskID)
+	}
+
+	if !task.Archived {
+		return nil, fmt.Errorf("task %d is not archived", taskID)
+	}
+
+	// The assignee may have been removed while the task was archived
+	if _, err := uc.uow.Users().GetUser(ctx, task.Assignee); err != nil {
+		return nil, fmt.Errorf("%w: assignee %s: %w", domain.ErrUserNotFound, task.Assignee, err)
+	}
+
+	task.Archived = false
+	task.UpdatedAt = uc.now()
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit task restore: %w", err)
+	}
+
+	return task, nil
+}
+
+// PurgeTask permanently deletes a task with its comments and attachments.
+// Only admins may purge; the task may be archived or not, but must still
+// satisfy the DeleteTask preconditions on status and dependents.
+func (uc *TaskUseCase) PurgeTask(ctx context.Context, taskID domain.TaskID) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return fmt.Errorf("current user not found: %w", err)
+	}
+
+	reason, err := uc.purgeBlocker(ctx, actor, task)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return errors.New(reason)
+	}
+
+	// Delete task, comments and attachments together
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := uc.uow.Tasks().DeleteTask(ctx, taskID); err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	if err := uc.uow.Comments().DeleteTaskComments(ctx, taskID); err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to delete task comments: %w", err)
+	}
+
+	if err := uc.uow.Attachments().DeleteTaskAttachments(ctx, taskID); err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to delete task attachments: %w", err)
+	}
+
+	if err := uc.detachSubtasks(ctx, map[domain.TaskID]bool{taskID: true}); err != nil {
+		uc.uow.Rollback()
+		return err
+	}
+
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task deletion: %w", err)
+	}
+
+	return nil
+}
+
+// CanDeleteTask reports whether the current user could archive the task with
+// DeleteTask, without archiving it. A task that cannot be archived yields
+// false with the reason DeleteTask would give; errors are reserved for
+// failures to evaluate the check, such as an unknown task.
+func (uc *TaskUseCase) CanDeleteTask(ctx context.Context, taskID domain.TaskID) (bool, string, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	return uc.canDelete(ctx, taskID, uc.archiveBlocker)
+}
+
+// CanPurgeTask is CanDeleteTask for permanent deletion with PurgeTask
+func (uc *TaskUseCase) CanPurgeTask(ctx context.Context, taskID domain.TaskID) (bool, string, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	return uc.canDelete(ctx, taskID, uc.purgeBlocker)
+}
+
+// deletePrecondition returns why the actor may not delete the task, or "" if
+// nothing stands in the way
+type deletePrecondition func(ctx context.Context, actor *domain.User, task *domain.Task) (string, error)
+
+func (uc *TaskUseCase) canDelete(ctx context.Context, taskID domain.TaskID, blocker deletePrecondition) (bool, string, error) {
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return false, "", domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return false, "", fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return false, "", fmt.Errorf("current user not found: %w", err)
+	}
+
+	reason, err := blocker(ctx, actor, task)
+	if err != nil {
+		return false, "", err
+	}
+	return reason == "", reason, nil
+}
+
+// archiveBlocker checks the DeleteTask preconditions: the actor manages the
+// task, which is not archived yet, and the task is deletable. Dependents do
+// not stand in the way when DeleteTask detaches them.
+func (uc *TaskUseCase) archiveBlocker(ctx context.Context, actor *domain.User, task *domain.Task) (string, error) {
+	// Check user owns the task or is an admin
+	if !canManage(actor, task) {
+		return fmt.Sprintf("user does not have permission to delete task %d", task.ID), nil
+	}
+
+	if task.Archived {
+		return fmt.Sprintf("task %d is already archived", task.ID), nil
+	}
+
+	return uc.deletionBlocker(ctx, task, uc.config.DetachDependentsOnDelete)
+}
+
+// purgeBlocker checks the PurgeTask preconditions: the actor is an admin and
+// the task, archived or not, is deletable
+func (uc *TaskUseCase) purgeBlocker(ctx context.Context, actor *domain.User, task *domain.Task) (string, error) {
+	if !actor.IsAdmin() {
+		return fmt.Sprintf("only admins can permanently delete task %d", task.ID), nil
+	}
+
+	return uc.deletionBlocker(ctx, task, false)
+}
+
+// deletionBlocker checks the task is completed or cancelled and, unless
+// dependents are allowed, that no other task depends on it
+func (uc *TaskUseCase) deletionBlocker(ctx context.Context, task *domain.Task, allowDependents bool) (string, error) {
+	if !task.CanDelete() {
+		return "can only delete completed or cancelled tasks", nil
+	}
+	if allowDependents {
+		return "", nil
+	}
+
+	dependentTasks, err := uc.uow.Tasks().GetTasksByDependency(ctx, task.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check dependencies: %w", err)
+	}
+
+	if len(dependentTasks) > 0 {
+		return fmt.Sprintf("cannot delete task %d: %d tasks depend on it", task.ID, len(dependentTasks)), nil
+	}
+
+	return "", nil
+}
+
+// PurgeOldTasks permanently deletes completed and cancelled tasks, archived or
+// not, that have not been updated for longer than olderThan. Only admins may
+// purge; the number of deleted tasks is returned.
+func (uc *TaskUseCase) PurgeOldTasks(ctx context.Context, olderThan time.Duration) (int, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return 0, domain.ErrUnauthenticated
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return 0, fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !actor.IsAdmin() {
+		return 0, fmt.Errorf("only admins can purge old tasks")
+	}
+
+	return uc.sweepOldTasks(ctx, olderThan)
+}
+
+// SweepOldTasks applies the PurgeOldTasks retention policy without an
+// authorization check, for use by scheduled jobs rather than user requests.
+// Tasks that another task depends on are kept, even when the dependent is
+// purged in the same sweep; a later sweep deletes them.
+func (uc *TaskUseCase) SweepOldTasks(ctx context.Context, olderThan time.Duration) (int, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	return uc.sweepOldTasks(ctx, olderThan)
+}
+
+// sweepOldTasks purges old finished tasks; the caller holds the lock
+func (uc *TaskUseCase) sweepOldTasks(ctx context.Context, olderThan time.Duration) (int, error) {
+	if olderThan < 0 {
+		return 0, fmt.Errorf("retention period cannot be negative: %v", olderThan)
+	}
+	cutoff := uc.now().Add(-olderThan)
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return 0, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	dependedUpon := make(map[domain.TaskID]bool)
+	for _, task := range allTasks {
+		for depID := range task.Dependencies {
+			dependedUpon[depID] = true
+		}
+	}
+
+	purged := make(map[domain.TaskID]bool)
+	for id, task := range allTasks {
+		if !task.CanDelete() || !task.UpdatedAt.Before(cutoff) || dependedUpon[id] {
+			continue
+		}
+
+		if err := uc.uow.Tasks().DeleteTask(ctx, id); err != nil {
+			uc.uow.Rollback()
+			return 0, fmt.Errorf("failed to delete task %d: %w", id, err)
+		}
+		if err := uc.uow.Comments().DeleteTaskComments(ctx, id); err != nil {
+			uc.uow.Rollback()
+			return 0, fmt.Errorf("failed to delete comments of task %d: %w", id, err)
+		}
+		if err := uc.uow.Attachments().DeleteTaskAttachments(ctx, id); err != nil {
+			uc.uow.Rollback()
+			return 0, fmt.Errorf("failed to delete attachments of task %d: %w", id, err)
+		}
+		purged[id] = true
+	}
+
+	if err := uc.detachSubtasks(ctx, purged); err != nil {
+		uc.uow.Rollback()
+		return 0, err
+	}
+
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return 0, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return 0, fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit purge: %w", err)
+	}
+
+	return len(purged), nil
+}
+
+// detachSubtasks makes the remaining subtasks of deleted tasks top-level
+// tasks, so no task is left with a parent that no longer exists
+func (uc *TaskUseCase) detachSubtasks(ctx context.Context, deleted map[domain.TaskID]bool) error {
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	now := uc.now()
+	for _, task := range allTasks {
+		if task.ParentID == 0 || !deleted[task.ParentID] {
+			continue
+		}
+		task.ParentID = 0
+		task.UpdatedAt = now
+		if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to detach subtask %d: %w", task.ID, err)
+		}
+	}
+	return nil
+}
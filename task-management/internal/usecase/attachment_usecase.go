@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// AddAttachment records metadata for a file the current user uploaded to an
+// existing task; the file itself is stored elsewhere and referenced by URL
+func (uc *TaskUseCase) AddAttachment(ctx context.Context, taskID domain.TaskID, filename, url string, size int64) (*domain.Attachment, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if _, err := uc.uow.Tasks().GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	attachment := &domain.Attachment{
+		TaskID:     taskID,
+		Filename:   filename,
+		URL:        url,
+		Size:       size,
+		UploadedBy: *currentUser,
+		UploadedAt: uc.now(),
+	}
+
+	if err := attachment.Validate(); err != nil {
+		return nil, fmt.Errorf("attachment validation failed: %w", err)
+	}
+
+	if err := uc.uow.Attachments().CreateAttachment(ctx, attachment); err != nil {
+		return nil, fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// ListAttachments returns the attachments of a task in upload order
+func (uc *TaskUseCase) ListAttachments(ctx context.Context, taskID domain.TaskID) ([]*domain.Attachment, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	if _, err := uc.uow.Tasks().GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	attachments, err := uc.uow.Attachments().GetAttachmentsByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	return attachments, nil
+}
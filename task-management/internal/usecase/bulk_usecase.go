@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// ValidateBulkTransition reports, without changing anything, whether each of
+// the tasks could be moved to newStatus by the current user: the result maps
+// every task ID to nil or to the reason it would be rejected. The checks are
+// those of BulkUpdateStatus and UpdateTaskStatus (the task exists, belongs to
+// the user, allows the transition and has its dependencies ready), so a task
+// that passes can be moved either way. An unauthenticated user or an unknown
+// status fails the whole request.
+func (uc *TaskUseCase) ValidateBulkTransition(ctx context.Context, taskIDs []domain.TaskID, newStatus domain.TaskStatus) (map[domain.TaskID]error, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if !uc.config.TransitionPolicy.HasStatus(newStatus) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, newStatus)
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	results := make(map[domain.TaskID]error, len(taskIDs))
+	for _, taskID := range taskIDs {
+		results[taskID] = uc.checkStatusChange(allTasks, taskID, *currentUser, newStatus)
+	}
+
+	return results, nil
+}
+
+// checkStatusChange returns why the user could not move the task to
+// newStatus, or nil if they could
+func (uc *TaskUseCase) checkStatusChange(allTasks map[domain.TaskID]*domain.Task, taskID domain.TaskID, userID domain.UserID, newStatus domain.TaskStatus) error {
+	task, exists := allTasks[taskID]
+	if !exists {
+		return fmt.Errorf("%w: %d", domain.ErrTaskNotFound, taskID)
+	}
+	if !task.IsOwner(userID) {
+		return fmt.Errorf("user does not have access to task %d", taskID)
+	}
+	if !uc.config.TransitionPolicy.IsValid(task.Status, newStatus) {
+		return fmt.Errorf("%w for task %d from %s to %s", domain.ErrInvalidTransition, taskID, task.Status, newStatus)
+	}
+
+	switch newStatus {
+	case domain.StatusInProgress:
+		if waiting := task.IncompleteDependencies(allTasks); len(waiting) > 0 {
+			return fmt.Errorf("cannot start task %d: dependency %d is not completed", taskID, waiting[0])
+		}
+	case domain.StatusCompleted:
+		if uc.config.RequireDependenciesToComplete {
+			if waiting := task.IncompleteDependencies(allTasks); len(waiting) > 0 {
+				return fmt.Errorf("cannot complete task %d: dependency %d is not completed", taskID, waiting[0])
+			}
+		}
+	case domain.StatusBlocked:
+		if !task.IsBlocked(allTasks) {
+			return fmt.Errorf("cannot block task %d: it has no incomplete dependencies", taskID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+)
+
+// AddCollaborators shares ownership of a task with the given users, who can then
+// update its status and find it in their task list. Users who already own the
+// task are skipped; every user must exist.
+func (uc *TaskUseCase) AddCollaborators(ctx context.Context, taskID domain.TaskID, userIDs []domain.UserID) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have permission to share task %d", taskID)
+	}
+
+	if task.Archived {
+		return nil, fmt.Errorf("task %d is archived", taskID)
+	}
+
+	// Copy the list so the stored task is only changed through UpdateTask
+	task.Collaborators = append([]domain.UserID(nil), task.Collaborators...)
+	added := false
+	for _, userID := range userIDs {
+		if _, err := uc.uow.Users().GetUser(ctx, userID); err != nil {
+			return nil, fmt.Errorf("%w: collaborator %s: %w", domain.ErrUserNotFound, userID, err)
+		}
+		if task.IsOwner(userID) {
+			continue
+		}
+		task.Collaborators = append(task.Collaborators, userID)
+		added = true
+	}
+	if !added {
+		return task, nil
+	}
+	task.UpdatedAt = uc.now()
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to add collaborators: %w", err)
+	}
+
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit collaborators: %w", err)
+	}
+
+	return task, nil
+}
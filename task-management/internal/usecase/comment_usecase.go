@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// AddComment adds a comment by the current user to an existing task
+func (uc *TaskUseCase) AddComment(ctx context.Context, taskID domain.TaskID, body string) (*domain.Comment, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if _, err := uc.uow.Tasks().GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	comment := &domain.Comment{
+		TaskID:    taskID,
+		Author:    *currentUser,
+		Body:      body,
+		CreatedAt: uc.now(),
+	}
+
+	if err := comment.Validate(); err != nil {
+		return nil, fmt.Errorf("comment validation failed: %w", err)
+	}
+
+	if err := uc.uow.Comments().CreateComment(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// ListComments returns the comments of a task in creation order
+func (uc *TaskUseCase) ListComments(ctx context.Context, taskID domain.TaskID) ([]*domain.Comment, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	if _, err := uc.uow.Tasks().GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	comments, err := uc.uow.Comments().GetCommentsByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+)
+
+// AddDependency makes a task depend on another existing, non-cancelled task.
+// A pending or in-progress task whose new dependency is not yet completed
+// becomes blocked. Adding an existing dependency is a no-op.
+func (uc *TaskUseCase) AddDependency(ctx context.Context, taskID, depID domain.TaskID) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	return uc.updateDependencies(ctx, taskID, depID, true)
+}
+
+// RemoveDependency drops a dependency of a task. A blocked task whose
+// remaining dependencies are all completed returns to pending.
+func (uc *TaskUseCase) RemoveDependency(ctx context.Context, taskID, depID domain.TaskID) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	return uc.updateDependencies(ctx, taskID, depID, false)
+}
+
+func (uc *TaskUseCase) updateDependencies(ctx context.Context, taskID, depID domain.TaskID, add bool) (*domain.Task, error) {
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have access to task %d", taskID)
+	}
+
+	if task.Dependencies[depID] == add {
+		if add {
+			return task, nil
+		}
+		return nil, fmt.Errorf("%w: task %d does not depend on task %d", domain.ErrInvalidDependency, taskID, depID)
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	// Copy the set so the stored task is only changed through UpdateTask
+	dependencies := make(map[domain.TaskID]bool, len(task.Dependencies)+1)
+	for id := range task.Dependencies {
+		dependencies[id] = true
+	}
+
+	if add {
+		if depID == taskID {
+			return nil, fmt.Errorf("%w: task %d cannot depend on itself", domain.ErrInvalidDependency, taskID)
+		}
+		if task.Status == domain.StatusCompleted || task.Status == domain.StatusCancelled {
+			return nil, fmt.Errorf("%w: task %d is already %s", domain.ErrInvalidDependency, taskID, task.Status)
+		}
+		depTask, exists := allTasks[depID]
+		if !exists {
+			return nil, fmt.Errorf("%w: task %d does not exist", domain.ErrInvalidDependency, depID)
+		}
+		if depTask.Status == domain.StatusCancelled {
+			return nil, fmt.Errorf("%w: cannot depend on cancelled task %d", domain.ErrInvalidDependency, depID)
+		}
+		dependencies[depID] = true
+
+		// Check the graph with the task's new edges in place of its old ones
+		others := make(map[domain.TaskID]*domain.Task, len(allTasks))
+		for id, other := range allTasks {
+			if id != taskID {
+				others[id] = other
+			}
+		}
+		if err := uc.checkCyclicDependencies(taskID, dependencies, others); err != nil {
+			return nil, err
+		}
+		if err := uc.checkDependencyDepth(taskID, dependencies, others); err != nil {
+			return nil, err
+		}
+	} else {
+		delete(dependencies, depID)
+	}
+	task.Dependencies = dependencies
+
+	// Recompute the blocked status from the new dependencies
+	oldStatus := task.Status
+	switch {
+	case add && (task.Status == domain.StatusPending || task.Status == domain.StatusInProgress) && task.IsBlocked(allTasks):
+		task.Status = domain.StatusBlocked
+	case !add && task.ShouldUnblock(allTasks):
+		task.Status = domain.StatusPending
+	}
+	if task.Status != oldStatus && !uc.config.TransitionPolicy.IsValid(oldStatus, task.Status) {
+		return nil, fmt.Errorf("%w from %s to %s", domain.ErrInvalidTransition, oldStatus, task.Status)
+	}
+	task.UpdatedAt = uc.now()
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to update dependencies: %w", err)
+	}
+
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit dependencies: %w", err)
+	}
+	if task.Status != oldStatus {
+		metrics.RecordTransition(oldStatus, task.Status)
+		uc.notifyStatusChange(ctx, task, oldStatus, *currentUser)
+	}
+	if oldStatus == domain.StatusBlocked && task.Status == domain.StatusPending {
+		// Removing the last incomplete dependency is what made the task ready
+		uc.publish(ctx, domain.EventTaskUnblocked, task, *currentUser, map[string]string{
+			"dependency": strconv.Itoa(int(depID)),
+		})
+	}
+
+	return task, nil
+}
+
+// notifyUnblocked publishes the task.unblocked event of a task whose
+// dependencies have all been completed, naming the dependency completed last
+// according to the status histories
+func (uc *TaskUseCase) notifyUnblocked(ctx context.Context, task *domain.Task, allTasks map[domain.TaskID]*domain.Task, actor domain.UserID) error {
+	var last domain.TaskID
+	var lastAt time.Time
+	for _, depID := range domain.SortedTaskIDs(task.Dependencies) {
+		if _, exists := allTasks[depID]; !exists {
+			continue
+		}
+		history, err := uc.uow.Tasks().GetStatusHistory(ctx, depID)
+		if err != nil {
+			return fmt.Errorf("failed to get status history of task %d: %w", depID, err)
+		}
+		for _, change := range history {
+			if change.Status == domain.StatusCompleted && (last == 0 || change.At.After(lastAt)) {
+				last, lastAt = depID, change.At
+			}
+		}
+	}
+
+	var data map[string]string
+	if last != 0 {
+		data = map[string]string{"dependency": strconv.Itoa(int(last))}
+	}
+	uc.publish(ctx, domain.EventTaskUnblocked, task, actor, data)
+	return nil
+}
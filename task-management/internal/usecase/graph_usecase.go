@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// GetDependencyGraph returns the dependency graph as an adjacency list mapping
+// every task to the sorted IDs of the tasks it depends on
+func (uc *TaskUseCase) GetDependencyGraph(ctx context.Context) (map[domain.TaskID][]domain.TaskID, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	tasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	graph := make(map[domain.TaskID][]domain.TaskID, len(tasks))
+	for id, task := range tasks {
+		deps := make([]domain.TaskID, 0, len(task.Dependencies))
+		for depID := range task.Dependencies {
+			deps = append(deps, depID)
+		}
+		sort.Slice(deps, func(i, j int) bool { return deps[i] < deps[j] })
+		graph[id] = deps
+	}
+
+	return graph, nil
+}
+
+// CheckDependencyGraph reports whether the dependency graph is acyclic using
+// the invariant checker's NoCyclicDependencies check
+func (uc *TaskUseCase) CheckDependencyGraph(ctx context.Context) error {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	return uc.checkDependencyGraph(ctx)
+}
+
+func (uc *TaskUseCase) checkDependencyGraph(ctx context.Context) error {
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get system state: %w", err)
+	}
+
+	if err := uc.invariantChecker.CheckNoCyclicDependencies(state); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrCyclicDependency, err)
+	}
+
+	return nil
+}
+
+// GetDependencyProgress returns the dependency completion fraction of each of
+// the given tasks, keyed by task ID
+func (uc *TaskUseCase) GetDependencyProgress(ctx context.Context, tasks []*domain.Task) (map[domain.TaskID]float64, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	progress := make(map[domain.TaskID]float64, len(tasks))
+	for _, task := range tasks {
+		progress[task.ID] = task.DependencyProgress(allTasks)
+	}
+
+	return progress, nil
+}
+
+// TaskRelations lists a task's dependency edges in both directions
+type TaskRelations struct {
+	// BlockedBy holds the tasks this task depends on
+	BlockedBy []domain.TaskID
+	// Blocks holds the tasks that depend on this task
+	Blocks []domain.TaskID
+}
+
+// GetTaskRelations returns what a task depends on and what depends on it.
+// Only the forward edges are stored; the reverse edges are computed here.
+func (uc *TaskUseCase) GetTaskRelations(ctx context.Context, taskID domain.TaskID) (*TaskRelations, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	dependents, err := uc.uow.Tasks().GetTasksByDependency(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependent tasks: %w", err)
+	}
+
+	relations := &TaskRelations{
+		BlockedBy: make([]domain.TaskID, 0, len(task.Dependencies)),
+		Blocks:    make([]domain.TaskID, 0, len(dependents)),
+	}
+	for depID := range task.Dependencies {
+		relations.BlockedBy = append(relations.BlockedBy, depID)
+	}
+	for _, dependent := range dependents {
+		relations.Blocks = append(relations.Blocks, dependent.ID)
+	}
+	sort.Slice(relations.BlockedBy, func(i, j int) bool { return relations.BlockedBy[i] < relations.BlockedBy[j] })
+	sort.Slice(relations.Blocks, func(i, j int) bool { return relations.Blocks[i] < relations.Blocks[j] })
+
+	return relations, nil
+}
+
+// GetIncompleteDependencies returns the IDs of the task's dependencies that
+// are not completed yet, in ID order: the tasks it waits on
+func (uc *TaskUseCase) GetIncompleteDependencies(ctx context.Context, taskID domain.TaskID) ([]domain.TaskID, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	return task.IncompleteDependencies(allTasks), nil
+}
+
+// GetTransitiveDependencies returns, in ID order and without duplicates, every
+// task the given task depends on directly or through other dependencies:
+// everything that has to finish before it can start. Each task is visited
+// once, so a cycle cannot make the search loop, and the task itself is never
+// included. Dependencies no longer stored are skipped.
+func (uc *TaskUseCase) GetTransitiveDependencies(ctx context.Context, taskID domain.TaskID) ([]domain.TaskID, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	visited := map[domain.TaskID]bool{taskID: true}
+	dependencies := make(map[domain.TaskID]bool)
+	stack := domain.SortedTaskIDs(task.Dependencies)
+	for len(stack) > 0 {
+		depID := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[depID] {
+			continue
+		}
+		visited[depID] = true
+
+		dep, exists := allTasks[depID]
+		if !exists {
+			continue
+		}
+		dependencies[depID] = true
+		for next := range dep.Dependencies {
+			if !visited[next] {
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	return domain.SortedTaskIDs(dependencies), nil
+}
+
+// BlockedTaskReason is a blocked task with the dependencies holding it back
+type BlockedTaskReason struct {
+	Task *domain.Task
+	// WaitingOn holds the incomplete dependencies in ID order
+	WaitingOn []DependencyStatus
+}
+
+// DependencyStatus is the current status of one dependency, with its
+// assignee so the waiting user knows whom to ask about it
+type DependencyStatus struct {
+	TaskID   domain.TaskID
+	Status   domain.TaskStatus
+	Assignee domain.UserID
+}
+
+// GetBlockedTasks returns every blocked task that is not archived, in ID
+// order, with the status and assignee of each dependency it is still waiting on
+func (uc *TaskUseCase) GetBlockedTasks(ctx context.Context) ([]BlockedTaskReason, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	blockedTasks, err := uc.uow.Tasks().GetTasksByStatus(ctx, domain.StatusBlocked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked tasks: %w", err)
+	}
+	sort.Slice(blockedTasks, func(i, j int) bool { return blockedTasks[i].ID < blockedTasks[j].ID })
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	reasons := make([]BlockedTaskReason, 0, len(blockedTasks))
+	for _, task := range blockedTasks {
+		if task.Archived {
+			continue
+		}
+		reason := BlockedTaskReason{Task: task, WaitingOn: []DependencyStatus{}}
+		for _, depID := range task.IncompleteDependencies(allTasks) {
+			reason.WaitingOn = append(reason.WaitingOn, DependencyStatus{
+				TaskID:   depID,
+				Status:   allTasks[depID].Status,
+				Assignee: allTasks[depID].Assignee,
+			})
+		}
+		reasons = append(reasons, reason)
+	}
+
+	return reasons, nil
+}
+
+// TopologicalOrder returns the unarchived tasks ordered so that every task
+// comes after the tasks it depends on. Tasks are emitted in layers: first the
+// tasks without dependencies, then those depending only on earlier layers, and
+// so on; within a layer the most urgent priority comes first, then the lowest
+// ID. Dependencies on archived tasks do not constrain the order. A cycle in
+// the dependency graph yields ErrCyclicDependency.
+func (uc *TaskUseCase) TopologicalOrder(ctx context.Context) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	if err := uc.checkDependencyGraph(ctx); err != nil {
+		return nil, err
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	// remaining counts each task's unplaced dependencies; dependents holds the
+	// reverse edges so placing a task can release the tasks waiting on it
+	remaining := make(map[domain.TaskID]int, len(allTasks))
+	dependents := make(map[domain.TaskID][]domain.TaskID)
+	var layer []*domain.Task
+	for id, task := range allTasks {
+		if task.Archived {
+			continue
+		}
+		for depID := range task.Dependencies {
+			if dep, exists := allTasks[depID]; exists && !dep.Archived {
+				remaining[id]++
+				dependents[depID] = append(dependents[depID], id)
+			}
+		}
+		if remaining[id] == 0 {
+			layer = append(layer, task)
+		}
+	}
+
+	ordered := make([]*domain.Task, 0, len(allTasks))
+	for len(layer) > 0 {
+		sort.Slice(layer, func(i, j int) bool {
+			if layer[i].Priority.Weight() != layer[j].Priority.Weight() {
+				return layer[i].Priority.Weight() > layer[j].Priority.Weight()
+			}
+			return layer[i].ID < layer[j].ID
+		})
+		ordered = append(ordered, layer...)
+
+		var next []*domain.Task
+		for _, task := range layer {
+			for _, dependentID := range dependents[task.ID] {
+				remaining[dependentID]--
+				if remaining[dependentID] == 0 {
+					next = append(next, allTasks[dependentID])
+				}
+			}
+		}
+		layer = next
+	}
+
+	// Tasks never released sit on a cycle that the state check did not see,
+	// e.g. one created between the two reads
+	for id, count := range remaining {
+		if count > 0 {
+			return nil, fmt.Errorf("%w: task %d is part of a dependency cycle", domain.ErrCyclicDependency, id)
+		}
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// SetLabels replaces the labels of a task the current user manages; an empty
+// map removes them all
+func (uc *TaskUseCase) SetLabels(ctx context.Context, taskID domain.TaskID, labels map[string]string) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	// Check user owns the task or is an admin
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have access to task %d", taskID)
+	}
+
+	if err := domain.ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+
+	// Store a copy so the caller's map cannot change the task afterwards
+	var updated map[string]string
+	if len(labels) > 0 {
+		updated = make(map[string]string, len(labels))
+		for key, value := range labels {
+			updated[key] = value
+		}
+	}
+	task.Labels = updated
+	task.UpdatedAt = uc.now()
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update labels: %w", err)
+	}
+
+	return task, nil
+}
+
+// FindByLabel returns the unarchived tasks whose label key has exactly the
+// given value, in ID order
+func (uc *TaskUseCase) FindByLabel(ctx context.Context, key, value string) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	var matches []*domain.Task
+	for _, task := range allTasks {
+		if task.Archived {
+			continue
+		}
+		if labelValue, exists := task.Labels[key]; exists && labelValue == value {
+			matches = append(matches, task)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+
+	return matches, nil
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// TaskPatch lists the task fields to change; nil fields are left untouched.
+// Status and assignee have dedicated actions and cannot be patched.
+type TaskPatch struct {
+	Title          *string
+	Description    *string
+	Priority       *domain.Priority
+	Tags           *[]domain.Tag
+	EstimatedHours *float64
+
+	// DueDate sets a new due date; ClearDueDate removes it
+	DueDate      *time.Time
+	ClearDueDate bool
+}
+
+// PatchTask merges the given fields into an existing task and re-validates it
+func (uc *TaskUseCase) PatchTask(ctx context.Context, taskID domain.TaskID, patch TaskPatch) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	if patch.DueDate != nil && patch.ClearDueDate {
+		return nil, fmt.Errorf("cannot both set and clear the due date")
+	}
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have access to task %d", taskID)
+	}
+
+	if patch.Title != nil {
+		task.Title = *patch.Title
+	}
+	if patch.Description != nil {
+		task.Description = *patch.Description
+	}
+	if patch.Priority != nil {
+		task.Priority = *patch.Priority
+	}
+	if patch.Tags != nil {
+		task.Tags = append([]domain.Tag(nil), (*patch.Tags)...)
+	}
+	if patch.EstimatedHours != nil {
+		task.EstimatedHours = *patch.EstimatedHours
+	}
+	if patch.DueDate != nil {
+		task.DueDate = domain.NormalizeDueDate(patch.DueDate)
+	}
+	if patch.ClearDueDate {
+		task.DueDate = nil
+	}
+	task.UpdatedAt = uc.now()
+
+	if err := task.ValidateWithPolicy(uc.config.TransitionPolicy); err != nil {
+		return nil, fmt.Errorf("task validation failed: %w", err)
+	}
+	if err := uc.checkDueDate(patch.DueDate); err != nil {
+		return nil, err
+	}
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to patch task: %w", err)
+	}
+
+	if err := uc.checkInvariants(ctx); err != nil {
+		uc.uow.Rollback()
+		return nil, err
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit task patch: %w", err)
+	}
+
+	return task, nil
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// GetTasksByUser returns the tasks assigned to a user in the given order,
+// ties broken by ID, optionally restricted to a single status (empty status
+// means all). SortByRank lists them in backlog order. Archived and snoozed
+// tasks are only included when includeArchived and includeSnoozed are set.
+func (uc *TaskUseCase) GetTasksByUser(ctx context.Context, userID domain.UserID, status domain.TaskStatus, includeArchived, includeSnoozed bool, order TaskSort) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	less, err := taskLess(order)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.uow.Users().GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrUserNotFound, err)
+	}
+
+	if status != "" && !uc.config.TransitionPolicy.HasStatus(status) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, status)
+	}
+
+	tasks, err := uc.uow.Tasks().GetTasksByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks for user %s: %w", userID, err)
+	}
+
+	// Archived tasks are not in the user's task list
+	if includeArchived {
+		allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get archived tasks for user %s: %w", userID, err)
+		}
+		for _, task := range allTasks {
+			if task.Archived && task.IsOwner(userID) {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	if !includeSnoozed {
+		tasks = withoutSnoozed(tasks, uc.now())
+	}
+
+	return selectTasks(tasks, status, less), nil
+}
+
+// GetTasksByCreator returns the tasks a user created, whoever they are
+// assigned to, filtered and ordered like GetTasksByUser
+func (uc *TaskUseCase) GetTasksByCreator(ctx context.Context, userID domain.UserID, status domain.TaskStatus, includeArchived, includeSnoozed bool, order TaskSort) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	less, err := taskLess(order)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.uow.Users().GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrUserNotFound, err)
+	}
+
+	if status != "" && !uc.config.TransitionPolicy.HasStatus(status) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, status)
+	}
+
+	created, err := uc.uow.Tasks().GetTasksByCreator(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks created by %s: %w", userID, err)
+	}
+
+	tasks := make([]*domain.Task, 0, len(created))
+	for _, task := range created {
+		if includeArchived || !task.Archived {
+			tasks = append(tasks, task)
+		}
+	}
+	if !includeSnoozed {
+		tasks = withoutSnoozed(tasks, uc.now())
+	}
+
+	return selectTasks(tasks, status, less), nil
+}
+
+// selectTasks keeps the tasks in the given status (empty status keeps all)
+// and sorts them by less, ties broken by ID
+func selectTasks(tasks []*domain.Task, status domain.TaskStatus, less func(a, b *domain.Task) bool) []*domain.Task {
+	result := make([]*domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if status == "" || task.Status == status {
+			result = append(result, task)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	sort.SliceStable(result, func(i, j int) bool { return less(result[i], result[j]) })
+
+	return result
+}
+
+// GetOverdueTasksForUser returns the user's unarchived tasks that are past
+// their due date and not completed or cancelled, the same tasks the liveness
+// checker warns about. The most overdue task comes first, ties broken by ID.
+func (uc *TaskUseCase) GetOverdueTasksForUser(ctx context.Context, userID domain.UserID) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	if _, err := uc.uow.Users().GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrUserNotFound, err)
+	}
+
+	tasks, err := uc.uow.Tasks().GetTasksByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks for user %s: %w", userID, err)
+	}
+
+	now := uc.now()
+	overdue := make([]*domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.IsOverdueAt(now) {
+			overdue = append(overdue, task)
+		}
+	}
+
+	sort.Slice(overdue, func(i, j int) bool {
+		if !overdue[i].DueDate.Equal(*overdue[j].DueDate) {
+			return overdue[i].DueDate.Before(*overdue[j].DueDate)
+		}
+		return overdue[i].ID < overdue[j].ID
+	})
+
+	return overdue, nil
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+)
+
+// Directions for MoveTask
+const (
+	MoveUp   = "up"
+	MoveDown = "down"
+)
+
+// SetTaskRank sets the rank of a task the current user manages, placing it
+// within its assignee's backlog
+func (uc *TaskUseCase) SetTaskRank(ctx context.Context, taskID domain.TaskID, rank float64) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	task, err := uc.rankableTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(rank) || math.IsInf(rank, 0) {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidRank, rank)
+	}
+
+	task.Rank = rank
+	task.UpdatedAt = uc.now()
+	if err := uc.saveRanks(ctx, []*domain.Task{task}); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// MoveTask moves a task the current user manages one place up or down in its
+// assignee's backlog. Moving the first task up or the last one down leaves
+// it where it is.
+func (uc *TaskUseCase) MoveTask(ctx context.Context, taskID domain.TaskID, direction string) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	if direction != MoveUp && direction != MoveDown {
+		return nil, fmt.Errorf("%w: direction must be %q or %q, got %q", domain.ErrInvalidRank, MoveUp, MoveDown, direction)
+	}
+
+	task, err := uc.rankableTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	backlog, err := uc.backlog(ctx, task.Assignee, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The position the task currently takes among the other tasks
+	position := sort.Search(len(backlog), func(i int) bool { return !rankedBefore(backlog[i], task) })
+	if direction == MoveUp {
+		if position == 0 {
+			return task, nil
+		}
+		position--
+	} else {
+		if position == len(backlog) {
+			return task, nil
+		}
+		position++
+	}
+
+	return uc.placeTask(ctx, task, backlog, position)
+}
+
+// MoveTaskBetween moves a task the current user manages directly after the
+// task after and before the task before in its assignee's backlog, giving it
+// the midpoint of their ranks. Either may be zero to move the task to the
+// start or end of the backlog, but not both.
+func (uc *TaskUseCase) MoveTaskBetween(ctx context.Context, taskID, after, before domain.TaskID) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	if after == 0 && before == 0 {
+		return nil, fmt.Errorf("%w: a task to move after or before is required", domain.ErrInvalidRank)
+	}
+	if after == taskID || before == taskID {
+		return nil, fmt.Errorf("%w: task %d cannot be moved next to itself", domain.ErrInvalidRank, taskID)
+	}
+
+	task, err := uc.rankableTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	backlog, err := uc.backlog(ctx, task.Assignee, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexOf := func(id domain.TaskID) (int, error) {
+		for i, other := range backlog {
+			if other.ID == id {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("%w: task %d is not in the backlog of %s", domain.ErrInvalidRank, id, task.Assignee)
+	}
+
+	position := 0
+	if after != 0 {
+		index, err := indexOf(after)
+		if err != nil {
+			return nil, err
+		}
+		position = index + 1
+	}
+	if before != 0 {
+		index, err := indexOf(before)
+		if err != nil {
+			return nil, err
+		}
+		if after != 0 && index != position {
+			return nil, fmt.Errorf("%w: task %d does not directly follow task %d", domain.ErrInvalidRank, before, after)
+		}
+		position = index
+	}
+
+	return uc.placeTask(ctx, task, backlog, position)
+}
+
+// placeTask moves the task to the given position among the other tasks of the
+// backlog. The task takes the midpoint of its new neighbours' ranks; only when
+// they are too close for that is the backlog renumbered RankGap apart.
+func (uc *TaskUseCase) placeTask(ctx context.Context, task *domain.Task, backlog []*domain.Task, position int) (*domain.Task, error) {
+	rank, ok := rankBetween(backlog, position)
+	changed := []*domain.Task{task}
+	if !ok {
+		for i, other := range backlog {
+			offset := 1
+			if i >= position {
+				offset = 2
+			}
+			other.Rank = float64(i+offset) * domain.RankGap
+			changed = append(changed, other)
+		}
+		rank = float64(position+1) * domain.RankGap
+	}
+
+	task.Rank = rank
+	task.UpdatedAt = uc.now()
+	if err := uc.saveRanks(ctx, changed); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// rankBetween returns the rank for a task inserted at the given position of
+// the backlog, or false if its neighbours leave no room between them
+func rankBetween(backlog []*domain.Task, position int) (float64, bool) {
+	switch {
+	case len(backlog) == 0:
+		return domain.RankGap, true
+	case position == 0:
+		return backlog[0].Rank - domain.RankGap, true
+	case position == len(backlog):
+		return backlog[len(backlog)-1].Rank + domain.RankGap, true
+	}
+
+	lower, upper := backlog[position-1].Rank, backlog[position].Rank
+	rank := lower + (upper-lower)/2
+	return rank, lower < rank && rank < upper
+}
+
+// rankedBefore orders a backlog by rank, ties broken by ID
+func rankedBefore(a, b *domain.Task) bool {
+	if a.Rank != b.Rank {
+		return a.Rank < b.Rank
+	}
+	return a.ID < b.ID
+}
+
+// backlog returns the unarchived tasks assigned to a user in rank order,
+// leaving out the task being moved
+func (uc *TaskUseCase) backlog(ctx context.Context, assignee domain.UserID, exclude domain.TaskID) ([]*domain.Task, error) {
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	var backlog []*domain.Task
+	for _, task := range allTasks {
+		if task.Assignee == assignee && !task.Archived && task.ID != exclude {
+			backlog = append(backlog, task)
+		}
+	}
+	sort.Slice(backlog, func(i, j int) bool { return rankedBefore(backlog[i], backlog[j]) })
+	return backlog, nil
+}
+
+// bottomRank returns the rank placing a task after every task in the
+// assignee's backlog
+func (uc *TaskUseCase) bottomRank(ctx context.Context, assignee domain.UserID) (float64, error) {
+	backlog, err := uc.backlog(ctx, assignee, 0)
+	if err != nil {
+		return 0, err
+	}
+	rank, _ := rankBetween(backlog, len(backlog))
+	return rank, nil
+}
+
+// rankableTask returns a task the current user may reorder
+func (uc *TaskUseCase) rankableTask(ctx context.Context, taskID domain.TaskID) (*domain.Task, error) {
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	// Check user owns the task or is an admin
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have access to task %d", taskID)
+	}
+	if task.Archived {
+		return nil, fmt.Errorf("%w: archived task %d is in no backlog", domain.ErrInvalidRank, taskID)
+	}
+
+	return task, nil
+}
+
+// saveRanks stores reranked tasks in one transaction
+func (uc *TaskUseCase) saveRanks(ctx context.Context, tasks []*domain.Task) error {
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	for _, task := range tasks {
+		if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+			uc.uow.Rollback()
+			return fmt.Errorf("failed to update rank of task %d: %w", task.ID, err)
+		}
+	}
+
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rank change: %w", err)
+	}
+	return nil
+}
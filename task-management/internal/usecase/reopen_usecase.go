@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+)
+
+// ReopenTask moves a completed or cancelled task back to in_progress. The
+// transition table has no way out of a final status, so reopening bypasses it;
+// instead only admins and the task's creator may reopen, a reason is
+// required, and the reason is recorded with the actor in a task.reopened
+// event. As when starting a task, all its dependencies must be completed.
+// Archived tasks must be restored first.
+func (uc *TaskUseCase) ReopenTask(ctx context.Context, taskID domain.TaskID, reason string) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !actor.IsAdmin() && task.CreatedBy != actor.ID {
+		return nil, fmt.Errorf("only admins and the creator can reopen task %d", taskID)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w to reopen task %d", domain.ErrReasonRequired, taskID)
+	}
+
+	if task.Archived {
+		return nil, fmt.Errorf("task %d is archived; restore it first", taskID)
+	}
+	if !task.IsDone() {
+		return nil, fmt.Errorf("%w: only completed or cancelled tasks can be reopened, task %d is %s",
+			domain.ErrInvalidTransition, taskID, task.Status)
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	if waiting := task.IncompleteDependencies(allTasks); len(waiting) > 0 {
+		return nil, fmt.Errorf("cannot reopen task %d: dependency %d is not completed", taskID, waiting[0])
+	}
+
+	oldStatus := task.Status
+	task.Status = domain.StatusInProgress
+	task.UpdatedAt = uc.now()
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to reopen task: %w", err)
+	}
+
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w after reopening task: %w", domain.ErrInvariantViolation, err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reopen: %w", err)
+	}
+	metrics.RecordTransition(oldStatus, task.Status)
+	uc.publish(ctx, domain.EventTaskReopened, task, actor.ID, map[string]string{
+		"from":   string(oldStatus),
+		"to":     string(task.Status),
+		"reason": reason,
+	})
+
+	return task, nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// TaskRollup is a task with the effort of its subtasks added up
+type TaskRollup struct {
+	Task *domain.Task
+	// EstimatedHours and ActualHours are the task's own hours plus those of
+	// every subtask below it, however deeply nested
+	EstimatedHours float64
+	ActualHours    float64
+	// CompletedSubtasks of the TotalSubtasks below the task are completed
+	CompletedSubtasks int
+	TotalSubtasks     int
+}
+
+// GetTaskWithRollup returns the task with the hours and completion of its
+// subtask subtree rolled up. Archived subtasks and their subtrees are left
+// out. Each task is counted at most once, so a cycle of parents, which
+// CreateTask cannot produce, cannot make the walk loop either.
+func (uc *TaskUseCase) GetTaskWithRollup(ctx context.Context, taskID domain.TaskID) (*TaskRollup, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	subtasks := make(map[domain.TaskID][]*domain.Task)
+	for _, t := range allTasks {
+		if t.ParentID != 0 && !t.Archived {
+			subtasks[t.ParentID] = append(subtasks[t.ParentID], t)
+		}
+	}
+
+	rollup := &TaskRollup{
+		Task:           task,
+		EstimatedHours: task.EstimatedHours,
+		ActualHours:    task.ActualHours,
+	}
+	visited := map[domain.TaskID]bool{task.ID: true}
+	pending := subtasks[task.ID]
+	for len(pending) > 0 {
+		subtask := pending[0]
+		pending = pending[1:]
+		if visited[subtask.ID] {
+			continue
+		}
+		visited[subtask.ID] = true
+
+		rollup.EstimatedHours += subtask.EstimatedHours
+		rollup.ActualHours += subtask.ActualHours
+		rollup.TotalSubtasks++
+		if subtask.Status == domain.StatusCompleted {
+			rollup.CompletedSubtasks++
+		}
+		pending = append(pending, subtasks[subtask.ID]...)
+	}
+
+	return rollup, nil
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+)
+
+// SnoozeTask hides the task from listings until the given time, which must be
+// in the future; a nil time wakes the task at once. Completed and cancelled
+// tasks cannot be snoozed, and archived tasks must be restored first. Once
+// the snooze elapses the task is listed again, and the liveness check warns
+// about it until it is updated.
+func (uc *TaskUseCase) SnoozeTask(ctx context.Context, taskID domain.TaskID, until *time.Time) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have access to task %d", taskID)
+	}
+
+	if task.Archived {
+		return nil, fmt.Errorf("task %d is archived; restore it first", taskID)
+	}
+	if task.IsDone() {
+		return nil, fmt.Errorf("%w: task %d is %s and cannot be snoozed",
+			domain.ErrInvalidTransition, taskID, task.Status)
+	}
+
+	now := uc.now()
+	if until != nil && !until.After(now) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrSnoozeInPast, until.Format(time.RFC3339))
+	}
+
+	task.SnoozedUntil = until
+	task.UpdatedAt = now
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to snooze task: %w", err)
+	}
+
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w after snoozing task: %w", domain.ErrInvariantViolation, err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit snooze: %w", err)
+	}
+
+	// An empty "until" records that the task was woken
+	data := map[string]string{"until": ""}
+	if until != nil {
+		data["until"] = until.Format(time.RFC3339)
+	}
+	uc.publish(ctx, domain.EventTaskSnoozed, task, actor.ID, data)
+
+	return task, nil
+}
+
+// withoutSnoozed leaves out the tasks snoozed at the given time
+func withoutSnoozed(tasks []*domain.Task, now time.Time) []*domain.Task {
+	awake := make([]*domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.IsSnoozedAt(now) {
+			awake = append(awake, task)
+		}
+	}
+	return awake
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// TaskSort selects the order of task listings
+type TaskSort string
+
+const (
+	SortByID        TaskSort = ""
+	SortByPriority  TaskSort = "priority"
+	SortByDueDate   TaskSort = "due_date"
+	SortByCreatedAt TaskSort = "created_at"
+	SortByStatus    TaskSort = "status"
+	SortByRank      TaskSort = "rank"
+)
+
+// statusOrder ranks statuses by lifecycle stage; policy-defined statuses sort last
+var statusOrder = map[domain.TaskStatus]int{
+	domain.StatusPending:    0,
+	domain.StatusInProgress: 1,
+	domain.StatusBlocked:    2,
+	domain.StatusCompleted:  3,
+	domain.StatusCancelled:  4,
+}
+
+// ListFilter widens a task listing beyond the active tasks it returns by default
+type ListFilter struct {
+	// IncludeArchived adds archived (soft-deleted) tasks
+	IncludeArchived bool
+	// IncludeDone adds completed and cancelled tasks
+	IncludeDone bool
+	// IncludeSnoozed adds tasks snoozed until a later time
+	IncludeSnoozed bool
+	// Tags, when set, keeps only tasks carrying any of the tags, or all of
+	// them with MatchAllTags
+	Tags         []domain.Tag
+	MatchAllTags bool
+	// CreatedFrom and CreatedTo, when set, keep only tasks created at or
+	// after CreatedFrom and before CreatedTo, so back-to-back reporting
+	// periods never count a task twice
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// validate rejects tags outside the vocabulary and inverted creation ranges
+func (f ListFilter) validate() error {
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && f.CreatedTo.Before(f.CreatedFrom) {
+		return fmt.Errorf("end of range (%v) is before start (%v)", f.CreatedTo, f.CreatedFrom)
+	}
+	for _, tag := range f.Tags {
+		if !tag.IsValid() {
+			return fmt.Errorf("%w: %s", domain.ErrInvalidTag, tag)
+		}
+	}
+	return nil
+}
+
+// includes reports whether the filter admits the task at the given time
+func (f ListFilter) includes(task *domain.Task, now time.Time) bool {
+	if task.Archived && !f.IncludeArchived {
+		return false
+	}
+	if task.IsSnoozedAt(now) && !f.IncludeSnoozed {
+		return false
+	}
+	if len(f.Tags) > 0 && !matchesTags(task, f.Tags, f.MatchAllTags) {
+		return false
+	}
+	if !f.CreatedFrom.IsZero() && task.CreatedAt.Before(f.CreatedFrom) {
+		return false
+	}
+	if !f.CreatedTo.IsZero() && !task.CreatedAt.Before(f.CreatedTo) {
+		return false
+	}
+	return f.IncludeDone || !task.IsDone()
+}
+
+// matchesTags reports whether the task carries all of the tags, with
+// matchAll, or any of them
+func matchesTags(task *domain.Task, tags []domain.Tag, matchAll bool) bool {
+	for _, tag := range tags {
+		has := task.HasTag(tag)
+		if matchAll && !has {
+			return false
+		}
+		if !matchAll && has {
+			return true
+		}
+	}
+	return matchAll
+}
+
+// ListTasks returns the tasks admitted by the filter in the given order. By
+// default archived, snoozed, completed and cancelled tasks are left out.
+func (uc *TaskUseCase) ListTasks(ctx context.Context, order TaskSort, filter ListFilter) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	return uc.listTasks(ctx, order, filter)
+}
+
+// FindByTags returns the unarchived tasks, done or not, carrying all of the
+// tags with matchAll or any of them otherwise, in ID order. Tags outside the
+// vocabulary are ErrInvalidTag.
+func (uc *TaskUseCase) FindByTags(ctx context.Context, tags []domain.Tag, matchAll bool) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: no tags given", domain.ErrInvalidTag)
+	}
+	return uc.listTasks(ctx, SortByID, ListFilter{
+		IncludeDone:    true,
+		IncludeSnoozed: true,
+		Tags:           tags,
+		MatchAllTags:   matchAll,
+	})
+}
+
+// GetTasksCreatedBetween returns the unarchived tasks, done or not, created
+// at or after start and before end, oldest first
+func (uc *TaskUseCase) GetTasksCreatedBetween(ctx context.Context, start, end time.Time) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	return uc.listTasks(ctx, SortByCreatedAt, ListFilter{
+		IncludeDone:    true,
+		IncludeSnoozed: true,
+		CreatedFrom:    start,
+		CreatedTo:      end,
+	})
+}
+
+func (uc *TaskUseCase) listTasks(ctx context.Context, order TaskSort, filter ListFilter) ([]*domain.Task, error) {
+	less, err := taskLess(order)
+	if err != nil {
+		return nil, err
+	}
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	now := uc.now()
+	tasks := make([]*domain.Task, 0, len(allTasks))
+	for _, task := range allTasks {
+		if filter.includes(task, now) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	// Sort by ID first so ties under the requested order are reproducible
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	sort.SliceStable(tasks, func(i, j int) bool { return less(tasks[i], tasks[j]) })
+
+	return tasks, nil
+}
+
+// taskLess returns the comparison for a sort order; ties are left to the ID order
+func taskLess(order TaskSort) (func(a, b *domain.Task) bool, error) {
+	switch order {
+	case SortByID:
+		return func(a, b *domain.Task) bool { return false }, nil
+	case SortByPriority:
+		// Most urgent first
+		return func(a, b *domain.Task) bool { return a.Priority.Weight() > b.Priority.Weight() }, nil
+	case SortByDueDate:
+		// Earliest due first, tasks without a due date last
+		return func(a, b *domain.Task) bool {
+			if a.DueDate == nil || b.DueDate == nil {
+				return a.DueDate != nil && b.DueDate == nil
+			}
+			return a.DueDate.Before(*b.DueDate)
+		}, nil
+	case SortByCreatedAt:
+		return func(a, b *domain.Task) bool { return a.CreatedAt.Before(b.CreatedAt) }, nil
+	case SortByStatus:
+		return func(a, b *domain.Task) bool { return statusRank(a.Status) < statusRank(b.Status) }, nil
+	case SortByRank:
+		// Backlog order, as arranged with MoveTask
+		return func(a, b *domain.Task) bool { return a.Rank < b.Rank }, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidSortKey, order)
+	}
+}
+
+func statusRank(status domain.TaskStatus) int {
+	if rank, ok := statusOrder[status]; ok {
+		return rank
+	}
+	return len(statusOrder)
+}
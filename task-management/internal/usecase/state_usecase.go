@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+)
+
+// ExportState returns a snapshot of the whole system state for backups.
+// Only admins may export. Sessions are exported without their tokens, and
+// password hashes are left out of the serialized snapshot.
+func (uc *TaskUseCase) ExportState(ctx context.Context) (*domain.SystemState, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	if err := uc.requireAdmin(ctx, "export the system state"); err != nil {
+		return nil, err
+	}
+
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+
+	// The sessions are copies, so clearing their tokens leaves the stored
+	// sessions valid
+	for _, session := range state.Sessions {
+		session.Token = ""
+	}
+
+	return state, nil
+}
+
+// ImportState replaces the whole system state with the given one, as saved by
+// ExportState. The state is checked against every safety invariant first and
+// the import is rejected without changes if any of them fails. Imported users
+// already known keep their password. The current user and sessions are not
+// imported: the caller stays logged in and existing sessions are kept, so the
+// imported users must include everyone logged in. Only admins may import.
+func (uc *TaskUseCase) ImportState(ctx context.Context, state *domain.SystemState) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	if err := uc.requireAdmin(ctx, "import the system state"); err != nil {
+		return err
+	}
+
+	if state == nil {
+		return fmt.Errorf("%w: no state to import", domain.ErrInvariantViolation)
+	}
+	if state.Users == nil {
+		// SaveSystemState would keep the current users, so the invariants
+		// could not be checked against the users the state ends up with
+		return fmt.Errorf("%w: imported state has no users", domain.ErrInvariantViolation)
+	}
+	if state.Tasks == nil {
+		state.Tasks = make(map[domain.TaskID]*domain.Task)
+	}
+	if state.UserTasks == nil {
+		state.UserTasks = make(map[domain.UserID][]domain.TaskID)
+	}
+
+	current, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get system state: %w", err)
+	}
+	state.CurrentUser = current.CurrentUser
+	state.Sessions = current.Sessions
+
+	if err := uc.validateImport(state); err != nil {
+		metrics.RecordInvariantViolation()
+		return fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+
+	// Snapshots carry no password hashes, so users already known keep theirs
+	for id, user := range state.Users {
+		if user.PasswordHash != "" {
+			continue
+		}
+		if existing, err := uc.uow.Users().GetUser(ctx, id); err == nil {
+			user.PasswordHash = existing.PasswordHash
+		}
+	}
+
+	// GetSystemState reports one valid session per user at most, so the
+	// stored sessions are kept by saving a state without any
+	state.Sessions = nil
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := uc.uow.SystemState().SaveSystemState(ctx, state); err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to save system state: %w", err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// validateImport checks each task and then the state as a whole
+func (uc *TaskUseCase) validateImport(state *domain.SystemState) error {
+	for id, task := range state.Tasks {
+		if task == nil {
+			return fmt.Errorf("task %d is empty", id)
+		}
+	}
+	for id, user := range state.Users {
+		if user == nil || user.ID != id {
+			return fmt.Errorf("user map key %s doesn't match the user", id)
+		}
+	}
+
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		return err
+	}
+	for _, task := range state.Tasks {
+		if err := uc.invariantChecker.CheckTaskInvariants(task, state); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// requireAdmin returns an error unless the current user is an admin
+func (uc *TaskUseCase) requireAdmin(ctx context.Context, action string) error {
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return domain.ErrUnauthenticated
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !actor.IsAdmin() {
+		return fmt.Errorf("only admins can %s", action)
+	}
+
+	return nil
+}
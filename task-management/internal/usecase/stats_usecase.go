@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// TaskStats holds the headline numbers of the system. Archived tasks are
+// counted only in Archived.
+type TaskStats struct {
+	// Total is the number of unarchived tasks
+	Total int
+	// ByStatus counts unarchived tasks per status, listing every built-in
+	// status even when no task has it
+	ByStatus map[domain.TaskStatus]int
+	// ByPriority counts unarchived tasks per priority, listing every
+	// priority of the vocabulary even when no task has it
+	ByPriority map[domain.Priority]int
+	// Overdue counts unarchived tasks past their due date and not done
+	Overdue int
+	// Archived is the number of archived tasks
+	Archived int
+	// ActiveSessions counts the sessions still valid now
+	ActiveSessions int
+}
+
+// GetStats computes the task and session counts in a single pass over the
+// system state, so clients need not fetch every task for headline numbers
+func (uc *TaskUseCase) GetStats(ctx context.Context) (*TaskStats, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+
+	stats := &TaskStats{
+		ByStatus:   make(map[domain.TaskStatus]int, len(statusOrder)),
+		ByPriority: make(map[domain.Priority]int),
+	}
+	for status := range statusOrder {
+		stats.ByStatus[status] = 0
+	}
+	for _, priority := range domain.CurrentVocabulary().Priorities {
+		stats.ByPriority[priority] = 0
+	}
+
+	now := uc.now()
+	for _, task := range state.Tasks {
+		if task.Archived {
+			stats.Archived++
+			continue
+		}
+		stats.Total++
+		stats.ByStatus[task.Status]++
+		stats.ByPriority[task.Priority]++
+		if task.IsOverdueAt(now) {
+			stats.Overdue++
+		}
+	}
+	for _, session := range state.Sessions {
+		if session.IsValidAt(now) {
+			stats.ActiveSessions++
+		}
+	}
+
+	return stats, nil
+}
@@ -2,19 +2,107 @@
 package usecase
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 	
 	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/metrics"
+	"github.com/bhatti/sample-task-management/internal/requestid"
 	"github.com/bhatti/sample-task-management/internal/repository"
 )
 
-// TaskUseCase implements task-related TLA+ actions
+// TaskUseCase implements task-related TLA+ actions.
+//
+// A TaskUseCase is safe for concurrent use, as are several TaskUseCases
+// sharing one UnitOfWork. Every operation runs under the unit of work's
+// operation lock, so it checks its preconditions and applies its changes
+// against one consistent state: mutations are serialized and queries never
+// see a half-applied mutation. The current user is part of that shared state,
+// as in the TLA+ model: callers acting as different users at the same time
+// must serialize their Authenticate and subsequent calls themselves. Events
+// and notifications are delivered while the lock is held, so publishers and
+// notifiers must not call back into the use case.
 type TaskUseCase struct {
 	uow              repository.UnitOfWork
 	invariantChecker InvariantChecker
+	config           Config
+	publisher        EventPublisher
+	notifier         NotificationService
+}
+
+// Config holds the tunable limits of a TaskUseCase
+type Config struct {
+	// MaxTasks is the maximum number of tasks in the system (maps to TLA+ MaxTasks)
+	MaxTasks int
+	// CountLiveTasks applies MaxTasks to the number of stored tasks, so purging
+	// tasks frees capacity. By default, as in the TLA+ model, it bounds
+	// nextTaskId: every task ever created counts. Task IDs are never reused
+	// either way.
+	CountLiveTasks bool
+	// TransitionPolicy defines the allowed status transitions
+	TransitionPolicy *domain.TransitionPolicy
+	// SessionDuration is how long a session lasts after login
+	SessionDuration time.Duration
+	// RememberMeDuration is the longer session lifetime of a "remember me" login
+	RememberMeDuration time.Duration
+	// RequireFutureDueDate rejects due dates in the past when tasks are created
+	// or their due date changes
+	RequireFutureDueDate bool
+	// Clock supplies the current time for timestamps, session expiry and due
+	// date checks; nil selects the system clock
+	Clock domain.Clock
+	// EscalationEnabled raises the priority of stale pending tasks by one
+	// level whenever dependencies are checked
+	EscalationEnabled bool
+	// EscalationAge is how long a pending task may go without updates before
+	// it is escalated
+	EscalationAge time.Duration
+	// MaxDependencyDepth bounds the longest dependency chain, counted in
+	// dependencies, that CreateTask and AddDependency may produce; 0 means
+	// unlimited
+	MaxDependencyDepth int
+	// RequireDependenciesToComplete rejects moving a task to completed,
+	// singly or in bulk, while any of its dependencies is not completed, as
+	// starting it already is. A task can be in progress with an incomplete
+	// dependency when the dependency was added or reopened after it started.
+	RequireDependenciesToComplete bool
+	// DetachDependentsOnDelete lets DeleteTask archive a task that other
+	// tasks depend on: the task is removed from each dependent's
+	// dependencies, and a blocked dependent whose remaining dependencies are
+	// all completed becomes pending. By default the deletion is refused.
+	// PurgeTask refuses either way.
+	DetachDependentsOnDelete bool
+	// NoAuth lets Login open a session for any known user without checking
+	// the password. It exists for local development only.
+	NoAuth bool
+}
+
+// Default session lifetimes
+const (
+	DefaultSessionDuration    = 24 * time.Hour
+	DefaultRememberMeDuration = 30 * 24 * time.Hour
+)
+
+// DefaultEscalationAge is how long a pending task may sit idle before it is
+// escalated when escalation is enabled
+const DefaultEscalationAge = 72 * time.Hour
+
+// DefaultConfig returns the configuration matching the TLA+ model constants
+func DefaultConfig() Config {
+	return Config{
+		MaxTasks:           domain.MaxTasks,
+		TransitionPolicy:   domain.DefaultTransitionPolicy(),
+		SessionDuration:    DefaultSessionDuration,
+		RememberMeDuration: DefaultRememberMeDuration,
+		Clock:              domain.RealClock{},
+		EscalationAge:      DefaultEscalationAge,
+	}
 }
 
 // InvariantChecker interface for runtime invariant validation
@@ -22,69 +110,185 @@ type InvariantChecker interface {
 	CheckAllInvariants(state *domain.SystemState) error
 	CheckTaskInvariants(task *domain.Task, state *domain.SystemState) error
 	CheckTransitionInvariant(from, to domain.TaskStatus) error
+	CheckNoCyclicDependencies(state *domain.SystemState) error
 }
 
-// NewTaskUseCase creates a new task use case
+// EventPublisher receives domain events emitted by the use cases
+type EventPublisher interface {
+	Publish(event domain.Event)
+}
+
+// NotificationService delivers messages to individual users
+type NotificationService interface {
+	Notify(userID domain.UserID, message string)
+}
+
+// NewTaskUseCase creates a new task use case with the default configuration
 func NewTaskUseCase(uow repository.UnitOfWork, checker InvariantChecker) *TaskUseCase {
+	return NewTaskUseCaseWithConfig(uow, checker, DefaultConfig())
+}
+
+// NewTaskUseCaseWithConfig creates a new task use case with the given configuration.
+// Zero-valued settings fall back to their defaults.
+func NewTaskUseCaseWithConfig(uow repository.UnitOfWork, checker InvariantChecker, config Config) *TaskUseCase {
+	defaults := DefaultConfig()
+	if config.MaxTasks <= 0 {
+		config.MaxTasks = defaults.MaxTasks
+	}
+	if config.TransitionPolicy == nil {
+		config.TransitionPolicy = defaults.TransitionPolicy
+	}
+	if config.SessionDuration <= 0 {
+		config.SessionDuration = defaults.SessionDuration
+	}
+	if config.RememberMeDuration <= 0 {
+		config.RememberMeDuration = defaults.RememberMeDuration
+	}
+	if config.Clock == nil {
+		config.Clock = defaults.Clock
+	}
+	if config.EscalationAge <= 0 {
+		config.EscalationAge = defaults.EscalationAge
+	}
+	
 	return &TaskUseCase{
 		uow:              uow,
 		invariantChecker: checker,
+		config:           config,
+	}
+}
+
+// now returns the current time of the configured clock
+func (uc *TaskUseCase) now() time.Time {
+	return uc.config.Clock.Now()
+}
+
+// SetEventPublisher sets the publisher notified of task events; nil disables events
+func (uc *TaskUseCase) SetEventPublisher(publisher EventPublisher) {
+	uc.publisher = publisher
+}
+
+// SetNotificationService sets the service that tells users about tasks assigned
+// to them; nil disables notifications
+func (uc *TaskUseCase) SetNotificationService(notifier NotificationService) {
+	uc.notifier = notifier
+}
+
+// Login checks the user's password and then opens a session like
+// AuthenticateWithRememberMe. An unknown user, a user without a password and
+// a wrong password all yield ErrInvalidCredentials. With Config.NoAuth set
+// the password is ignored.
+func (uc *TaskUseCase) Login(ctx context.Context, userID domain.UserID, password string, rememberMe bool) (*domain.Session, error) {
+	if !uc.config.NoAuth {
+		if err := uc.checkPassword(ctx, userID, password); err != nil {
+			return nil, err
+		}
+	}
+	return uc.AuthenticateWithRememberMe(ctx, userID, rememberMe)
+}
+
+// checkPassword compares the password with the user's hash outside the lock,
+// since bcrypt is deliberately slow
+func (uc *TaskUseCase) checkPassword(ctx context.Context, userID domain.UserID, password string) error {
+	uc.uow.RLock(ctx)
+	user, err := uc.uow.Users().GetUser(ctx, userID)
+	uc.uow.RUnlock(ctx)
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	if err != nil || !user.CheckPassword(password) {
+		return domain.ErrInvalidCredentials
+	}
+	return nil
 }
 
-// Authenticate implements TLA+ Authenticate action
-func (uc *TaskUseCase) Authenticate(userID domain.UserID) (*domain.Session, error) {
+// Authenticate implements TLA+ Authenticate action with the default session
+// duration. It trusts the caller to have verified the user; Login checks a
+// password first.
+func (uc *TaskUseCase) Authenticate(ctx context.Context, userID domain.UserID) (*domain.Session, error) {
+	return uc.AuthenticateWithRememberMe(ctx, userID, false)
+}
+
+// AuthenticateWithRememberMe implements TLA+ Authenticate action; a "remember me"
+// session lasts RememberMeDuration instead of SessionDuration
+func (uc *TaskUseCase) AuthenticateWithRememberMe(ctx context.Context, userID domain.UserID, rememberMe bool) (*domain.Session, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
 	// Preconditions from TLA+:
 	// - user \in Users
 	// - ~sessions[user]
 	
-	user, err := uc.uow.Users().GetUser(userID)
+	user, err := uc.uow.Users().GetUser(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 	
 	// Check if user already has an active session
-	existingSession, _ := uc.uow.Sessions().GetSessionByUser(userID)
-	if existingSession != nil && existingSession.IsValid() {
+	existingSession, _ := uc.uow.Sessions().GetSessionByUser(ctx, userID)
+	if existingSession != nil && existingSession.IsValidAt(uc.now()) {
 		return nil, fmt.Errorf("user %s already has an active session", userID)
 	}
 	
 	// Create new session
+	duration := uc.config.SessionDuration
+	if rememberMe {
+		duration = uc.config.RememberMeDuration
+	}
 	token := generateToken()
+	now := uc.now()
 	session := &domain.Session{
 		UserID:    user.ID,
 		Token:     token,
 		Active:    true,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		CreatedAt: now,
+		ExpiresAt: now.Add(duration),
 	}
 	
 	// Update state
-	if err := uc.uow.Sessions().CreateSession(session); err != nil {
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	if err := uc.uow.Sessions().CreateSession(ctx, session); err != nil {
+		uc.uow.Rollback()
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 	
-	if err := uc.uow.SystemState().SetCurrentUser(&userID); err != nil {
+	if err := uc.uow.SystemState().SetCurrentUser(ctx, &userID); err != nil {
+		uc.uow.Rollback()
 		return nil, fmt.Errorf("failed to set current user: %w", err)
 	}
 	
 	// Check invariants
-	state, _ := uc.uow.SystemState().GetSystemState()
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
 	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
 		uc.uow.Rollback()
-		return nil, fmt.Errorf("invariant violation: %w", err)
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit session: %w", err)
 	}
 	
 	return session, nil
 }
 
 // Logout implements TLA+ Logout action
-func (uc *TaskUseCase) Logout(userID domain.UserID) error {
+func (uc *TaskUseCase) Logout(ctx context.Context, userID domain.UserID) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
 	// Preconditions from TLA+:
 	// - currentUser # NULL
 	// - currentUser \in Users
 	
-	currentUser, err := uc.uow.SystemState().GetCurrentUser()
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
 	if err != nil || currentUser == nil {
 		return fmt.Errorf("no user currently authenticated")
 	}
@@ -94,53 +298,292 @@ func (uc *TaskUseCase) Logout(userID domain.UserID) error {
 	}
 	
 	// Deactivate session
-	session, err := uc.uow.Sessions().GetSessionByUser(userID)
+	session, err := uc.uow.Sessions().GetSessionByUser(ctx, userID)
 	if err == nil && session != nil {
 		session.Active = false
-		uc.uow.Sessions().UpdateSession(session)
+		uc.uow.Sessions().UpdateSession(ctx, session)
 	}
 	
 	// Clear current user
-	if err := uc.uow.SystemState().SetCurrentUser(nil); err != nil {
+	if err := uc.uow.SystemState().SetCurrentUser(ctx, nil); err != nil {
 		return fmt.Errorf("failed to clear current user: %w", err)
 	}
 	
 	return nil
 }
 
+// LogoutSession revokes the session with the given token. Logging out a
+// session that is already inactive or expired succeeds without change, so
+// retries are safe; an unknown token is ErrUnauthenticated. The current user
+// is cleared if it belongs to the session.
+func (uc *TaskUseCase) LogoutSession(ctx context.Context, token string) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	if token == "" {
+		return domain.ErrUnauthenticated
+	}
+	
+	session, err := uc.uow.Sessions().GetSession(ctx, token)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrUnauthenticated, err)
+	}
+	if !session.IsValidAt(uc.now()) {
+		return nil
+	}
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	session.Active = false
+	if err := uc.uow.Sessions().UpdateSession(ctx, session); err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to deactivate session: %w", err)
+	}
+	if err := uc.clearCurrentUserIf(ctx, session.UserID); err != nil {
+		uc.uow.Rollback()
+		return err
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit logout: %w", err)
+	}
+	
+	return nil
+}
+
+// LogoutAll revokes every session of the user holding the given token, e.g.
+// after a lost device, and clears the current user if it is that user. The
+// token must belong to a valid session.
+func (uc *TaskUseCase) LogoutAll(ctx context.Context, token string) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	session, err := uc.getSession(ctx, token)
+	if err != nil {
+		return err
+	}
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	if err := uc.uow.Sessions().DeleteUserSessions(ctx, session.UserID); err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	if err := uc.clearCurrentUserIf(ctx, session.UserID); err != nil {
+		uc.uow.Rollback()
+		return err
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit logout: %w", err)
+	}
+	
+	return nil
+}
+
+// RefreshSession extends the still-valid session with the given token to
+// SessionDuration from now and returns it, so long-lived clients need not log
+// in again; a session already lasting longer, like a "remember me" one, keeps
+// its expiry. Unknown, inactive and expired sessions are ErrUnauthenticated.
+func (uc *TaskUseCase) RefreshSession(ctx context.Context, token string) (*domain.Session, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	session, err := uc.getSession(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	
+	if expiresAt := uc.now().Add(uc.config.SessionDuration); expiresAt.After(session.ExpiresAt) {
+		session.ExpiresAt = expiresAt
+	}
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	if err := uc.uow.Sessions().UpdateSession(ctx, session); err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+	if err := uc.checkInvariants(ctx); err != nil {
+		uc.uow.Rollback()
+		return nil, err
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit session refresh: %w", err)
+	}
+	
+	return session, nil
+}
+
+// clearCurrentUserIf clears the current user if it is the given user
+func (uc *TaskUseCase) clearCurrentUserIf(ctx context.Context, userID domain.UserID) error {
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil || *currentUser != userID {
+		return nil
+	}
+	
+	if err := uc.uow.SystemState().SetCurrentUser(ctx, nil); err != nil {
+		return fmt.Errorf("failed to clear current user: %w", err)
+	}
+	return nil
+}
+
+// GetSession returns the session for a token; unknown, inactive and expired
+// sessions are reported as ErrUnauthenticated
+func (uc *TaskUseCase) GetSession(ctx context.Context, token string) (*domain.Session, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+	
+	return uc.getSession(ctx, token)
+}
+
+func (uc *TaskUseCase) getSession(ctx context.Context, token string) (*domain.Session, error) {
+	if token == "" {
+		return nil, domain.ErrUnauthenticated
+	}
+	
+	session, err := uc.uow.Sessions().GetSession(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrUnauthenticated, err)
+	}
+	
+	if !session.IsValidAt(uc.now()) {
+		return nil, fmt.Errorf("%w: session expired or logged out", domain.ErrUnauthenticated)
+	}
+	
+	return session, nil
+}
+
+// TaskOption sets an optional field of a task being created
+type TaskOption func(task *domain.Task)
+
+// WithEstimatedHours sets the initial time estimate of a task
+func WithEstimatedHours(hours float64) TaskOption {
+	return func(task *domain.Task) {
+		task.EstimatedHours = hours
+	}
+}
+
+// WithRecurrence makes the task regenerate with the given interval on completion
+func WithRecurrence(recurrence domain.Recurrence) TaskOption {
+	return func(task *domain.Task) {
+		task.Recurrence = recurrence
+	}
+}
+
+// WithParent makes the task a subtask of the given task
+func WithParent(parentID domain.TaskID) TaskOption {
+	return func(task *domain.Task) {
+		task.ParentID = parentID
+	}
+}
+
+// WithDueIn sets the due date to the given time after the task's creation, in
+// place of an absolute due date
+func WithDueIn(d time.Duration) TaskOption {
+	return func(task *domain.Task) {
+		dueDate := task.CreatedAt.Add(d)
+		task.DueDate = &dueDate
+	}
+}
+
 // CreateTask implements TLA+ CreateTask action
 func (uc *TaskUseCase) CreateTask(
+	ctx context.Context,
+	title, description string,
+	priority domain.Priority,
+	assignee domain.UserID,
+	dueDate *time.Time,
+	tags []domain.Tag,
+	dependencies []domain.TaskID,
+	opts ...TaskOption,
+) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	return uc.createTask(ctx, false, title, description, priority, assignee, dueDate, tags, dependencies, opts...)
+}
+
+// DryRunCreateTask makes every check CreateTask makes, including the
+// invariant check of the state with the task added, and returns the task as
+// it would be created, but creates nothing: the state, nextTaskId included,
+// is left unchanged and no event is published.
+func (uc *TaskUseCase) DryRunCreateTask(
+	ctx context.Context,
+	title, description string,
+	priority domain.Priority,
+	assignee domain.UserID,
+	dueDate *time.Time,
+	tags []domain.Tag,
+	dependencies []domain.TaskID,
+	opts ...TaskOption,
+) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	return uc.createTask(ctx, true, title, description, priority, assignee, dueDate, tags, dependencies, opts...)
+}
+
+// createTask creates the task, or with dryRun rolls its creation back once
+// the invariants have been checked
+func (uc *TaskUseCase) createTask(
+	ctx context.Context,
+	dryRun bool,
 	title, description string,
 	priority domain.Priority,
 	assignee domain.UserID,
 	dueDate *time.Time,
 	tags []domain.Tag,
 	dependencies []domain.TaskID,
+	opts ...TaskOption,
 ) (*domain.Task, error) {
 	// Preconditions from TLA+:
 	// - currentUser # NULL
 	// - currentUser \in Users
-	// - nextTaskId <= MaxTasks
+	// - nextTaskId <= MaxTasks (the stored task count with CountLiveTasks)
 	// - deps \subseteq DOMAIN tasks
 	// - \A dep \in deps : tasks[dep].status # "cancelled"
 	
-	currentUser, err := uc.uow.SystemState().GetCurrentUser()
-	if err != nil || currentUser == nil {
-		return nil, fmt.Errorf("authentication required")
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
 	}
 	
-	// Check max tasks limit
-	nextID, err := uc.uow.SystemState().GetNextTaskID()
+	// The assignee may be given by email, or left out to pick the least
+	// loaded user
+	if assignee == "" {
+		assignee, err = uc.suggestAssignee(ctx)
+	} else {
+		assignee, err = uc.resolveUserID(ctx, assignee)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get next task ID: %w", err)
+		return nil, err
 	}
 	
-	if nextID > domain.MaxTasks {
-		return nil, fmt.Errorf("maximum number of tasks (%d) reached", domain.MaxTasks)
+	// Check max tasks limit
+	if err := uc.checkTaskLimit(ctx); err != nil {
+		return nil, err
+	}
+	nextID, err := uc.uow.SystemState().GetNextTaskID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next task ID: %w", err)
 	}
 	
 	// Validate dependencies
-	allTasks, err := uc.uow.Tasks().GetAllTasks()
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tasks: %w", err)
 	}
@@ -149,10 +592,10 @@ func (uc *TaskUseCase) CreateTask(
 	for _, depID := range dependencies {
 		depTask, exists := allTasks[depID]
 		if !exists {
-			return nil, fmt.Errorf("dependency task %d does not exist", depID)
+			return nil, fmt.Errorf("%w: task %d does not exist", domain.ErrInvalidDependency, depID)
 		}
 		if depTask.Status == domain.StatusCancelled {
-			return nil, fmt.Errorf("cannot depend on cancelled task %d", depID)
+			return nil, fmt.Errorf("%w: cannot depend on cancelled task %d", domain.ErrInvalidDependency, depID)
 		}
 		depMap[depID] = true
 	}
@@ -161,6 +604,9 @@ func (uc *TaskUseCase) CreateTask(
 	if err := uc.checkCyclicDependencies(nextID, depMap, allTasks); err != nil {
 		return nil, err
 	}
+	if err := uc.checkDependencyDepth(nextID, depMap, allTasks); err != nil {
+		return nil, err
+	}
 	
 	// Determine initial status based on dependencies
 	status := domain.StatusPending
@@ -187,40 +633,104 @@ func (uc *TaskUseCase) CreateTask(
 		Priority:     priority,
 		Assignee:     assignee,
 		CreatedBy:    *currentUser,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		CreatedAt:    uc.now(),
+		UpdatedAt:    uc.now(),
 		DueDate:      dueDate,
 		Tags:         tags,
 		Dependencies: depMap,
 	}
+	for _, opt := range opts {
+		opt(task)
+	}
+	task.DueDate = domain.NormalizeDueDate(task.DueDate)
+	
+	// A subtask's parent must be a task that has not been archived
+	if task.ParentID != 0 {
+		if parent, exists := allTasks[task.ParentID]; !exists || parent.Archived {
+			return nil, fmt.Errorf("%w: task %d does not exist", domain.ErrInvalidParent, task.ParentID)
+		}
+	}
 	
 	// Validate task
-	if err := task.Validate(); err != nil {
+	if err := task.ValidateWithPolicy(uc.config.TransitionPolicy); err != nil {
 		return nil, fmt.Errorf("task validation failed: %w", err)
 	}
+	if err := uc.checkDueDate(task.DueDate); err != nil {
+		return nil, err
+	}
+	
+	// Check the assignee is a known user (AssigneeExists invariant)
+	if _, err := uc.uow.Users().GetUser(ctx, assignee); err != nil {
+		return nil, fmt.Errorf("%w: assignee %s: %w", domain.ErrUserNotFound, assignee, err)
+	}
+	
+	// New tasks go to the end of the assignee's backlog
+	rank, err := uc.bottomRank(ctx, task.Assignee)
+	if err != nil {
+		return nil, err
+	}
+	task.Rank = rank
 	
 	// Save task
-	if err := uc.uow.Tasks().CreateTask(task); err != nil {
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	if err := uc.uow.Tasks().CreateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 	
 	// Increment next task ID
-	if _, err := uc.uow.SystemState().IncrementNextTaskID(); err != nil {
+	if _, err := uc.uow.SystemState().IncrementNextTaskID(ctx); err != nil {
+		uc.uow.Rollback()
 		return nil, fmt.Errorf("failed to increment task ID: %w", err)
 	}
 	
 	// Check invariants
-	state, _ := uc.uow.SystemState().GetSystemState()
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
 	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
 		uc.uow.Rollback()
-		return nil, fmt.Errorf("invariant violation after task creation: %w", err)
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w after task creation: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if dryRun {
+		uc.uow.Rollback()
+		return task, nil
+	}
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit task creation: %w", err)
+	}
+	metrics.RecordTaskCreated()
+	uc.publish(ctx, domain.EventTaskCreated, task, *currentUser, nil)
+	uc.notifyAssignee(task, *currentUser)
+	
+	return task, nil
+}
+
+// GetTask returns a single task; archived tasks are returned too, marked as such
+func (uc *TaskUseCase) GetTask(ctx context.Context, taskID domain.TaskID) (*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+	
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
 	}
 	
 	return task, nil
 }
 
 // UpdateTaskStatus implements TLA+ UpdateTaskStatus action
-func (uc *TaskUseCase) UpdateTaskStatus(taskID domain.TaskID, newStatus domain.TaskStatus) error {
+func (uc *TaskUseCase) UpdateTaskStatus(ctx context.Context, taskID domain.TaskID, newStatus domain.TaskStatus) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
 	// Preconditions from TLA+:
 	// - currentUser # NULL
 	// - TaskExists(taskId)
@@ -228,18 +738,21 @@ func (uc *TaskUseCase) UpdateTaskStatus(taskID domain.TaskID, newStatus domain.T
 	// - IsValidTransition(tasks[taskId].status, newStatus)
 	// - newStatus = "in_progress" => all dependencies completed
 	
-	currentUser, err := uc.uow.SystemState().GetCurrentUser()
-	if err != nil || currentUser == nil {
-		return fmt.Errorf("authentication required")
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return domain.ErrUnauthenticated
 	}
 	
-	task, err := uc.uow.Tasks().GetTask(taskID)
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
 	if err != nil {
-		return fmt.Errorf("task not found: %w", err)
+		return fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
 	}
 	
-	// Check user owns the task
-	userTasks, err := uc.uow.SystemState().GetUserTasks(*currentUser)
+	// Check user owns the task; collaborators are indexed in their task list too
+	userTasks, err := uc.uow.SystemState().GetUserTasks(ctx, *currentUser)
 	if err != nil {
 		return fmt.Errorf("failed to get user tasks: %w", err)
 	}
@@ -257,13 +770,16 @@ func (uc *TaskUseCase) UpdateTaskStatus(taskID domain.TaskID, newStatus domain.T
 	}
 	
 	// Check valid transition
-	if !domain.IsValidTransition(task.Status, newStatus) {
-		return fmt.Errorf("invalid transition from %s to %s", task.Status, newStatus)
+	if !uc.config.TransitionPolicy.IsValid(task.Status, newStatus) {
+		return fmt.Errorf("%w from %s to %s", domain.ErrInvalidTransition, task.Status, newStatus)
 	}
 	
 	// Check dependencies if moving to in_progress
 	if newStatus == domain.StatusInProgress {
-		allTasks, _ := uc.uow.Tasks().GetAllTasks()
+		allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get tasks: %w", err)
+		}
 		for depID := range task.Dependencies {
 			if depTask, exists := allTasks[depID]; exists {
 				if depTask.Status != domain.StatusCompleted {
@@ -272,35 +788,108 @@ func (uc *TaskUseCase) UpdateTaskStatus(taskID domain.TaskID, newStatus domain.T
 			}
 		}
 	}
+
+	// Optionally check dependencies if completing
+	if newStatus == domain.StatusCompleted && uc.config.RequireDependenciesToComplete {
+		allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get tasks: %w", err)
+		}
+		if waiting := task.IncompleteDependencies(allTasks); len(waiting) > 0 {
+			return fmt.Errorf("cannot complete task %d: dependency %d is not completed", taskID, waiting[0])
+		}
+	}
+	
+	// Only allow blocking a task that actually has incomplete dependencies
+	if newStatus == domain.StatusBlocked {
+		allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get tasks: %w", err)
+		}
+		if !task.IsBlocked(allTasks) {
+			return fmt.Errorf("cannot block task %d: it has no incomplete dependencies", taskID)
+		}
+	}
+
+	// Completing a recurring task creates its successor, which needs a free task ID
+	regenerate := newStatus == domain.StatusCompleted && task.IsRecurring()
+	var successor *domain.Task
+	if regenerate {
+		if err := uc.checkTaskLimit(ctx); err != nil {
+			return fmt.Errorf("cannot regenerate recurring task %d: %w", taskID, err)
+		}
+	}
 	
 	// Update status
+	oldStatus := task.Status
 	task.Status = newStatus
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = uc.now()
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
 	
-	if err := uc.uow.Tasks().UpdateTask(task); err != nil {
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 	
+	if regenerate {
+		nextID, err := uc.uow.SystemState().IncrementNextTaskID(ctx)
+		if err != nil {
+			uc.uow.Rollback()
+			return fmt.Errorf("failed to increment task ID: %w", err)
+		}
+		
+		// IncrementNextTaskID returns the ID it reserved
+		successor = task.NextOccurrence(nextID, task.UpdatedAt)
+		if err := uc.uow.Tasks().CreateTask(ctx, successor); err != nil {
+			uc.uow.Rollback()
+			return fmt.Errorf("failed to create next occurrence of task %d: %w", taskID, err)
+		}
+	}
+	
 	// Check invariants
-	state, _ := uc.uow.SystemState().GetSystemState()
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to get system state: %w", err)
+	}
 	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
 		uc.uow.Rollback()
-		return fmt.Errorf("invariant violation: %w", err)
+		metrics.RecordInvariantViolation()
+		return fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status update: %w", err)
+	}
+	metrics.RecordTransition(oldStatus, newStatus)
+	uc.notifyStatusChange(ctx, task, oldStatus, *currentUser)
+	if successor != nil {
+		metrics.RecordTaskCreated()
+		uc.publish(ctx, domain.EventTaskCreated, successor, *currentUser, nil)
 	}
 	
 	return nil
 }
 
 // UpdateTaskPriority implements TLA+ UpdateTaskPriority action
-func (uc *TaskUseCase) UpdateTaskPriority(taskID domain.TaskID, newPriority domain.Priority) error {
-	currentUser, err := uc.uow.SystemState().GetCurrentUser()
-	if err != nil || currentUser == nil {
-		return fmt.Errorf("authentication required")
+func (uc *TaskUseCase) UpdateTaskPriority(ctx context.Context, taskID domain.TaskID, newPriority domain.Priority) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return domain.ErrUnauthenticated
 	}
 	
-	task, err := uc.uow.Tasks().GetTask(taskID)
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
 	if err != nil {
-		return fmt.Errorf("task not found: %w", err)
+		return fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
 	}
 	
 	// Check user owns the task
@@ -309,93 +898,201 @@ func (uc *TaskUseCase) UpdateTaskPriority(taskID domain.TaskID, newPriority doma
 	}
 	
 	task.Priority = newPriority
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = uc.now()
 	
-	if err := uc.uow.Tasks().UpdateTask(task); err != nil {
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
 		return fmt.Errorf("failed to update task priority: %w", err)
 	}
 	
+	if err := uc.checkInvariants(ctx); err != nil {
+		uc.uow.Rollback()
+		return err
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit priority update: %w", err)
+	}
+	
 	return nil
 }
 
 // ReassignTask implements TLA+ ReassignTask action
-func (uc *TaskUseCase) ReassignTask(taskID domain.TaskID, newAssignee domain.UserID) error {
-	currentUser, err := uc.uow.SystemState().GetCurrentUser()
-	if err != nil || currentUser == nil {
-		return fmt.Errorf("authentication required")
-	}
+func (uc *TaskUseCase) ReassignTask(ctx context.Context, taskID domain.TaskID, newAssignee domain.UserID) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
 	
-	task, err := uc.uow.Tasks().GetTask(taskID)
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
 	if err != nil {
-		return fmt.Errorf("task not found: %w", err)
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return domain.ErrUnauthenticated
 	}
 	
-	// Check user owns the task
-	if task.Assignee != *currentUser && task.CreatedBy != *currentUser {
-		return fmt.Errorf("user does not have permission to reassign task %d", taskID)
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
 	}
 	
-	// Verify new assignee exists
-	if _, err := uc.uow.Users().GetUser(newAssignee); err != nil {
-		return fmt.Errorf("new assignee not found: %w", err)
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return fmt.Errorf("current user not found: %w", err)
+	}
+	
+	// Check user owns the task, created it, or is an admin
+	if !canManage(actor, task) {
+		return fmt.Errorf("user does not have permission to reassign task %d", taskID)
+	}
+	
+	// Verify new assignee exists; it may be given by email
+	newAssignee, err = uc.resolveUserID(ctx, newAssignee)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.uow.Users().GetUser(ctx, newAssignee); err != nil {
+		return fmt.Errorf("new assignee not found: %w", err)
 	}
 	
 	oldAssignee := task.Assignee
+	if newAssignee != oldAssignee {
+		// The task joins the end of the new assignee's backlog
+		rank, err := uc.bottomRank(ctx, newAssignee)
+		if err != nil {
+			return err
+		}
+		task.Rank = rank
+	}
 	task.Assignee = newAssignee
-	task.UpdatedAt = time.Now()
 	
-	// Update task
-	if err := uc.uow.Tasks().UpdateTask(task); err != nil {
+	// A collaborator who becomes the assignee no longer needs a separate entry
+	var collaborators []domain.UserID
+	for _, userID := range task.Collaborators {
+		if userID != newAssignee {
+			collaborators = append(collaborators, userID)
+		}
+	}
+	task.Collaborators = collaborators
+	task.UpdatedAt = uc.now()
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	// Update task; the repository moves the task between the assignees'
+	// task lists, so the userTasks mapping has a single source of truth
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
 		return fmt.Errorf("failed to reassign task: %w", err)
 	}
 	
-	// Update user task mappings
-	uc.uow.SystemState().RemoveUserTask(oldAssignee, taskID)
-	uc.uow.SystemState().AddUserTask(newAssignee, taskID)
+	if err := uc.checkInvariants(ctx); err != nil {
+		uc.uow.Rollback()
+		return err
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reassignment: %w", err)
+	}
+	
+	uc.publish(ctx, domain.EventTaskReassigned, task, *currentUser, map[string]string{
+		"from": string(oldAssignee),
+		"to":   string(newAssignee),
+	})
+	if newAssignee != oldAssignee {
+		uc.notifyAssignee(task, *currentUser)
+	}
 	
 	return nil
 }
 
 // UpdateTaskDetails implements TLA+ UpdateTaskDetails action
 func (uc *TaskUseCase) UpdateTaskDetails(
+	ctx context.Context,
 	taskID domain.TaskID,
 	title, description string,
 	dueDate *time.Time,
 ) error {
-	currentUser, err := uc.uow.SystemState().GetCurrentUser()
-	if err != nil || currentUser == nil {
-		return fmt.Errorf("authentication required")
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return domain.ErrUnauthenticated
 	}
 	
-	task, err := uc.uow.Tasks().GetTask(taskID)
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
 	if err != nil {
-		return fmt.Errorf("task not found: %w", err)
+		return fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
 	}
 	
-	// Check user owns the task
-	if task.Assignee != *currentUser {
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return fmt.Errorf("current user not found: %w", err)
+	}
+	
+	// Check user owns the task or is an admin
+	if !canManage(actor, task) {
 		return fmt.Errorf("user does not have access to task %d", taskID)
 	}
 	
+	// Only a changed due date has to be in the future
+	dueDateChanged := (task.DueDate == nil) != (dueDate == nil) ||
+		(dueDate != nil && !task.DueDate.Equal(*dueDate))
+	
 	task.Title = title
 	task.Description = description
-	task.DueDate = dueDate
-	task.UpdatedAt = time.Now()
+	task.DueDate = domain.NormalizeDueDate(dueDate)
+	task.UpdatedAt = uc.now()
 	
 	// Validate updated task
-	if err := task.Validate(); err != nil {
+	if err := task.ValidateWithPolicy(uc.config.TransitionPolicy); err != nil {
 		return fmt.Errorf("task validation failed: %w", err)
 	}
+	if dueDateChanged {
+		if err := uc.checkDueDate(dueDate); err != nil {
+			return err
+		}
+	}
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
 	
-	if err := uc.uow.Tasks().UpdateTask(task); err != nil {
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
 		return fmt.Errorf("failed to update task details: %w", err)
 	}
 	
+	if err := uc.checkInvariants(ctx); err != nil {
+		uc.uow.Rollback()
+		return err
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task details: %w", err)
+	}
+	
 	return nil
 }
 
-// DeleteTask implements TLA+ DeleteTask action
-func (uc *TaskUseCase) DeleteTask(taskID domain.TaskID) error {
+// DeleteTask implements TLA+ DeleteTask action as a soft delete: the task is
+// archived, which hides it from listings and removes it from its assignee's
+// task list, but the task, its comments and its attachments are retained so
+// RestoreTask can bring it back. Admins can remove a task permanently with PurgeTask.
+// Tasks that depend on it are refused, or detached from it with
+// Config.DetachDependentsOnDelete.
+func (uc *TaskUseCase) DeleteTask(ctx context.Context, taskID domain.TaskID) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
 	// Preconditions from TLA+:
 	// - currentUser # NULL
 	// - TaskExists(taskId)
@@ -403,114 +1100,830 @@ func (uc *TaskUseCase) DeleteTask(taskID domain.TaskID) error {
 	// - tasks[taskId].status \in {"completed", "cancelled"}
 	// - No other tasks depend on this one
 	
-	currentUser, err := uc.uow.SystemState().GetCurrentUser()
-	if err != nil || currentUser == nil {
-		return fmt.Errorf("authentication required")
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return domain.ErrUnauthenticated
 	}
 	
-	task, err := uc.uow.Tasks().GetTask(taskID)
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
 	if err != nil {
-		return fmt.Errorf("task not found: %w", err)
+		return fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
 	}
 	
-	// Check user owns the task
-	if task.Assignee != *currentUser {
-		return fmt.Errorf("user does not have permission to delete task %d", taskID)
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return fmt.Errorf("current user not found: %w", err)
 	}
 	
-	// Check task is completed or cancelled
-	if !task.CanDelete() {
-		return fmt.Errorf("can only delete completed or cancelled tasks")
+	reason, err := uc.archiveBlocker(ctx, actor, task)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return errors.New(reason)
 	}
 	
-	// Check no other tasks depend on this one
-	dependentTasks, err := uc.uow.Tasks().GetTasksByDependency(taskID)
+	if uc.config.DetachDependentsOnDelete {
+		return uc.archiveDetachingDependents(ctx, task, *currentUser)
+	}
+	
+	task.Archived = true
+	task.UpdatedAt = uc.now()
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	// The repository drops archived tasks from the assignee's task list
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to archive task: %w", err)
+	}
+	
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to check dependencies: %w", err)
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
 	}
 	
-	if len(dependentTasks) > 0 {
-		return fmt.Errorf("cannot delete task %d: %d tasks depend on it", taskID, len(dependentTasks))
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task archive: %w", err)
+	}
+	
+	return nil
+}
+
+// archiveDetachingDependents archives the task after removing it from the
+// dependencies of every task that depends on it, moving dependents left with
+// only completed dependencies from blocked to pending
+func (uc *TaskUseCase) archiveDetachingDependents(ctx context.Context, task *domain.Task, actor domain.UserID) error {
+	dependents, err := uc.uow.Tasks().GetTasksByDependency(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find dependent tasks: %w", err)
+	}
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
 	}
 	
-	// Delete task
-	if err := uc.uow.Tasks().DeleteTask(taskID); err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	now := uc.now()
+	var unblocked []*domain.Task
+	for _, dependent := range dependents {
+		// Copy the set so the stored task is only changed through UpdateTask
+		dependencies := make(map[domain.TaskID]bool, len(dependent.Dependencies))
+		for id := range dependent.Dependencies {
+			if id != task.ID {
+				dependencies[id] = true
+			}
+		}
+		dependent.Dependencies = dependencies
+		if dependent.ShouldUnblock(allTasks) && uc.config.TransitionPolicy.IsValid(dependent.Status, domain.StatusPending) {
+			dependent.Status = domain.StatusPending
+			unblocked = append(unblocked, dependent)
+		}
+		dependent.UpdatedAt = now
+		if err := uc.uow.Tasks().UpdateTask(ctx, dependent); err != nil {
+			uc.uow.Rollback()
+			return fmt.Errorf("failed to detach dependent task %d: %w", dependent.ID, err)
+		}
+	}
+	
+	task.Archived = true
+	task.UpdatedAt = now
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to archive task: %w", err)
+	}
+	
+	if err := uc.checkInvariants(ctx); err != nil {
+		uc.uow.Rollback()
+		return err
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task deletion: %w", err)
+	}
+	for _, dependent := range unblocked {
+		metrics.RecordTransition(domain.StatusBlocked, domain.StatusPending)
+		uc.notifyStatusChange(ctx, dependent, domain.StatusBlocked, actor)
+		// Detaching the deleted task is what made the dependent ready
+		uc.publish(ctx, domain.EventTaskUnblocked, dependent, actor, map[string]string{
+			"dependency": strconv.Itoa(int(task.ID)),
+		})
 	}
 	
 	return nil
 }
 
-// CheckDependencies implements TLA+ CheckDependencies action
-func (uc *TaskUseCase) CheckDependencies() (int, error) {
+// CancelTask cancels a task and blocks the tasks that depend on it.
+//
+// A cancelled task can never complete, so any dependent that is still pending or
+// in progress can no longer make progress: it is moved to blocked and stays there
+// (CheckDependencies will not unblock it) until the dependency is resolved by hand.
+// Dependents that are already blocked, completed or cancelled are left untouched.
+// The IDs of the newly blocked dependents are returned.
+func (uc *TaskUseCase) CancelTask(ctx context.Context, taskID domain.TaskID) ([]domain.TaskID, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+	
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+	
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have permission to cancel task %d", taskID)
+	}
+	
+	if !uc.config.TransitionPolicy.IsValid(task.Status, domain.StatusCancelled) {
+		return nil, fmt.Errorf("%w from %s to %s", domain.ErrInvalidTransition, task.Status, domain.StatusCancelled)
+	}
+	
+	dependents, err := uc.uow.Tasks().GetTasksByDependency(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dependent tasks: %w", err)
+	}
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	now := uc.now()
+	oldStatus := task.Status
+	task.Status = domain.StatusCancelled
+	task.UpdatedAt = now
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to cancel task: %w", err)
+	}
+	
+	type transition struct {
+		task *domain.Task
+		from domain.TaskStatus
+	}
+	transitions := []transition{{task, oldStatus}}
+	blocked := []domain.TaskID{}
+	for _, dependent := range dependents {
+		if dependent.Status != domain.StatusPending && dependent.Status != domain.StatusInProgress {
+			continue
+		}
+		
+		transitions = append(transitions, transition{dependent, dependent.Status})
+		dependent.Status = domain.StatusBlocked
+		dependent.UpdatedAt = now
+		if err := uc.uow.Tasks().UpdateTask(ctx, dependent); err != nil {
+			uc.uow.Rollback()
+			return nil, fmt.Errorf("failed to block dependent task %d: %w", dependent.ID, err)
+		}
+		blocked = append(blocked, dependent.ID)
+	}
+	
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w after cancellation: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cancellation: %w", err)
+	}
+	for _, t := range transitions {
+		metrics.RecordTransition(t.from, t.task.Status)
+		uc.notifyStatusChange(ctx, t.task, t.from, *currentUser)
+	}
+	
+	return blocked, nil
+}
+
+// GetTasksDueBetween returns tasks whose due date falls within [start, end].
+// Tasks without a due date are excluded, as are archived tasks unless
+// includeArchived is set.
+func (uc *TaskUseCase) GetTasksDueBetween(ctx context.Context, start, end time.Time, includeArchived bool) ([]*domain.Task, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+	
+	if end.Before(start) {
+		return nil, fmt.Errorf("end of range (%v) is before start (%v)", end, start)
+	}
+	
+	tasks, err := uc.uow.Tasks().GetTasksDueBetween(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks due between %v and %v: %w", start, end, err)
+	}
+	
+	if includeArchived {
+		return tasks, nil
+	}
+	
+	visible := make([]*domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.Archived {
+			visible = append(visible, task)
+		}
+	}
+	
+	return visible, nil
+}
+
+// CheckDependencies implements TLA+ CheckDependencies action, returning the
+// number of tasks unblocked
+func (uc *TaskUseCase) CheckDependencies(ctx context.Context) (int, error) {
+	report, err := uc.CheckDependenciesReport(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(report.Unblocked), nil
+}
+
+// DependencyReport is the outcome of a CheckDependencies pass
+type DependencyReport struct {
+	// Unblocked holds the tasks returned to pending, in ID order
+	Unblocked []domain.TaskID
+	// StillBlocked holds the tasks that remain blocked, in ID order
+	StillBlocked []BlockedTask
+	// Escalated holds the stale pending tasks whose priority was raised, in
+	// ID order; it is empty unless escalation is enabled
+	Escalated []domain.TaskID
+}
+
+// BlockedTask is a blocked task with the dependencies it is waiting on
+type BlockedTask struct {
+	TaskID    domain.TaskID
+	WaitingOn []domain.TaskID
+}
+
+// CheckDependenciesReport runs CheckDependencies and reports which tasks were
+// unblocked and which are still blocked, with their incomplete dependencies.
+// When escalation is enabled, stale pending tasks are escalated afterwards.
+func (uc *TaskUseCase) CheckDependenciesReport(ctx context.Context) (*DependencyReport, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
 	// Find all blocked tasks and check if they can be unblocked
-	blockedTasks, err := uc.uow.Tasks().GetTasksByStatus(domain.StatusBlocked)
+	blockedTasks, err := uc.uow.Tasks().GetTasksByStatus(ctx, domain.StatusBlocked)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get blocked tasks: %w", err)
+		return nil, fmt.Errorf("failed to get blocked tasks: %w", err)
 	}
+	sort.Slice(blockedTasks, func(i, j int) bool { return blockedTasks[i].ID < blockedTasks[j].ID })
 	
-	allTasks, err := uc.uow.Tasks().GetAllTasks()
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get all tasks: %w", err)
+		return nil, fmt.Errorf("failed to get all tasks: %w", err)
+	}
+	
+	report := &DependencyReport{
+		Unblocked:    []domain.TaskID{},
+		StillBlocked: []BlockedTask{},
+		Escalated:    []domain.TaskID{},
+	}
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	
-	unblockedCount := 0
+	var unblocked []*domain.Task
 	for _, task := range blockedTasks {
-		if task.ShouldUnblock(allTasks) {
-			task.Status = domain.StatusPending
-			task.UpdatedAt = time.Now()
-			
-			if err := uc.uow.Tasks().UpdateTask(task); err != nil {
-				return unblockedCount, fmt.Errorf("failed to unblock task %d: %w", task.ID, err)
-			}
-			unblockedCount++
+		if !task.ShouldUnblock(allTasks) {
+			report.StillBlocked = append(report.StillBlocked, BlockedTask{
+				TaskID:    task.ID,
+				WaitingOn: task.IncompleteDependencies(allTasks),
+			})
+			continue
+		}
+		
+		task.Status = domain.StatusPending
+		task.UpdatedAt = uc.now()
+		
+		if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+			uc.uow.Rollback()
+			return nil, fmt.Errorf("failed to unblock task %d: %w", task.ID, err)
+		}
+		unblocked = append(unblocked, task)
+		report.Unblocked = append(report.Unblocked, task.ID)
+	}
+	
+	escalations, err := uc.escalateStaleTasks(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, err
+	}
+	
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit dependency check: %w", err)
+	}
+	for _, task := range unblocked {
+		metrics.RecordTransition(domain.StatusBlocked, domain.StatusPending)
+		uc.notifyStatusChange(ctx, task, domain.StatusBlocked, "")
+		if err := uc.notifyUnblocked(ctx, task, allTasks, ""); err != nil {
+			return report, err
 		}
 	}
+	report.Escalated = append(report.Escalated, uc.publishEscalations(ctx, escalations)...)
 	
-	return unblockedCount, nil
+	return report, nil
 }
 
-// BulkUpdateStatus implements TLA+ BulkUpdateStatus action
-func (uc *TaskUseCase) BulkUpdateStatus(taskIDs []domain.TaskID, newStatus domain.TaskStatus) error {
-	currentUser, err := uc.uow.SystemState().GetCurrentUser()
-	if err != nil || currentUser == nil {
-		return fmt.Errorf("authentication required")
+// EscalateStaleTasks raises the priority of every pending task that has not
+// been updated for EscalationAge by one level (by default low, medium, high, critical),
+// publishing a task.escalated event for each. Escalating updates the task, so
+// a task still idle after another EscalationAge is escalated again. It does
+// nothing unless escalation is enabled and returns the escalated tasks in ID
+// order.
+func (uc *TaskUseCase) EscalateStaleTasks(ctx context.Context) ([]domain.TaskID, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	if !uc.config.EscalationEnabled {
+		return nil, nil
 	}
 	
-	// Check all tasks exist and user has access
-	for _, taskID := range taskIDs {
-		task, err := uc.uow.Tasks().GetTask(taskID)
-		if err != nil {
-			return fmt.Errorf("task %d not found: %w", taskID, err)
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	escalations, err := uc.escalateStaleTasks(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, err
+	}
+	
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return nil, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit escalation: %w", err)
+	}
+	
+	return uc.publishEscalations(ctx, escalations), nil
+}
+
+// escalation is a priority raise to announce once it has been committed
+type escalation struct {
+	task *domain.Task
+	from domain.Priority
+}
+
+// escalateStaleTasks escalates the stale pending tasks within the caller's
+// transaction; the caller publishes the escalations after committing
+func (uc *TaskUseCase) escalateStaleTasks(ctx context.Context) ([]escalation, error) {
+	if !uc.config.EscalationEnabled {
+		return nil, nil
+	}
+	
+	pending, err := uc.uow.Tasks().GetTasksByStatus(ctx, domain.StatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending tasks: %w", err)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+	
+	now := uc.now()
+	var escalations []escalation
+	for _, task := range pending {
+		if task.Archived || task.Priority.IsHighest() {
+			continue
 		}
-		
-		if task.Assignee != *currentUser {
-			return fmt.Errorf("user does not have access to task %d", taskID)
+		if now.Sub(task.UpdatedAt) < uc.config.EscalationAge {
+			continue
 		}
 		
-		// Check valid transition
-		if !domain.IsValidTransition(task.Status, newStatus) {
-			return fmt.Errorf("invalid transition for task %d from %s to %s", taskID, task.Status, newStatus)
+		from := task.Priority
+		task.Priority = from.Escalated()
+		task.UpdatedAt = now
+		
+		if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to escalate task %d: %w", task.ID, err)
+		}
+		escalations = append(escalations, escalation{task: task, from: from})
+	}
+	
+	return escalations, nil
+}
+
+// publishEscalations publishes a task.escalated event for each committed
+// escalation and returns the escalated tasks in order
+func (uc *TaskUseCase) publishEscalations(ctx context.Context, escalations []escalation) []domain.TaskID {
+	escalated := make([]domain.TaskID, 0, len(escalations))
+	for _, e := range escalations {
+		uc.publish(ctx, domain.EventTaskEscalated, e.task, "", map[string]string{
+			"from": string(e.from),
+			"to":   string(e.task.Priority),
+		})
+		escalated = append(escalated, e.task.ID)
+	}
+	return escalated
+}
+
+// RepairIndex rebuilds the userTasks index from the tasks' assignees, restoring
+// the NoOrphanTasks and TaskOwnership invariants if the two have drifted apart.
+// Only admins may run it; the number of corrected entries is returned.
+func (uc *TaskUseCase) RepairIndex(ctx context.Context) (int, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return 0, domain.ErrUnauthenticated
+	}
+	
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return 0, fmt.Errorf("current user not found: %w", err)
+	}
+	
+	if !actor.IsAdmin() {
+		return 0, fmt.Errorf("only admins can repair the task index")
+	}
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	changed, err := uc.uow.SystemState().RebuildUserTaskIndex(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return 0, fmt.Errorf("failed to rebuild task index: %w", err)
+	}
+	
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return 0, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return 0, fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit task index repair: %w", err)
+	}
+	
+	return changed, nil
+}
+
+// CheckReadiness verifies the repository can be read and that the current
+// state satisfies every invariant, returning the first violation otherwise
+func (uc *TaskUseCase) CheckReadiness(ctx context.Context) error {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+	
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		return fmt.Errorf("repository unavailable: %w", err)
+	}
+	
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	return nil
+}
+
+// BulkUpdateStatus implements TLA+ BulkUpdateStatus action. Each task must
+// pass the checks of UpdateTaskStatus, dependency readiness included, or
+// none is updated. A dependency counts as completed only if it was before
+// the update, even when it is completed in the same batch.
+func (uc *TaskUseCase) BulkUpdateStatus(ctx context.Context, taskIDs []domain.TaskID, newStatus domain.TaskStatus) error {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return domain.ErrUnauthenticated
+	}
+	
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+	
+	// Check every task as UpdateTaskStatus would, dependency readiness
+	// included; the first task that fails rejects the whole update
+	updated := make([]*domain.Task, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		if err := uc.checkStatusChange(allTasks, taskID, *currentUser, newStatus); err != nil {
+			return err
 		}
+		updated = append(updated, allTasks[taskID])
 	}
 	
 	// Perform bulk update
-	if err := uc.uow.Tasks().BulkUpdateStatus(taskIDs, newStatus); err != nil {
+	if err := uc.uow.Begin(ctx); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	if err := uc.uow.Tasks().BulkUpdateStatus(ctx, taskIDs, newStatus); err != nil {
+		uc.uow.Rollback()
 		return fmt.Errorf("bulk update failed: %w", err)
 	}
 	
 	// Check invariants
-	state, _ := uc.uow.SystemState().GetSystemState()
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return fmt.Errorf("failed to get system state: %w", err)
+	}
 	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
 		uc.uow.Rollback()
-		return fmt.Errorf("invariant violation after bulk update: %w", err)
+		metrics.RecordInvariantViolation()
+		return fmt.Errorf("%w after bulk update: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return fmt.Errorf("failed to commit bulk update: %w", err)
+	}
+	for _, task := range updated {
+		from := task.Status
+		task.Status = newStatus
+		metrics.RecordTransition(from, newStatus)
+		uc.notifyStatusChange(ctx, task, from, *currentUser)
 	}
 	
 	return nil
 }
 
+// BulkReassign moves every task assigned to from over to to, for example when
+// teams are reorganized. Only admins may run it. The tasks are moved in a
+// single transaction and invariants are checked once after the last move.
+// The number of tasks moved is returned.
+func (uc *TaskUseCase) BulkReassign(ctx context.Context, from, to domain.UserID) (int, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+	
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return 0, domain.ErrUnauthenticated
+	}
+	
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return 0, fmt.Errorf("current user not found: %w", err)
+	}
+	
+	if !actor.IsAdmin() {
+		return 0, fmt.Errorf("only admins can bulk reassign tasks")
+	}
+	
+	// Verify both users exist; either may be given by email
+	from, err = uc.resolveUserID(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := uc.uow.Users().GetUser(ctx, from); err != nil {
+		return 0, fmt.Errorf("%w: previous assignee: %w", domain.ErrUserNotFound, err)
+	}
+	to, err = uc.resolveUserID(ctx, to)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := uc.uow.Users().GetUser(ctx, to); err != nil {
+		return 0, fmt.Errorf("%w: new assignee: %w", domain.ErrUserNotFound, err)
+	}
+	if from == to {
+		return 0, fmt.Errorf("cannot reassign tasks from %s to themselves", from)
+	}
+	
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	
+	moved := make([]*domain.Task, 0)
+	for _, task := range allTasks {
+		if task.Assignee == from {
+			moved = append(moved, task)
+		}
+	}
+	sort.Slice(moved, func(i, j int) bool { return moved[i].ID < moved[j].ID })
+	if len(moved) == 0 {
+		return 0, nil
+	}
+	
+	if err := uc.uow.Begin(ctx); err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	// The tasks join the end of the new assignee's backlog in ID order
+	rank, err := uc.bottomRank(ctx, to)
+	if err != nil {
+		uc.uow.Rollback()
+		return 0, err
+	}
+	
+	now := uc.now()
+	for _, task := range moved {
+		task.Assignee = to
+		task.Rank = rank
+		rank += domain.RankGap
+		
+		// A collaborator who becomes the assignee no longer needs a separate entry
+		var collaborators []domain.UserID
+		for _, userID := range task.Collaborators {
+			if userID != to {
+				collaborators = append(collaborators, userID)
+			}
+		}
+		task.Collaborators = collaborators
+		task.UpdatedAt = now
+		
+		// The repository moves the task between the assignees' task lists
+		if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+			uc.uow.Rollback()
+			return 0, fmt.Errorf("failed to reassign task %d: %w", task.ID, err)
+		}
+	}
+	
+	// Check invariants
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		uc.uow.Rollback()
+		return 0, fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		uc.uow.Rollback()
+		metrics.RecordInvariantViolation()
+		return 0, fmt.Errorf("%w after bulk reassign: %w", domain.ErrInvariantViolation, err)
+	}
+	
+	if err := uc.uow.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit bulk reassign: %w", err)
+	}
+	for _, task := range moved {
+		uc.publish(ctx, domain.EventTaskReassigned, task, *currentUser, map[string]string{
+			"from": string(from),
+			"to":   string(to),
+		})
+		uc.notifyAssignee(task, *currentUser)
+	}
+	
+	return len(moved), nil
+}
+
 // Helper functions
 
+// checkInvariants checks the safety invariants against the state of the
+// current transaction; the caller rolls back on error
+func (uc *TaskUseCase) checkInvariants(ctx context.Context) error {
+	state, err := uc.uow.SystemState().GetSystemState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get system state: %w", err)
+	}
+	if err := uc.invariantChecker.CheckAllInvariants(state); err != nil {
+		metrics.RecordInvariantViolation()
+		return fmt.Errorf("%w: %w", domain.ErrInvariantViolation, err)
+	}
+	return nil
+}
+
+// checkTaskLimit returns ErrMaxTasksReached if one more task would exceed
+// MaxTasks, counting either every task ever created (nextTaskId) or, with
+// CountLiveTasks, the tasks currently stored
+func (uc *TaskUseCase) checkTaskLimit(ctx context.Context) error {
+	if uc.config.CountLiveTasks {
+		allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get tasks: %w", err)
+		}
+		if len(allTasks) >= uc.config.MaxTasks {
+			return fmt.Errorf("%w (%d) reached", domain.ErrMaxTasksReached, uc.config.MaxTasks)
+		}
+		return nil
+	}
+	
+	nextID, err := uc.uow.SystemState().GetNextTaskID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get next task ID: %w", err)
+	}
+	if int(nextID) > uc.config.MaxTasks {
+		return fmt.Errorf("%w (%d) reached", domain.ErrMaxTasksReached, uc.config.MaxTasks)
+	}
+	return nil
+}
+
+// notifyStatusChange publishes a status change of the task
+func (uc *TaskUseCase) notifyStatusChange(ctx context.Context, task *domain.Task, from domain.TaskStatus, actor domain.UserID) {
+	uc.publish(ctx, domain.EventTaskStatusChanged, task, actor, map[string]string{
+		"from": string(from),
+		"to":   string(task.Status),
+	})
+}
+
+// publish records a task event in the activity log and emits it to the
+// task's watchers, tagged with the request ID of ctx
+func (uc *TaskUseCase) publish(ctx context.Context, eventType domain.EventType, task *domain.Task, actor domain.UserID, data map[string]string) {
+	event := domain.Event{
+		Type:       eventType,
+		TaskID:     task.ID,
+		Assignee:   task.Assignee,
+		Actor:      actor,
+		Recipients: task.WatcherIDs(),
+		Data:       data,
+		Timestamp:  uc.now(),
+		RequestID:  requestid.FromContext(ctx),
+	}
+	
+	// The change is already committed, so it is logged even if the request
+	// has been canceled meanwhile
+	if err := uc.uow.Activity().RecordActivity(context.WithoutCancel(ctx), domain.NewActivity(event)); err != nil {
+		metrics.RecordActivityDropped()
+	}
+	
+	if uc.publisher != nil {
+		uc.publisher.Publish(event)
+	}
+}
+
+// checkDueDate rejects a due date in the past when RequireFutureDueDate is set
+func (uc *TaskUseCase) checkDueDate(dueDate *time.Time) error {
+	if !uc.config.RequireFutureDueDate || dueDate == nil {
+		return nil
+	}
+	if dueDate.Before(uc.now()) {
+		return fmt.Errorf("%w: %s", domain.ErrDueDateInPast, dueDate.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// notifyAssignee tells the task's assignee it was assigned to them, unless
+// they assigned it to themselves
+func (uc *TaskUseCase) notifyAssignee(task *domain.Task, actor domain.UserID) {
+	if uc.notifier == nil || task.Assignee == actor {
+		return
+	}
+	
+	uc.notifier.Notify(task.Assignee, fmt.Sprintf("%s assigned task %d %q to you", actor, task.ID, task.Title))
+}
+
+// canManage checks if the user may manage the task: admins can manage any task,
+// members only the tasks assigned to them or created by them
+func canManage(user *domain.User, task *domain.Task) bool {
+	return user.IsAdmin() || task.Assignee == user.ID || task.CreatedBy == user.ID
+}
+
 func generateToken() string {
 	b := make([]byte, 32)
 	rand.Read(b)
@@ -522,49 +1935,131 @@ func (uc *TaskUseCase) checkCyclicDependencies(
 	dependencies map[domain.TaskID]bool,
 	allTasks map[domain.TaskID]*domain.Task,
 ) error {
-	// Build dependency graph and check for cycles
+	// Build dependency graph and search it for a cycle, keeping the path
+	// from the new task so the cycle can be reported
 	visited := make(map[domain.TaskID]bool)
-	recStack := make(map[domain.TaskID]bool)
+	onPath := make(map[domain.TaskID]int)
+	var path []domain.TaskID
 	
-	var hasCycle func(taskID domain.TaskID) bool
-	hasCycle = func(taskID domain.TaskID) bool {
+	var findCycle func(taskID domain.TaskID) domain.DependencyCycle
+	findCycle = func(taskID domain.TaskID) domain.DependencyCycle {
 		visited[taskID] = true
-		recStack[taskID] = true
+		onPath[taskID] = len(path)
+		path = append(path, taskID)
 		
-		task, exists := allTasks[taskID]
-		if !exists {
+		var deps map[domain.TaskID]bool
+		if task, exists := allTasks[taskID]; exists {
+			deps = task.Dependencies
+		} else if taskID == newTaskID {
 			// For new task being created
-			if taskID == newTaskID {
-				for depID := range dependencies {
-					if !visited[depID] {
-						if hasCycle(depID) {
-							return true
-						}
-					} else if recStack[depID] {
-						return true
-					}
-				}
+			deps = dependencies
+		}
+		for _, depID := range domain.SortedTaskIDs(deps) {
+			if start, found := onPath[depID]; found {
+				return append(append(domain.DependencyCycle{}, path[start:]...), depID)
 			}
-		} else {
-			for depID := range task.Dependencies {
-				if !visited[depID] {
-					if hasCycle(depID) {
-						return true
-					}
-				} else if recStack[depID] {
-					return true
+			if !visited[depID] {
+				if cycle := findCycle(depID); cycle != nil {
+					return cycle
 				}
 			}
 		}
 		
-		recStack[taskID] = false
-		return false
+		path = path[:len(path)-1]
+		delete(onPath, taskID)
+		return nil
 	}
 	
 	// Check from the new task
-	if hasCycle(newTaskID) {
-		return fmt.Errorf("cyclic dependency detected")
+	if cycle := findCycle(newTaskID); cycle != nil {
+		return fmt.Errorf("%w: cycle: %s", domain.ErrCyclicDependency, cycle)
+	}
+	
+	return nil
+}
+
+// checkDependencyDepth rejects giving a task the given dependencies if the
+// longest dependency chain running through it, from its furthest dependent
+// down to its deepest dependency, would exceed MaxDependencyDepth. Like
+// checkCyclicDependencies, it takes the other tasks without the task itself;
+// the graph must already be known to be acyclic.
+func (uc *TaskUseCase) checkDependencyDepth(
+	taskID domain.TaskID,
+	dependencies map[domain.TaskID]bool,
+	others map[domain.TaskID]*domain.Task,
+) error {
+	limit := uc.config.MaxDependencyDepth
+	if limit <= 0 {
+		return nil
+	}
+	
+	dependents := make(map[domain.TaskID][]domain.TaskID)
+	for id, task := range others {
+		for depID := range task.Dependencies {
+			dependents[depID] = append(dependents[depID], id)
+		}
+	}
+	
+	// Longest path below the task through its new dependencies
+	below := 0
+	depthOf := longestPath(func(id domain.TaskID) []domain.TaskID {
+		task, exists := others[id]
+		if !exists {
+			return nil
+		}
+		ids := make([]domain.TaskID, 0, len(task.Dependencies))
+		for depID := range task.Dependencies {
+			if _, exists := others[depID]; exists {
+				ids = append(ids, depID)
+			}
+		}
+		return ids
+	})
+	for depID := range dependencies {
+		if _, exists := others[depID]; exists && 1+depthOf(depID) > below {
+			below = 1 + depthOf(depID)
+		}
 	}
 	
+	// Longest path above the task through the tasks depending on it
+	above := longestPath(func(id domain.TaskID) []domain.TaskID {
+		return dependents[id]
+	})(taskID)
+	
+	if depth := above + below; depth > limit {
+		return fmt.Errorf("%w: the chain through task %d would be %d dependencies deep, the limit is %d",
+			domain.ErrDependencyTooDeep, taskID, depth, limit)
+	}
 	return nil
 }
+
+// longestPath returns a memoized function giving the number of edges on the
+// longest path from a task along next. Revisiting a task still being explored,
+// which only a cycle can cause, ends the path there.
+func longestPath(next func(id domain.TaskID) []domain.TaskID) func(id domain.TaskID) int {
+	lengths := make(map[domain.TaskID]int)
+	exploring := make(map[domain.TaskID]bool)
+	
+	var length func(id domain.TaskID) int
+	length = func(id domain.TaskID) int {
+		if n, done := lengths[id]; done {
+			return n
+		}
+		if exploring[id] {
+			return 0
+		}
+		exploring[id] = true
+		
+		longest := 0
+		for _, nextID := range next(id) {
+			if n := 1 + length(nextID); n > longest {
+				longest = n
+			}
+		}
+		
+		exploring[id] = false
+		lengths[id] = longest
+		return longest
+	}
+	return length
+}
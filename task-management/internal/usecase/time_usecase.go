@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// LogTime adds hours of work to a task's actual time
+func (uc *TaskUseCase) LogTime(ctx context.Context, taskID domain.TaskID, hours float64) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if hours < 0 {
+		return nil, fmt.Errorf("logged %w", domain.ErrNegativeHours)
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	actor, err := uc.uow.Users().GetUser(ctx, *currentUser)
+	if err != nil {
+		return nil, fmt.Errorf("current user not found: %w", err)
+	}
+
+	if !canManage(actor, task) {
+		return nil, fmt.Errorf("user does not have access to task %d", taskID)
+	}
+
+	if task.Status == domain.StatusCancelled {
+		return nil, fmt.Errorf("cannot log time against cancelled task %d", taskID)
+	}
+
+	task.ActualHours += hours
+	task.UpdatedAt = uc.now()
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to log time: %w", err)
+	}
+
+	return task, nil
+}
+
+// GetStatusDurations returns how long a task has spent in each status it has
+// entered, with the current status counting until now. A task without a
+// recorded history is treated as having been in its current status since it
+// was created.
+func (uc *TaskUseCase) GetStatusDurations(ctx context.Context, taskID domain.TaskID) (map[domain.TaskStatus]time.Duration, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	history, err := uc.uow.Tasks().GetStatusHistory(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	if len(history) == 0 {
+		history = []domain.StatusChange{{Status: task.Status, At: task.CreatedAt}}
+	}
+
+	return domain.StatusDurations(history, uc.now()), nil
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// FindUserByEmail returns the user registered with the given email, compared
+// case-insensitively
+func (uc *TaskUseCase) FindUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if strings.TrimSpace(email) == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+
+	user, err := uc.uow.Users().GetByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrUserNotFound, err)
+	}
+	return user, nil
+}
+
+const (
+	// DefaultUserLimit is the page size of user listings when none is given
+	DefaultUserLimit = 50
+	// MaxUserLimit is the largest page size of user listings
+	MaxUserLimit = 200
+)
+
+// ListUsers returns a page of the users matching the filter, in filter order:
+// up to limit users after skipping offset of them, along with the number of
+// matching users. A zero limit selects DefaultUserLimit.
+func (uc *TaskUseCase) ListUsers(ctx context.Context, filter domain.UserFilter, offset, limit int) ([]*domain.User, int, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, 0, domain.ErrUnauthenticated
+	}
+
+	if limit == 0 {
+		limit = DefaultUserLimit
+	}
+	if limit < 0 || limit > MaxUserLimit {
+		return nil, 0, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidPage, MaxUserLimit)
+	}
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("%w: offset cannot be negative", domain.ErrInvalidPage)
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	users, total, err := uc.uow.Users().QueryUsers(ctx, filter, domain.Page{Offset: offset, Limit: limit})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// RegisterUser adds a member with the given ID, name and email. Registering
+// is idempotent: repeating a registration with the same details returns the
+// existing user with created false. A different user with the same ID or
+// email yields ErrDuplicateUser or ErrDuplicateEmail. The password, if any,
+// is what Login checks; a repeated registration leaves it unchanged, and a
+// user registered without one can log in only when Config.NoAuth is set.
+func (uc *TaskUseCase) RegisterUser(ctx context.Context, id domain.UserID, name, email, password string) (*domain.User, bool, error) {
+	user := &domain.User{
+		ID:       domain.UserID(strings.TrimSpace(string(id))),
+		Name:     strings.TrimSpace(name),
+		Email:    strings.TrimSpace(email),
+		Role:     domain.RoleMember,
+		JoinedAt: uc.now(),
+	}
+	if err := user.Validate(); err != nil {
+		return nil, false, err
+	}
+	// Hashing is slow, so it happens before taking the lock
+	if password != "" {
+		hash, err := domain.HashPassword(password)
+		if err != nil {
+			return nil, false, err
+		}
+		user.PasswordHash = hash
+	}
+
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	if err := uc.uow.Begin(ctx); err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if existing, err := uc.uow.Users().GetUser(ctx, user.ID); err == nil {
+		uc.uow.Rollback()
+		if existing.Name == user.Name && domain.NormalizeEmail(existing.Email) == domain.NormalizeEmail(user.Email) {
+			return existing, false, nil
+		}
+		return nil, false, fmt.Errorf("%w: %s", domain.ErrDuplicateUser, user.ID)
+	}
+	if owner, err := uc.uow.Users().GetByEmail(ctx, user.Email); err == nil {
+		uc.uow.Rollback()
+		return nil, false, fmt.Errorf("%w: %s is used by %s", domain.ErrDuplicateEmail, user.Email, owner.ID)
+	}
+
+	if err := uc.uow.Users().CreateUser(ctx, user); err != nil {
+		uc.uow.Rollback()
+		return nil, false, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if err := uc.uow.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return user, true, nil
+}
+
+// SuggestAssignee returns the known user with the fewest open (not completed
+// or cancelled) tasks assigned, choosing the lowest user ID among equally
+// loaded users
+func (uc *TaskUseCase) SuggestAssignee(ctx context.Context) (domain.UserID, error) {
+	uc.uow.RLock(ctx)
+	defer uc.uow.RUnlock(ctx)
+
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return "", domain.ErrUnauthenticated
+	}
+
+	return uc.suggestAssignee(ctx)
+}
+
+// suggestAssignee picks the least loaded user for SuggestAssignee and for
+// tasks created without an assignee
+func (uc *TaskUseCase) suggestAssignee(ctx context.Context) (domain.UserID, error) {
+	users, err := uc.uow.Users().GetAllUsers(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get users: %w", err)
+	}
+	if len(users) == 0 {
+		return "", fmt.Errorf("%w: no users to assign", domain.ErrUserNotFound)
+	}
+	allTasks, err := uc.uow.Tasks().GetAllTasks(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	load := make(map[domain.UserID]int, len(users))
+	for _, task := range allTasks {
+		if !task.IsDone() {
+			load[task.Assignee]++
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if load[users[i].ID] != load[users[j].ID] {
+			return load[users[i].ID] < load[users[j].ID]
+		}
+		return users[i].ID < users[j].ID
+	})
+	return users[0].ID, nil
+}
+
+// resolveUserID lets callers name a user by email in place of a user ID. An
+// existing user ID is returned unchanged; otherwise a value containing "@" is
+// looked up by email. Anything else is returned as-is for the caller's own
+// existence check to reject.
+func (uc *TaskUseCase) resolveUserID(ctx context.Context, userID domain.UserID) (domain.UserID, error) {
+	if _, err := uc.uow.Users().GetUser(ctx, userID); err == nil {
+		return userID, nil
+	}
+	if !strings.Contains(string(userID), "@") {
+		return userID, nil
+	}
+
+	user, err := uc.uow.Users().GetByEmail(ctx, string(userID))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", domain.ErrUserNotFound, err)
+	}
+	return user.ID, nil
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// AddWatcher subscribes a user to a task's status changes.
+// An empty userID subscribes the current user; adding an existing watcher is a no-op.
+func (uc *TaskUseCase) AddWatcher(ctx context.Context, taskID domain.TaskID, userID domain.UserID) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	return uc.updateWatchers(ctx, taskID, userID, true)
+}
+
+// RemoveWatcher unsubscribes a user from a task.
+// An empty userID unsubscribes the current user; removing a non-watcher is a no-op.
+func (uc *TaskUseCase) RemoveWatcher(ctx context.Context, taskID domain.TaskID, userID domain.UserID) (*domain.Task, error) {
+	uc.uow.Lock(ctx)
+	defer uc.uow.Unlock(ctx)
+
+	return uc.updateWatchers(ctx, taskID, userID, false)
+}
+
+func (uc *TaskUseCase) updateWatchers(ctx context.Context, taskID domain.TaskID, userID domain.UserID, watch bool) (*domain.Task, error) {
+	currentUser, err := uc.uow.SystemState().GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if userID == "" {
+		userID = *currentUser
+	}
+
+	task, err := uc.uow.Tasks().GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrTaskNotFound, err)
+	}
+
+	if _, err := uc.uow.Users().GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("watcher not found: %w", err)
+	}
+
+	if task.Watchers[userID] == watch {
+		return task, nil
+	}
+
+	// Copy the set so the stored task is only changed through UpdateTask
+	watchers := make(map[domain.UserID]bool, len(task.Watchers)+1)
+	for id := range task.Watchers {
+		watchers[id] = true
+	}
+	if watch {
+		watchers[userID] = true
+	} else {
+		delete(watchers, userID)
+	}
+	task.Watchers = watchers
+
+	if err := uc.uow.Tasks().UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update watchers: %w", err)
+	}
+
+	return task, nil
+}
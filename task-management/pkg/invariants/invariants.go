@@ -3,66 +3,176 @@ package invariants
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bhatti/sample-task-management/internal/domain"
 )
 
 // InvariantChecker implements all TLA+ safety invariants
-type InvariantChecker struct{}
+type InvariantChecker struct {
+	dueSoonThreshold time.Duration
+	pendingThreshold time.Duration
+	stuckThreshold   time.Duration
+	policy           *domain.TransitionPolicy
+	clock            domain.Clock
+	enabled          map[string]bool
+}
 
-// NewInvariantChecker creates a new invariant checker
-func NewInvariantChecker() *InvariantChecker {
-	return &InvariantChecker{}
+// Config holds the tunable settings of an InvariantChecker
+type Config struct {
+	// DueSoonThreshold is the window before a task's due date in which a
+	// "due soon" liveness warning is emitted; zero disables the warning
+	DueSoonThreshold time.Duration
+
+	// StalePendingThreshold is how long a task may stay pending before a
+	// "stale pending" warning is emitted; zero selects
+	// DefaultStalePendingThreshold and a negative value disables the warning
+	StalePendingThreshold time.Duration
+
+	// StuckInProgressThreshold is how long an in-progress task may go without
+	// updates before a "stuck in progress" warning is emitted; zero selects
+	// DefaultStuckInProgressThreshold and a negative value disables the warning
+	StuckInProgressThreshold time.Duration
+
+	// TransitionPolicy defines the allowed status transitions and statuses;
+	// nil selects the default policy
+	TransitionPolicy *domain.TransitionPolicy
+
+	// Clock, when set, supplies the time liveness properties are evaluated
+	// at; nil evaluates them at the state's own clock
+	Clock domain.Clock
+
+	// DisabledInvariants names the safety invariants, as listed by
+	// SafetyInvariantNames, that are not checked; all are checked by default
+	DisabledInvariants []string
 }
 
-// CheckAllInvariants verifies all safety invariants (maps to TLA+ SafetyInvariant)
-func (ic *InvariantChecker) CheckAllInvariants(state *domain.SystemState) error {
-	// Check each invariant from the TLA+ specification
+// DefaultDueSoonThreshold is the suggested window for due-date reminders
+const DefaultDueSoonThreshold = 48 * time.Hour
 
-	if err := ic.checkNoOrphanTasks(state); err != nil {
-		return fmt.Errorf("NoOrphanTasks violated: %w", err)
-	}
+// DefaultStalePendingThreshold is how long a task may stay pending by default
+const DefaultStalePendingThreshold = 7 * 24 * time.Hour
 
-	if err := ic.checkTaskOwnership(state); err != nil {
-		return fmt.Errorf("TaskOwnership violated: %w", err)
-	}
+// DefaultStuckInProgressThreshold is how long an in-progress task may go
+// without updates by default
+const DefaultStuckInProgressThreshold = 7 * 24 * time.Hour
 
-	if err := ic.checkValidTaskIds(state); err != nil {
-		return fmt.Errorf("ValidTaskIds violated: %w", err)
+// NewInvariantChecker creates a new invariant checker
+func NewInvariantChecker() *InvariantChecker {
+	return &InvariantChecker{
+		pendingThreshold: DefaultStalePendingThreshold,
+		stuckThreshold:   DefaultStuckInProgressThreshold,
+		policy:           domain.DefaultTransitionPolicy(),
+		enabled:          enabledInvariants(nil),
 	}
+}
 
-	if err := ic.checkNoDuplicateTaskIds(state); err != nil {
-		return fmt.Errorf("NoDuplicateTaskIds violated: %w", err)
+// NewInvariantCheckerWithConfig creates a new invariant checker with the given settings
+func NewInvariantCheckerWithConfig(config Config) *InvariantChecker {
+	policy := config.TransitionPolicy
+	if policy == nil {
+		policy = domain.DefaultTransitionPolicy()
+	}
+	pendingThreshold := config.StalePendingThreshold
+	if pendingThreshold == 0 {
+		pendingThreshold = DefaultStalePendingThreshold
+	}
+	stuckThreshold := config.StuckInProgressThreshold
+	if stuckThreshold == 0 {
+		stuckThreshold = DefaultStuckInProgressThreshold
 	}
+	return &InvariantChecker{
+		dueSoonThreshold: config.DueSoonThreshold,
+		pendingThreshold: pendingThreshold,
+		stuckThreshold:   stuckThreshold,
+		policy:           policy,
+		clock:            config.Clock,
+		enabled:          enabledInvariants(config.DisabledInvariants),
+	}
+}
 
-	if err := ic.checkValidStateTransitions(state); err != nil {
-		return fmt.Errorf("ValidStateTransitions violated: %w", err)
+// enabledInvariants returns the set of safety invariant names minus the
+// disabled ones
+func enabledInvariants(disabled []string) map[string]bool {
+	enabled := make(map[string]bool)
+	for _, name := range SafetyInvariantNames() {
+		enabled[name] = true
+	}
+	for _, name := range disabled {
+		delete(enabled, name)
 	}
+	return enabled
+}
 
-	if err := ic.checkConsistentTimestamps(state); err != nil {
-		return fmt.Errorf("ConsistentTimestamps violated: %w", err)
+// now returns the time liveness properties of the state are evaluated at
+func (ic *InvariantChecker) now(state *domain.SystemState) time.Time {
+	if ic.clock != nil {
+		return ic.clock.Now()
 	}
+	return state.Clock
+}
+
+// safetyInvariant is a named TLA+ safety invariant check
+type safetyInvariant struct {
+	name  string
+	check func(state *domain.SystemState) error
+}
 
-	if err := ic.checkNoCyclicDependencies(state); err != nil {
-		return fmt.Errorf("NoCyclicDependencies violated: %w", err)
+// safetyInvariants lists the TLA+ safety invariants in the order they are checked
+func (ic *InvariantChecker) safetyInvariants() []safetyInvariant {
+	return []safetyInvariant{
+		{"NoOrphanTasks", ic.checkNoOrphanTasks},
+		{"TaskOwnership", ic.checkTaskOwnership},
+		{"ValidTaskIds", ic.checkValidTaskIds},
+		{"NoDuplicateTaskIds", ic.checkNoDuplicateTaskIds},
+		{"ValidStateTransitions", ic.checkValidStateTransitions},
+		{"ConsistentTimestamps", ic.checkConsistentTimestamps},
+		{"NoCyclicDependencies", ic.CheckNoCyclicDependencies},
+		{"NoDanglingDependencies", ic.checkNoDanglingDependencies},
+		{"AuthenticationRequired", ic.checkAuthenticationRequired},
+		{"AssigneeExists", ic.checkAssigneeExists},
+		{"SessionConsistency", ic.checkSessionConsistency},
 	}
+}
 
-	if err := ic.checkAuthenticationRequired(state); err != nil {
-		return fmt.Errorf("AuthenticationRequired violated: %w", err)
+// SafetyInvariantNames lists the names of the safety invariants in the order
+// they are checked
+func SafetyInvariantNames() []string {
+	var names []string
+	for _, invariant := range (&InvariantChecker{}).safetyInvariants() {
+		names = append(names, invariant.name)
 	}
+	return names
+}
+
+// IsEnabled reports whether the named safety invariant is checked
+func (ic *InvariantChecker) IsEnabled(name string) bool {
+	return ic.enabled[name]
+}
 
+// CheckAllInvariants verifies all enabled safety invariants (maps to TLA+
+// SafetyInvariant) and returns the first violation
+func (ic *InvariantChecker) CheckAllInvariants(state *domain.SystemState) error {
+	for _, invariant := range ic.safetyInvariants() {
+		if !ic.enabled[invariant.name] {
+			continue
+		}
+		if err := invariant.check(state); err != nil {
+			return fmt.Errorf("%s violated: %w", invariant.name, err)
+		}
+	}
 	return nil
 }
 
 // CheckTaskInvariants verifies invariants for a specific task
 func (ic *InvariantChecker) CheckTaskInvariants(task *domain.Task, state *domain.SystemState) error {
 	// Validate task structure
-	if err := task.Validate(); err != nil {
+	if err := task.ValidateWithPolicy(ic.policy); err != nil {
 		return fmt.Errorf("task validation failed: %w", err)
 	}
 
-	// Check task is not orphaned
-	found := false
+	// Check task is not orphaned; archived tasks are in no user's task list
+	found := task.Archived
 	for _, taskIDs := range state.UserTasks {
 		for _, id := range taskIDs {
 			if id == task.ID {
@@ -91,15 +201,19 @@ func (ic *InvariantChecker) CheckTaskInvariants(task *domain.Task, state *domain
 
 // CheckTransitionInvariant verifies state transition validity
 func (ic *InvariantChecker) CheckTransitionInvariant(from, to domain.TaskStatus) error {
-	if !domain.IsValidTransition(from, to) {
+	if !ic.policy.IsValid(from, to) {
 		return fmt.Errorf("invalid transition from %s to %s", from, to)
 	}
 	return nil
 }
 
-// NoOrphanTasks: Every task must be assigned to a user
+// NoOrphanTasks: Every task must be assigned to a user (archived tasks are exempt)
 func (ic *InvariantChecker) checkNoOrphanTasks(state *domain.SystemState) error {
 	for taskID, task := range state.Tasks {
+		if task.Archived {
+			continue
+		}
+		
 		found := false
 		for _, userTasks := range state.UserTasks {
 			for _, id := range userTasks {
@@ -120,20 +234,27 @@ func (ic *InvariantChecker) checkNoOrphanTasks(state *domain.SystemState) error
 	return nil
 }
 
-// TaskOwnership: Tasks must be in their assignee's task list
+// TaskOwnership: Tasks must be in the task list of their assignee and of each
+// collaborator (archived tasks are exempt)
 func (ic *InvariantChecker) checkTaskOwnership(state *domain.SystemState) error {
 	for taskID, task := range state.Tasks {
-		userTasks := state.GetUserTasks(task.Assignee)
-		found := false
-		for _, id := range userTasks {
-			if id == taskID {
-				found = true
-				break
-			}
+		if task.Archived {
+			continue
 		}
+		
+		for _, owner := range task.Owners() {
+			userTasks := state.GetUserTasks(owner)
+			found := false
+			for _, id := range userTasks {
+				if id == taskID {
+					found = true
+					break
+				}
+			}
 
-		if !found {
-			return fmt.Errorf("task %d assigned to %s but not in their task list", taskID, task.Assignee)
+			if !found {
+				return fmt.Errorf("task %d owned by %s but not in their task list", taskID, owner)
+			}
 		}
 	}
 	return nil
@@ -169,16 +290,8 @@ func (ic *InvariantChecker) checkNoDuplicateTaskIds(state *domain.SystemState) e
 
 // ValidStateTransitions: All task states must be valid
 func (ic *InvariantChecker) checkValidStateTransitions(state *domain.SystemState) error {
-	validStates := map[domain.TaskStatus]bool{
-		domain.StatusPending:    true,
-		domain.StatusInProgress: true,
-		domain.StatusCompleted:  true,
-		domain.StatusCancelled:  true,
-		domain.StatusBlocked:    true,
-	}
-
 	for taskID, task := range state.Tasks {
-		if !validStates[task.Status] {
+		if !ic.policy.HasStatus(task.Status) {
 			return fmt.Errorf("task %d has invalid status %s", taskID, task.Status)
 		}
 	}
@@ -201,44 +314,103 @@ func (ic *InvariantChecker) checkConsistentTimestamps(state *domain.SystemState)
 	return nil
 }
 
-// NoCyclicDependencies: No task can depend on itself transitively
-func (ic *InvariantChecker) checkNoCyclicDependencies(state *domain.SystemState) error {
-	// For each task, compute transitive dependencies and check for cycles
-	for taskID := range state.Tasks {
-		visited := make(map[domain.TaskID]bool)
-		recStack := make(map[domain.TaskID]bool)
+// CheckNoCyclicDependencies verifies NoCyclicDependencies: no task can depend on itself transitively
+func (ic *InvariantChecker) CheckNoCyclicDependencies(state *domain.SystemState) error {
+	// This runs after every mutation, so settle the usual acyclic case with one
+	// topological pass and only search for the cycle to report when there is one
+	if isAcyclic(state) {
+		return nil
+	}
 
-		if ic.hasCycle(taskID, state, visited, recStack) {
-			return fmt.Errorf("cyclic dependency detected starting from task %d", taskID)
+	// Search from each task in ID order, so the same cycle is reported each time
+	visited := make(map[domain.TaskID]bool)
+	for _, taskID := range domain.SortedTaskIDs(taskIDSet(state)) {
+		if visited[taskID] {
+			continue
+		}
+		if cycle := ic.findCycle(taskID, state, visited, map[domain.TaskID]int{}, nil); cycle != nil {
+			return fmt.Errorf("cyclic dependency detected: cycle: %s", cycle)
 		}
 	}
 	return nil
 }
 
-func (ic *InvariantChecker) hasCycle(
+// isAcyclic reports whether the dependency graph has no cycle, by repeatedly
+// removing tasks no remaining task depends on (Kahn's algorithm) in O(V+E).
+// Dependencies on tasks not in the state cannot be part of a cycle.
+func isAcyclic(state *domain.SystemState) bool {
+	dependents := make(map[domain.TaskID]int, len(state.Tasks))
+	for _, task := range state.Tasks {
+		for depID := range task.Dependencies {
+			if _, exists := state.Tasks[depID]; exists {
+				dependents[depID]++
+			}
+		}
+	}
+
+	free := make([]domain.TaskID, 0, len(state.Tasks))
+	for taskID := range state.Tasks {
+		if dependents[taskID] == 0 {
+			free = append(free, taskID)
+		}
+	}
+	removed := 0
+	for len(free) > 0 {
+		taskID := free[len(free)-1]
+		free = free[:len(free)-1]
+		removed++
+		for depID := range state.Tasks[taskID].Dependencies {
+			if _, exists := state.Tasks[depID]; !exists {
+				continue
+			}
+			dependents[depID]--
+			if dependents[depID] == 0 {
+				free = append(free, depID)
+			}
+		}
+	}
+	return removed == len(state.Tasks)
+}
+
+// taskIDSet returns the IDs of the state's tasks
+func taskIDSet(state *domain.SystemState) map[domain.TaskID]bool {
+	ids := make(map[domain.TaskID]bool, len(state.Tasks))
+	for taskID := range state.Tasks {
+		ids[taskID] = true
+	}
+	return ids
+}
+
+// findCycle searches depth-first from taskID for a dependency cycle and
+// returns it; path holds the tasks leading to taskID and onPath their
+// positions in it
+func (ic *InvariantChecker) findCycle(
 	taskID domain.TaskID,
 	state *domain.SystemState,
 	visited map[domain.TaskID]bool,
-	recStack map[domain.TaskID]bool,
-) bool {
+	onPath map[domain.TaskID]int,
+	path []domain.TaskID,
+) domain.DependencyCycle {
 	visited[taskID] = true
-	recStack[taskID] = true
+	onPath[taskID] = len(path)
+	path = append(path, taskID)
 
 	if task, exists := state.Tasks[taskID]; exists {
-		for depID := range task.Dependencies {
+		for _, depID := range domain.SortedTaskIDs(task.Dependencies) {
+			if start, found := onPath[depID]; found {
+				// Found a back edge (cycle)
+				return append(append(domain.DependencyCycle{}, path[start:]...), depID)
+			}
 			if !visited[depID] {
-				if ic.hasCycle(depID, state, visited, recStack) {
-					return true
+				if cycle := ic.findCycle(depID, state, visited, onPath, path); cycle != nil {
+					return cycle
 				}
-			} else if recStack[depID] {
-				// Found a back edge (cycle)
-				return true
 			}
 		}
 	}
 
-	recStack[taskID] = false
-	return false
+	delete(onPath, taskID)
+	return nil
 }
 
 // AuthenticationRequired: All tasks must have a valid creator
@@ -248,34 +420,141 @@ func (ic *InvariantChecker) checkAuthenticationRequired(state *domain.SystemStat
 			return fmt.Errorf("task %d has no creator", taskID)
 		}
 
-		// Note: In a full implementation, we'd verify the creator exists in the user database
-		// For now, we just check it's not empty
+		// Note: the creator may since have been removed, so only the assignee
+		// is required to be a known user (see AssigneeExists)
+	}
+	return nil
+}
+
+// NoDanglingDependencies: Every dependency refers to an existing task
+func (ic *InvariantChecker) checkNoDanglingDependencies(state *domain.SystemState) error {
+	for taskID, task := range state.Tasks {
+		for depID := range task.Dependencies {
+			if !state.TaskExists(depID) {
+				return fmt.Errorf("task %d depends on non-existent task %d", taskID, depID)
+			}
+		}
+	}
+	return nil
+}
+
+// AssigneeExists: Every active task is assigned to known users, collaborators
+// included (archived tasks are exempt, their owners may have left)
+func (ic *InvariantChecker) checkAssigneeExists(state *domain.SystemState) error {
+	for taskID, task := range state.Tasks {
+		if task.Archived {
+			continue
+		}
+		for _, owner := range task.Owners() {
+			if !state.UserExists(owner) {
+				return fmt.Errorf("task %d is assigned to unknown user %s", taskID, owner)
+			}
+		}
+	}
+	return nil
+}
+
+// SessionConsistency: The current user holds a valid session, and every
+// session belongs to a known user (maps to TLA+ currentUser # NULL =>
+// sessions[currentUser])
+func (ic *InvariantChecker) checkSessionConsistency(state *domain.SystemState) error {
+	if state.CurrentUser != nil {
+		session, exists := state.Sessions[*state.CurrentUser]
+		if !exists || session == nil || !session.IsValidAt(state.Clock) {
+			return fmt.Errorf("current user %s has no valid session", *state.CurrentUser)
+		}
+	}
+	for userID, session := range state.Sessions {
+		if session == nil {
+			return fmt.Errorf("session of user %s is empty", userID)
+		}
+		if !state.UserExists(session.UserID) {
+			return fmt.Errorf("session belongs to unknown user %s", session.UserID)
+		}
 	}
 	return nil
 }
 
+// Liveness warning kinds
+const (
+	WarningStalePending    = "stale_pending"
+	WarningStuckInProgress = "stuck_in_progress"
+	WarningOverdue         = "overdue"
+	WarningDueSoon         = "due_soon"
+	WarningBlockedReady    = "blocked_ready"
+	WarningCriticalPending = "critical_pending"
+	WarningSnoozeElapsed   = "snooze_elapsed"
+)
+
+// LivenessWarning describes a liveness property at risk. TaskID is zero for
+// warnings about the system as a whole.
+type LivenessWarning struct {
+	Kind    string        `json:"kind"`
+	TaskID  domain.TaskID `json:"task_id,omitempty"`
+	Message string        `json:"message"`
+}
+
+func (w LivenessWarning) String() string {
+	return w.Message
+}
+
 // Additional helper to check liveness properties (for monitoring)
 func (ic *InvariantChecker) CheckLivenessProperties(state *domain.SystemState) []string {
 	var warnings []string
+	for _, warning := range ic.LivenessWarnings(state) {
+		warnings = append(warnings, warning.Message)
+	}
+	return warnings
+}
+
+// LivenessWarnings returns the liveness warnings of a state as structured
+// values, for callers that log or filter them by kind or task
+func (ic *InvariantChecker) LivenessWarnings(state *domain.SystemState) []LivenessWarning {
+	var warnings []LivenessWarning
+	// Due dates are stored in UTC; compare against the clock in the same zone
+	now := ic.now(state).UTC()
 
 	// Check for tasks stuck in pending for too long
 	for taskID, task := range state.Tasks {
-		if task.Status == domain.StatusPending {
-			age := state.Clock.Sub(task.CreatedAt)
-			if age.Hours() > 24*7 { // Week old pending tasks
-				warnings = append(warnings,
-					fmt.Sprintf("Task %d has been pending for %v", taskID, age))
+		if task.Status == domain.StatusPending && ic.pendingThreshold > 0 {
+			age := now.Sub(task.CreatedAt)
+			if age > ic.pendingThreshold {
+				warnings = append(warnings, LivenessWarning{WarningStalePending, taskID,
+					fmt.Sprintf("Task %d has been pending for %v", taskID, age)})
+			}
+		}
+
+		// Check for started but abandoned tasks. The state carries no status
+		// history, so the last update stands in for the move to in_progress.
+		if task.Status == domain.StatusInProgress && ic.stuckThreshold > 0 {
+			idle := now.Sub(task.UpdatedAt)
+			if idle > ic.stuckThreshold {
+				warnings = append(warnings, LivenessWarning{WarningStuckInProgress, taskID,
+					fmt.Sprintf("Task %d has been in progress without updates for %v", taskID, idle)})
 			}
 		}
 
 		// Check for overdue tasks
-		if task.DueDate != nil && state.Clock.After(*task.DueDate) {
-			if task.Status != domain.StatusCompleted && task.Status != domain.StatusCancelled {
-				warnings = append(warnings,
-					fmt.Sprintf("Task %d is overdue (due: %v)", taskID, task.DueDate))
+		if task.IsOverdueAt(now) {
+			warnings = append(warnings, LivenessWarning{WarningOverdue, taskID,
+				fmt.Sprintf("Task %d is overdue (due: %s)", taskID, task.DueDate.UTC().Format(time.RFC3339))})
+		}
+
+		// Check for tasks due within the reminder window
+		if ic.dueSoonThreshold > 0 && task.DueDate != nil && !now.After(task.DueDate.UTC()) {
+			if task.Status != domain.StatusCompleted && task.Status != domain.StatusCancelled &&
+				task.DueDate.Sub(now) <= ic.dueSoonThreshold {
+				warnings = append(warnings, LivenessWarning{WarningDueSoon, taskID,
+					fmt.Sprintf("Task %d is due soon (due: %s)", taskID, task.DueDate.UTC().Format(time.RFC3339))})
 			}
 		}
 
+		// Check for snoozed tasks that are due for attention again
+		if task.SnoozeElapsedAt(now) {
+			warnings = append(warnings, LivenessWarning{WarningSnoozeElapsed, taskID,
+				fmt.Sprintf("Task %d was snoozed until %v and has not been updated since", taskID, *task.SnoozedUntil)})
+		}
+
 		// Check for blocked tasks with completed dependencies
 		if task.Status == domain.StatusBlocked {
 			allDepsCompleted := true
@@ -288,22 +567,22 @@ func (ic *InvariantChecker) CheckLivenessProperties(state *domain.SystemState) [
 				}
 			}
 			if allDepsCompleted {
-				warnings = append(warnings,
-					fmt.Sprintf("Task %d is blocked but all dependencies are completed", taskID))
+				warnings = append(warnings, LivenessWarning{WarningBlockedReady, taskID,
+					fmt.Sprintf("Task %d is blocked but all dependencies are completed", taskID)})
 			}
 		}
 	}
 
-	// Check for critical tasks not in progress
+	// Check for tasks of the highest priority (critical by default) not in progress
 	criticalPendingCount := 0
 	for _, task := range state.Tasks {
-		if task.Priority == domain.PriorityCritical && task.Status == domain.StatusPending {
+		if task.Priority.IsHighest() && task.Status == domain.StatusPending {
 			criticalPendingCount++
 		}
 	}
 	if criticalPendingCount > 0 {
-		warnings = append(warnings,
-			fmt.Sprintf("%d critical tasks are still pending", criticalPendingCount))
+		warnings = append(warnings, LivenessWarning{Kind: WarningCriticalPending,
+			Message: fmt.Sprintf("%d critical tasks are still pending", criticalPendingCount)})
 	}
 
 	return warnings
@@ -0,0 +1,47 @@
+package invariants
+
+import (
+	"sort"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+)
+
+// InvariantResult is the outcome of checking one safety invariant; a disabled
+// invariant is not checked and reported as passed
+type InvariantResult struct {
+	Name      string `json:"name"`
+	Passed    bool   `json:"passed"`
+	Disabled  bool   `json:"disabled,omitempty"`
+	Violation string `json:"violation,omitempty"`
+}
+
+// Report summarizes every safety invariant and liveness property of a state
+type Report struct {
+	Healthy          bool              `json:"healthy"`
+	Invariants       []InvariantResult `json:"invariants"`
+	LivenessWarnings []string          `json:"liveness_warnings"`
+}
+
+// Report checks every enabled safety invariant, unlike CheckAllInvariants
+// which stops at the first violation, and collects the liveness warnings in
+// sorted order
+func (ic *InvariantChecker) Report(state *domain.SystemState) Report {
+	report := Report{Healthy: true}
+
+	for _, invariant := range ic.safetyInvariants() {
+		result := InvariantResult{Name: invariant.name, Passed: true}
+		if !ic.enabled[invariant.name] {
+			result.Disabled = true
+		} else if err := invariant.check(state); err != nil {
+			result.Passed = false
+			result.Violation = err.Error()
+			report.Healthy = false
+		}
+		report.Invariants = append(report.Invariants, result)
+	}
+
+	report.LivenessWarnings = append([]string{}, ic.CheckLivenessProperties(state)...)
+	sort.Strings(report.LivenessWarnings)
+
+	return report
+}
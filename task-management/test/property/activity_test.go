@@ -0,0 +1,141 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestActivityFeed verifies task events are recorded in the activity log and
+// that GET /activity filters them and pages through them newest first
+// without gaps or repeats while new entries arrive
+func TestActivityFeed(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	router := mux.NewRouter()
+	router.HandleFunc("/activity", handlers.NewTaskHandler(uc).GetActivity).Methods("GET")
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	loginAs := func(userID domain.UserID) {
+		if current, _ := repo.GetCurrentUser(ctx); current != nil {
+			require.NoError(t, uc.Logout(ctx, *current))
+		}
+		_, err := uc.Authenticate(ctx, userID)
+		require.NoError(t, err)
+	}
+
+	// No publisher is needed for the log
+	loginAs("alice")
+	first, err := uc.CreateTask(ctx, "First", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, first.ID, domain.StatusInProgress))
+	loginAs("bob")
+	second, err := uc.CreateTask(ctx, "Second", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, second.ID, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, second.ID, domain.StatusCompleted))
+
+	all, total, err := uc.GetActivity(ctx, domain.ActivityFilter{}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	require.Len(t, all, 5)
+	for i := 1; i < len(all); i++ {
+		assert.Greater(t, all[i-1].ID, all[i].ID, "newest first")
+	}
+	assert.Equal(t, domain.EventTaskStatusChanged, all[0].Action)
+	assert.Equal(t, domain.UserID("bob"), all[0].Actor)
+	assert.Equal(t, map[string]string{"from": "in_progress", "to": "completed"}, all[0].Data)
+	assert.Equal(t, domain.EventTaskCreated, all[4].Action)
+	assert.Equal(t, first.ID, all[4].TaskID)
+
+	t.Run("Filters", func(t *testing.T) {
+		byBob, total, err := uc.GetActivity(ctx, domain.ActivityFilter{Actor: "bob"}, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		for _, activity := range byBob {
+			assert.Equal(t, second.ID, activity.TaskID)
+		}
+
+		_, total, err = uc.GetActivity(ctx, domain.ActivityFilter{TaskID: first.ID}, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+
+		created, total, err := uc.GetActivity(ctx, domain.ActivityFilter{Action: domain.EventTaskCreated, Actor: "alice"}, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, first.ID, created[0].TaskID)
+
+		page, total, err := uc.GetActivity(ctx, domain.ActivityFilter{}, 3, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 5, total)
+		assert.Equal(t, all[3:], page)
+	})
+
+	get := func(query string) (*httptest.ResponseRecorder, handlers.ActivityResponse) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/activity?"+query, nil))
+		var resp handlers.ActivityResponse
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec, resp
+	}
+
+	t.Run("StablePaging", func(t *testing.T) {
+		rec, page := get("limit=2")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		seen := []domain.ActivityID{page.Activities[0].ID, page.Activities[1].ID}
+		require.NotZero(t, page.NextBefore)
+
+		// A new entry arrives between pages and does not shift them
+		_, err := uc.CreateTask(ctx, "Third", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+		require.NoError(t, err)
+
+		for page.NextBefore != 0 {
+			rec, page = get("limit=2&before=" + fmt.Sprint(page.NextBefore))
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			for _, activity := range page.Activities {
+				seen = append(seen, activity.ID)
+			}
+		}
+		var expected []domain.ActivityID
+		for _, activity := range all {
+			expected = append(expected, activity.ID)
+		}
+		assert.Equal(t, expected, seen)
+
+		rec, page = get("user=bob&action=task.created&task=" + fmt.Sprint(second.ID))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 1, page.Total)
+		assert.Zero(t, page.NextBefore)
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		for _, query := range []string{"limit=-1", "limit=1000", "offset=-1", "task=abc", "before=x"} {
+			rec, _ := get(query)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		}
+		_, _, err := uc.GetActivity(ctx, domain.ActivityFilter{}, 0, usecase.MaxActivityLimit+1)
+		assert.ErrorIs(t, err, domain.ErrInvalidPage)
+	})
+}
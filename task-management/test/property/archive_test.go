@@ -0,0 +1,160 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSoftDelete verifies archived tasks are retained, hidden from listings
+// and restorable, and that only admins can delete permanently
+func TestSoftDelete(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*memory.MemoryRepository, *usecase.TaskUseCase, *invariants.InvariantChecker, *domain.Task) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		checker := invariants.NewInvariantChecker()
+		uc := usecase.NewTaskUseCase(uow, checker)
+
+		users := []domain.User{
+			{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+			{ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+		}
+		for i := range users {
+			require.NoError(t, repo.CreateUser(ctx, &users[i]))
+		}
+
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityMedium, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCancelled))
+
+		return repo, uc, checker, task
+	}
+
+	t.Run("ArchiveHidesTask", func(t *testing.T) {
+		repo, uc, checker, task := setup(t)
+		require.NoError(t, uc.DeleteTask(ctx, task.ID))
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.Archived)
+
+		visible, err := uc.GetTasksByUser(ctx, "alice", "", false, false, usecase.SortByID)
+		require.NoError(t, err)
+		assert.Empty(t, visible)
+
+		all, err := uc.GetTasksByUser(ctx, "alice", "", true, false, usecase.SortByID)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, task.ID, all[0].ID)
+
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.NoError(t, checker.CheckAllInvariants(state))
+
+		assert.Error(t, uc.DeleteTask(ctx, task.ID), "archiving twice should fail")
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		repo, uc, checker, task := setup(t)
+		require.NoError(t, uc.DeleteTask(ctx, task.ID))
+
+		restored, err := uc.RestoreTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.False(t, restored.Archived)
+
+		visible, err := uc.GetTasksByUser(ctx, "alice", "", false, false, usecase.SortByID)
+		require.NoError(t, err)
+		assert.Len(t, visible, 1)
+
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.NoError(t, checker.CheckAllInvariants(state))
+
+		_, err = uc.RestoreTask(ctx, task.ID)
+		assert.Error(t, err, "restoring an active task should fail")
+	})
+
+	t.Run("PurgeRequiresAdmin", func(t *testing.T) {
+		repo, uc, _, task := setup(t)
+		assert.Error(t, uc.PurgeTask(ctx, task.ID))
+
+		require.NoError(t, uc.Logout(ctx, "alice"))
+		_, err := uc.Authenticate(ctx, "root")
+		require.NoError(t, err)
+
+		require.NoError(t, uc.PurgeTask(ctx, task.ID))
+		_, err = repo.GetTask(ctx, task.ID)
+		assert.Error(t, err)
+	})
+}
+
+// TestNoDanglingDependencies verifies deleting a task never leaves other tasks
+// depending on it: the admin hard delete refuses while dependents exist, and
+// the repository strips references to any task it deletes
+func TestNoDanglingDependencies(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	users := []domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+	}
+	for i := range users {
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	dependedUpon, err := uc.CreateTask(ctx, "Depended upon", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	other, err := uc.CreateTask(ctx, "Other", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	dependent, err := uc.CreateTask(ctx, "Dependent", "Desc", domain.PriorityLow, "alice", nil, nil,
+		[]domain.TaskID{dependedUpon.ID, other.ID})
+	require.NoError(t, err)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, dependedUpon.ID, domain.StatusCancelled))
+
+	assertNoDangling := func(t *testing.T) {
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.NoError(t, checker.CheckAllInvariants(state))
+	}
+
+	// The admin hard delete refuses while a task still depends on it
+	_, err = uc.Authenticate(ctx, "root")
+	require.NoError(t, err)
+	assert.Error(t, uc.PurgeTask(ctx, dependedUpon.ID))
+	_, err = repo.GetTask(ctx, dependedUpon.ID)
+	require.NoError(t, err)
+	assertNoDangling(t)
+
+	// Deleting it at the repository level, as the purge does, strips the
+	// reference from the dependent instead of leaving it dangling
+	require.NoError(t, repo.DeleteTask(ctx, dependedUpon.ID))
+	stored, err := repo.GetTask(ctx, dependent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[domain.TaskID]bool{other.ID: true}, stored.Dependencies)
+	assertNoDangling(t)
+
+	// A dangling reference is reported by the invariant
+	stored.Dependencies = map[domain.TaskID]bool{other.ID: true, 99: true}
+	require.NoError(t, repo.UpdateTask(ctx, stored))
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	err = checker.CheckAllInvariants(state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NoDanglingDependencies")
+}
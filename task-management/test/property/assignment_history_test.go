@@ -0,0 +1,101 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestAssignmentHistory verifies every reassignment of a task, single or
+// bulk, is listed oldest first with who made it and when
+func TestAssignmentHistory(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+		SessionDuration: 24 * time.Hour,
+		Clock:           clock,
+	})
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleAdmin, JoinedAt: start,
+	}))
+	for _, id := range []domain.UserID{"bob", "carol"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: start,
+		}))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	other, err := uc.CreateTask(ctx, "Other", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	history, err := uc.GetAssignmentHistory(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, history, "creating a task is not a reassignment")
+
+	clock.Advance(time.Hour)
+	require.NoError(t, uc.ReassignTask(ctx, task.ID, "bob"))
+	require.NoError(t, uc.ReassignTask(ctx, other.ID, "carol"))
+	clock.Advance(time.Hour)
+	require.NoError(t, uc.ReassignTask(ctx, task.ID, "carol"))
+	require.NoError(t, uc.ReassignTask(ctx, task.ID, "carol"))
+	clock.Advance(time.Hour)
+	moved, err := uc.BulkReassign(ctx, "carol", "alice")
+	require.NoError(t, err)
+	require.Equal(t, 2, moved)
+
+	expected := []domain.AssignmentChange{
+		{From: "alice", To: "bob", By: "alice", At: start.Add(time.Hour)},
+		{From: "bob", To: "carol", By: "alice", At: start.Add(2 * time.Hour)},
+		{From: "carol", To: "alice", By: "alice", At: start.Add(3 * time.Hour)},
+	}
+	history, err = uc.GetAssignmentHistory(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, history, "the reassignment to the current assignee is left out")
+
+	history, err = uc.GetAssignmentHistory(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	_, err = uc.GetAssignmentHistory(ctx, 99)
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+	t.Run("Handler", func(t *testing.T) {
+		router := mux.NewRouter()
+		router.HandleFunc("/tasks/{id}/assignment-history", handlers.NewTaskHandler(uc).GetAssignmentHistory).Methods("GET")
+		get := func(id string) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/"+id+"/assignment-history", nil))
+			return rec
+		}
+
+		rec := get("1")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp handlers.AssignmentHistoryResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, task.ID, resp.TaskID)
+		assert.Equal(t, expected, resp.Reassignments)
+
+		assert.Equal(t, http.StatusNotFound, get("99").Code)
+		assert.Equal(t, http.StatusBadRequest, get("abc").Code)
+	})
+}
@@ -0,0 +1,73 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAttachments verifies attachment metadata is recorded against existing
+// tasks and removed when the task is permanently deleted
+func TestAttachments(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	users := []domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+	}
+	for i := range users {
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+
+	_, err := uc.AddAttachment(ctx, 1, "spec.pdf", "https://files.example.com/spec.pdf", 1024)
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+
+	_, err = uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	task, err := uc.CreateTask(ctx, "Title", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = uc.AddAttachment(ctx, task.ID+1, "spec.pdf", "https://files.example.com/spec.pdf", 1024)
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+	_, err = uc.AddAttachment(ctx, task.ID, "", "https://files.example.com/spec.pdf", 1024)
+	assert.Error(t, err)
+
+	first, err := uc.AddAttachment(ctx, task.ID, "spec.pdf", "https://files.example.com/spec.pdf", 1024)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserID("alice"), first.UploadedBy)
+	second, err := uc.AddAttachment(ctx, task.ID, "logo.png", "https://files.example.com/logo.png", 2048)
+	require.NoError(t, err)
+
+	attachments, err := uc.ListAttachments(ctx, task.ID)
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, first.ID, attachments[0].ID)
+	assert.Equal(t, second.ID, attachments[1].ID)
+
+	// Archiving keeps the attachments for RestoreTask
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCancelled))
+	require.NoError(t, uc.DeleteTask(ctx, task.ID))
+	attachments, err = repo.GetAttachmentsByTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Len(t, attachments, 2)
+
+	_, err = uc.Authenticate(ctx, "root")
+	require.NoError(t, err)
+	require.NoError(t, uc.PurgeTask(ctx, task.ID))
+
+	attachments, err = repo.GetAttachmentsByTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, attachments)
+}
@@ -0,0 +1,90 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoleBasedAuthorization verifies admins can manage any task and members
+// only the tasks assigned to them or created by them
+func TestRoleBasedAuthorization(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*memory.MemoryRepository, *usecase.TaskUseCase, *domain.Task) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+		users := []domain.User{
+			{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+			{ID: "bob", Name: "Bob", Email: "bob@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+			{ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+		}
+		for i := range users {
+			require.NoError(t, repo.CreateUser(ctx, &users[i]))
+		}
+
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityMedium, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCancelled))
+		require.NoError(t, uc.Logout(ctx, "alice"))
+
+		return repo, uc, task
+	}
+
+	t.Run("AdminOverridesOwnership", func(t *testing.T) {
+		repo, uc, task := setup(t)
+		_, err := uc.Authenticate(ctx, "root")
+		require.NoError(t, err)
+
+		assert.NoError(t, uc.UpdateTaskDetails(ctx, task.ID, "Renamed", "Desc", nil))
+		assert.NoError(t, uc.ReassignTask(ctx, task.ID, "bob"))
+		assert.NoError(t, uc.DeleteTask(ctx, task.ID))
+
+		archived, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.True(t, archived.Archived)
+	})
+
+	t.Run("CreatorManages", func(t *testing.T) {
+		repo, uc, task := setup(t)
+		_, err := uc.Authenticate(ctx, "root")
+		require.NoError(t, err)
+		require.NoError(t, uc.ReassignTask(ctx, task.ID, "bob"))
+		require.NoError(t, uc.Logout(ctx, "root"))
+		_, err = uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		assert.NoError(t, uc.UpdateTaskDetails(ctx, task.ID, "Renamed", "Desc", nil))
+		assert.NoError(t, uc.DeleteTask(ctx, task.ID))
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("bob"), stored.Assignee)
+		assert.True(t, stored.Archived)
+	})
+
+	t.Run("MemberDenied", func(t *testing.T) {
+		repo, uc, task := setup(t)
+		_, err := uc.Authenticate(ctx, "bob")
+		require.NoError(t, err)
+
+		assert.Error(t, uc.UpdateTaskDetails(ctx, task.ID, "Renamed", "Desc", nil))
+		assert.Error(t, uc.ReassignTask(ctx, task.ID, "bob"))
+		assert.Error(t, uc.DeleteTask(ctx, task.ID))
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("alice"), stored.Assignee)
+		assert.Equal(t, "Task", stored.Title)
+	})
+}
@@ -0,0 +1,111 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestBulkUpdateDependencyReadiness verifies a bulk status change checks
+// dependency readiness as a single one does: starting always, and completing
+// with RequireDependenciesToComplete, rejecting the whole batch otherwise
+func TestBulkUpdateDependencyReadiness(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	type fixture struct {
+		repo *memory.MemoryRepository
+		uc   *usecase.TaskUseCase
+		// reopened was completed and then reopened; started and pending
+		// depend on it, the first in progress, the second pending. ready
+		// has no dependencies and is in progress.
+		reopened, started, pending, ready domain.TaskID
+	}
+	setup := func(t *testing.T, requireDeps bool) fixture {
+		clock := domain.NewFakeClock(start)
+		repo := memory.NewMemoryRepositoryWithClock(clock)
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			SessionDuration:               24 * time.Hour,
+			Clock:                         clock,
+			RequireDependenciesToComplete: requireDeps,
+		})
+
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		create := func(deps ...domain.TaskID) domain.TaskID {
+			task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+			require.NoError(t, err)
+			return task.ID
+		}
+		f := fixture{repo: repo, uc: uc}
+		f.reopened = create()
+		require.NoError(t, uc.UpdateTaskStatus(ctx, f.reopened, domain.StatusInProgress))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, f.reopened, domain.StatusCompleted))
+		f.started = create(f.reopened)
+		f.pending = create(f.reopened)
+		f.ready = create()
+		require.NoError(t, uc.BulkUpdateStatus(ctx, []domain.TaskID{f.started, f.ready}, domain.StatusInProgress))
+
+		// Reopening leaves the dependents where they are
+		_, err = uc.ReopenTask(ctx, f.reopened, "found a regression")
+		require.NoError(t, err)
+		return f
+	}
+	status := func(t *testing.T, f fixture, id domain.TaskID) domain.TaskStatus {
+		task, err := f.repo.GetTask(ctx, id)
+		require.NoError(t, err)
+		return task.Status
+	}
+
+	t.Run("StartRejected", func(t *testing.T) {
+		f := setup(t, false)
+
+		err := f.uc.BulkUpdateStatus(ctx, []domain.TaskID{f.pending}, domain.StatusInProgress)
+		assert.ErrorContains(t, err, "dependency")
+		assert.Equal(t, domain.StatusPending, status(t, f, f.pending))
+	})
+
+	t.Run("CompleteRequired", func(t *testing.T) {
+		f := setup(t, true)
+
+		results, err := f.uc.ValidateBulkTransition(ctx, []domain.TaskID{f.ready, f.started}, domain.StatusCompleted)
+		require.NoError(t, err)
+		assert.NoError(t, results[f.ready])
+		assert.ErrorContains(t, results[f.started], "cannot complete task")
+
+		err = f.uc.BulkUpdateStatus(ctx, []domain.TaskID{f.ready, f.started}, domain.StatusCompleted)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dependency")
+		assert.Equal(t, domain.StatusInProgress, status(t, f, f.ready), "the whole batch is rejected")
+		assert.Equal(t, domain.StatusInProgress, status(t, f, f.started))
+
+		err = f.uc.UpdateTaskStatus(ctx, f.started, domain.StatusCompleted)
+		assert.ErrorContains(t, err, "dependency", "a single change is checked alike")
+
+		// Once the dependency is completed the batch goes through
+		require.NoError(t, f.uc.UpdateTaskStatus(ctx, f.reopened, domain.StatusCompleted))
+		require.NoError(t, f.uc.BulkUpdateStatus(ctx, []domain.TaskID{f.ready, f.started}, domain.StatusCompleted))
+		assert.Equal(t, domain.StatusCompleted, status(t, f, f.started))
+	})
+
+	t.Run("CompleteNotRequired", func(t *testing.T) {
+		f := setup(t, false)
+
+		require.NoError(t, f.uc.BulkUpdateStatus(ctx, []domain.TaskID{f.ready, f.started}, domain.StatusCompleted))
+		assert.Equal(t, domain.StatusCompleted, status(t, f, f.ready))
+		assert.Equal(t, domain.StatusCompleted, status(t, f, f.started))
+	})
+}
@@ -0,0 +1,78 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestBulkReassign verifies every task of one user moves to another with the
+// userTasks index kept consistent, and that only admins may do so
+func TestBulkReassign(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	users := []domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "bob", Name: "Bob", Email: "bob@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+	}
+	for i := range users {
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	first, err := uc.CreateTask(ctx, "First", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	second, err := uc.CreateTask(ctx, "Second", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	bobs, err := uc.CreateTask(ctx, "Bob's", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = uc.BulkReassign(ctx, "alice", "bob")
+	assert.Error(t, err, "members cannot bulk reassign")
+
+	_, err = uc.Authenticate(ctx, "root")
+	require.NoError(t, err)
+
+	_, err = uc.BulkReassign(ctx, "alice", "nobody")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	_, err = uc.BulkReassign(ctx, "nobody", "bob")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	moved, err := uc.BulkReassign(ctx, "alice", "bob@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved)
+
+	aliceTasks, err := repo.GetUserTasks(ctx, "alice")
+	require.NoError(t, err)
+	assert.Empty(t, aliceTasks)
+	bobTasks, err := repo.GetUserTasks(ctx, "bob")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []domain.TaskID{first.ID, second.ID, bobs.ID}, bobTasks)
+	for _, id := range []domain.TaskID{first.ID, second.ID} {
+		task, err := repo.GetTask(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("bob"), task.Assignee)
+	}
+
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, checker.CheckAllInvariants(state))
+
+	moved, err = uc.BulkReassign(ctx, "alice", "bob")
+	require.NoError(t, err)
+	assert.Zero(t, moved, "nothing is left to move")
+}
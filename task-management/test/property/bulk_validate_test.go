@@ -0,0 +1,117 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestValidateBulkTransition verifies a bulk status change can be previewed
+// task by task without changing anything, and that tasks it accepts can then
+// be updated in bulk
+func TestValidateBulkTransition(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+
+	_, err := uc.ValidateBulkTransition(ctx, []domain.TaskID{1}, domain.StatusInProgress)
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+
+	_, err = uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	create := func(assignee domain.UserID, deps ...domain.TaskID) *domain.Task {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, assignee, nil, nil, deps)
+		require.NoError(t, err)
+		return task
+	}
+
+	ready := create("alice")
+	waiting := create("alice", ready.ID)
+	bobs := create("bob")
+	done := create("alice")
+	require.NoError(t, uc.UpdateTaskStatus(ctx, done.ID, domain.StatusCancelled))
+	before, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+
+	ids := []domain.TaskID{ready.ID, waiting.ID, bobs.ID, done.ID, 999}
+	results, err := uc.ValidateBulkTransition(ctx, ids, domain.StatusInProgress)
+	require.NoError(t, err)
+	require.Len(t, results, len(ids))
+	assert.NoError(t, results[ready.ID])
+	assert.ErrorContains(t, results[waiting.ID], "dependency")
+	assert.ErrorContains(t, results[bobs.ID], "does not have access")
+	assert.ErrorIs(t, results[done.ID], domain.ErrInvalidTransition)
+	assert.ErrorIs(t, results[999], domain.ErrTaskNotFound)
+
+	// Nothing changed
+	after, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assertSameJSON(t, before.Tasks, after.Tasks)
+
+	// Blocking needs incomplete dependencies
+	results, err = uc.ValidateBulkTransition(ctx, []domain.TaskID{ready.ID}, domain.StatusBlocked)
+	require.NoError(t, err)
+	assert.ErrorContains(t, results[ready.ID], "no incomplete dependencies")
+
+	_, err = uc.ValidateBulkTransition(ctx, []domain.TaskID{ready.ID}, "started")
+	assert.ErrorIs(t, err, domain.ErrInvalidStatus)
+
+	// The valid tasks go through as a bulk update
+	require.NoError(t, uc.BulkUpdateStatus(ctx, []domain.TaskID{ready.ID}, domain.StatusInProgress))
+
+	t.Run("Handler", func(t *testing.T) {
+		post := func(req handlers.BulkUpdateRequest) *httptest.ResponseRecorder {
+			body, err := json.Marshal(req)
+			require.NoError(t, err)
+			httpReq := httptest.NewRequest(http.MethodPost, "/tasks/bulk-validate", bytes.NewReader(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ValidateBulkTransition(rec, httpReq)
+			return rec
+		}
+
+		rec := post(handlers.BulkUpdateRequest{
+			TaskIDs: []domain.TaskID{ready.ID, done.ID, ready.ID, 999},
+			Status:  domain.StatusCompleted,
+		})
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp handlers.BulkValidateResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.False(t, resp.Valid)
+		require.Len(t, resp.Results, 3, "duplicates are reported once")
+		assert.Equal(t, handlers.BulkValidateResult{TaskID: ready.ID, Valid: true}, resp.Results[0])
+		assert.Equal(t, done.ID, resp.Results[1].TaskID)
+		assert.Equal(t, "invalid_transition", resp.Results[1].Code)
+		assert.NotEmpty(t, resp.Results[1].Error)
+		assert.Equal(t, "task_not_found", resp.Results[2].Code)
+
+		rec = post(handlers.BulkUpdateRequest{TaskIDs: []domain.TaskID{ready.ID}, Status: domain.StatusCompleted})
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.True(t, resp.Valid)
+
+		rec = post(handlers.BulkUpdateRequest{TaskIDs: []domain.TaskID{ready.ID}, Status: "started"})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,70 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCancelTask verifies cancellation and its effect on dependent tasks
+func TestCancelTask(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	t.Run("LeafCancel", func(t *testing.T) {
+		task, err := uc.CreateTask(ctx, "Leaf", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+
+		blocked, err := uc.CancelTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Empty(t, blocked)
+
+		stored, _ := repo.GetTask(ctx, task.ID)
+		assert.Equal(t, domain.StatusCancelled, stored.Status)
+	})
+
+	t.Run("CancelWithDependents", func(t *testing.T) {
+		root, err := uc.CreateTask(ctx, "Root", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		waiting, err := uc.CreateTask(ctx, "Waiting", "Desc", domain.PriorityLow, "alice", nil, nil,
+			[]domain.TaskID{root.ID})
+		require.NoError(t, err)
+		pending, err := uc.CreateTask(ctx, "Pending", "Desc", domain.PriorityLow, "alice", nil, nil,
+			[]domain.TaskID{root.ID})
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, pending.ID, domain.StatusPending))
+
+		blocked, err := uc.CancelTask(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{pending.ID}, blocked)
+
+		for _, id := range []domain.TaskID{waiting.ID, pending.ID} {
+			stored, _ := repo.GetTask(ctx, id)
+			assert.Equal(t, domain.StatusBlocked, stored.Status)
+		}
+
+		// A cancelled dependency never unblocks its dependents
+		count, err := uc.CheckDependencies(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		state, _ := repo.GetSystemState(ctx)
+		assert.NoError(t, checker.CheckAllInvariants(state))
+	})
+}
@@ -0,0 +1,103 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFakeClock verifies time-dependent behavior follows the injected clock
+// rather than the system time
+func TestFakeClock(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	setup := func(t *testing.T) (*domain.FakeClock, *memory.MemoryRepository, *usecase.TaskUseCase) {
+		clock := domain.NewFakeClock(start)
+		repo := memory.NewMemoryRepositoryWithClock(clock)
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			SessionDuration: time.Hour,
+			Clock:           clock,
+		})
+
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+		}))
+		return clock, repo, uc
+	}
+
+	t.Run("SessionExpiry", func(t *testing.T) {
+		clock, _, uc := setup(t)
+
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, start.Add(time.Hour), session.ExpiresAt)
+
+		clock.Advance(59 * time.Minute)
+		_, err = uc.GetSession(ctx, session.Token)
+		require.NoError(t, err)
+		_, err = uc.Authenticate(ctx, "alice")
+		assert.Error(t, err, "an unexpired session blocks a second login")
+
+		clock.Advance(2 * time.Minute)
+		_, err = uc.GetSession(ctx, session.Token)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+		_, err = uc.Authenticate(ctx, "alice")
+		assert.NoError(t, err, "an expired session no longer blocks login")
+	})
+
+	t.Run("Timestamps", func(t *testing.T) {
+		clock, repo, uc := setup(t)
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		task, err := uc.CreateTask(ctx, "Title", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, start, task.CreatedAt)
+		assert.Equal(t, start, task.UpdatedAt)
+
+		clock.Advance(3 * time.Hour)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, start, stored.CreatedAt)
+		assert.Equal(t, start.Add(3*time.Hour), stored.UpdatedAt)
+
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, start.Add(3*time.Hour), state.Clock)
+	})
+
+	t.Run("Overdue", func(t *testing.T) {
+		clock, repo, uc := setup(t)
+		checker := invariants.NewInvariantCheckerWithConfig(invariants.Config{Clock: clock})
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		due := start.Add(time.Hour)
+		_, err = uc.CreateTask(ctx, "Title", "Desc", domain.PriorityLow, "alice", &due, nil, nil)
+		require.NoError(t, err)
+
+		hasOverdue := func() bool {
+			state, err := repo.GetSystemState(ctx)
+			require.NoError(t, err)
+			for _, warning := range checker.LivenessWarnings(state) {
+				if warning.Kind == invariants.WarningOverdue {
+					return true
+				}
+			}
+			return false
+		}
+
+		assert.False(t, hasOverdue())
+		clock.Advance(2 * time.Hour)
+		assert.True(t, hasOverdue())
+	})
+}
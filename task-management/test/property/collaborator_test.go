@@ -0,0 +1,117 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCollaborators verifies shared tasks are indexed and updatable by every owner
+func TestCollaborators(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	for _, userID := range []domain.UserID{"alice", "bob", "charlie"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: userID, Name: string(userID), Email: string(userID) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	login := func(t *testing.T, userID domain.UserID) {
+		if current, _ := repo.GetCurrentUser(ctx); current != nil {
+			require.NoError(t, uc.Logout(ctx, *current))
+		}
+		_, err := uc.Authenticate(ctx, userID)
+		require.NoError(t, err)
+	}
+	assertInvariants := func(t *testing.T) {
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.NoError(t, checker.CheckAllInvariants(state))
+	}
+
+	login(t, "alice")
+	task, err := uc.CreateTask(ctx, "Shared", "Desc", domain.PriorityMedium, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	shared, err := uc.AddCollaborators(ctx, task.ID, []domain.UserID{"bob", "alice", "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"bob"}, shared.Collaborators)
+	assertInvariants(t)
+
+	t.Run("UnknownCollaborator", func(t *testing.T) {
+		_, err := uc.AddCollaborators(ctx, task.ID, []domain.UserID{"mallory"})
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("IndexedForEachOwner", func(t *testing.T) {
+		for _, userID := range []domain.UserID{"alice", "bob"} {
+			taskIDs, err := repo.GetUserTasks(ctx, userID)
+			require.NoError(t, err)
+			assert.Contains(t, taskIDs, task.ID, "task missing from %s's list", userID)
+		}
+		taskIDs, err := repo.GetUserTasks(ctx, "charlie")
+		require.NoError(t, err)
+		assert.NotContains(t, taskIDs, task.ID)
+	})
+
+	t.Run("MultiOwnerStatusUpdates", func(t *testing.T) {
+		login(t, "bob")
+		require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+
+		login(t, "alice")
+		require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusPending))
+
+		login(t, "bob")
+		require.NoError(t, uc.BulkUpdateStatus(ctx, []domain.TaskID{task.ID}, domain.StatusInProgress))
+
+		login(t, "charlie")
+		assert.Error(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCompleted))
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, stored.Status)
+		assertInvariants(t)
+	})
+
+	t.Run("ReassignToCollaborator", func(t *testing.T) {
+		login(t, "alice")
+		require.NoError(t, uc.ReassignTask(ctx, task.ID, "bob"))
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("bob"), stored.Assignee)
+		assert.Empty(t, stored.Collaborators)
+
+		taskIDs, err := repo.GetUserTasks(ctx, "alice")
+		require.NoError(t, err)
+		assert.NotContains(t, taskIDs, task.ID)
+		assertInvariants(t)
+	})
+
+	t.Run("OwnershipInvariantCoversCollaborators", func(t *testing.T) {
+		login(t, "alice")
+		other, err := uc.CreateTask(ctx, "Other", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		_, err = uc.AddCollaborators(ctx, other.ID, []domain.UserID{"charlie"})
+		require.NoError(t, err)
+		assertInvariants(t)
+
+		// Dropping the task from a collaborator's list breaks TaskOwnership
+		require.NoError(t, repo.RemoveUserTask(ctx, "charlie", other.ID))
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		err = checker.CheckAllInvariants(state)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TaskOwnership")
+	})
+}
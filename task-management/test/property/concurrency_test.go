@@ -0,0 +1,157 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestConcurrentUseCases runs many creates and updates concurrently through
+// TaskUseCases sharing one unit of work and verifies no task or update is
+// lost, readers only see committed states and the invariants hold
+func TestConcurrentUseCases(t *testing.T) {
+	const (
+		workers        = 8
+		tasksPerWorker = 15
+	)
+
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := usecase.NewTaskUseCase(uow, checker).Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	// All workers also log time against one shared task
+	shared, err := usecase.NewTaskUseCase(uow, checker).CreateTask(ctx, "Shared", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	var (
+		writers sync.WaitGroup
+		mu      sync.Mutex
+		created = make(map[domain.TaskID]int)
+		errs    []error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+
+	for w := 0; w < workers; w++ {
+		writers.Add(1)
+		go func(worker int) {
+			defer writers.Done()
+			uc := usecase.NewTaskUseCase(uow, checker)
+
+			for i := 0; i < tasksPerWorker; i++ {
+				task, err := uc.CreateTask(ctx, fmt.Sprintf("Task %d-%d", worker, i), "Desc", randomPriority(), "alice", nil, nil, nil)
+				if err != nil {
+					fail(fmt.Errorf("worker %d create %d: %w", worker, i, err))
+					continue
+				}
+				mu.Lock()
+				created[task.ID] = worker
+				mu.Unlock()
+
+				if err := uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress); err != nil {
+					fail(fmt.Errorf("worker %d start task %d: %w", worker, task.ID, err))
+				}
+				if i%2 == 0 {
+					if err := uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCompleted); err != nil {
+						fail(fmt.Errorf("worker %d complete task %d: %w", worker, task.ID, err))
+					}
+				}
+				if _, err := uc.LogTime(ctx, shared.ID, 1); err != nil {
+					fail(fmt.Errorf("worker %d log time: %w", worker, err))
+				}
+			}
+		}(w)
+	}
+
+	// Readers must never see a task count go down or the same task twice
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for r := 0; r < 2; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			uc := usecase.NewTaskUseCase(uow, checker)
+			seen := 0
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				tasks, err := uc.ListTasks(ctx, usecase.SortByID, usecase.ListFilter{IncludeDone: true})
+				if err != nil {
+					fail(fmt.Errorf("list tasks: %w", err))
+					return
+				}
+				ids := make(map[domain.TaskID]bool, len(tasks))
+				for _, task := range tasks {
+					if ids[task.ID] {
+						fail(fmt.Errorf("task %d listed twice", task.ID))
+					}
+					ids[task.ID] = true
+				}
+				if len(tasks) < seen {
+					fail(fmt.Errorf("task count went down from %d to %d", seen, len(tasks)))
+				}
+				seen = len(tasks)
+			}
+		}()
+	}
+
+	writers.Wait()
+	close(stop)
+	readers.Wait()
+	require.Empty(t, errs)
+
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, checker.CheckAllInvariants(state))
+
+	// No task lost: every create got its own ID and is stored
+	require.Len(t, created, workers*tasksPerWorker)
+	assert.Len(t, state.Tasks, workers*tasksPerWorker+1)
+	assert.Equal(t, domain.TaskID(workers*tasksPerWorker+2), state.NextTaskID)
+	assert.ElementsMatch(t, keys(state.Tasks), append(keys(created), shared.ID))
+
+	// No update lost either
+	completed := 0
+	for id := range created {
+		switch state.Tasks[id].Status {
+		case domain.StatusCompleted:
+			completed++
+		case domain.StatusInProgress:
+		default:
+			t.Errorf("task %d has status %s", id, state.Tasks[id].Status)
+		}
+	}
+	assert.Equal(t, workers*(tasksPerWorker+1)/2, completed)
+	assert.Equal(t, float64(workers*tasksPerWorker), state.Tasks[shared.ID].ActualHours)
+}
+
+func keys[V any](m map[domain.TaskID]V) []domain.TaskID {
+	ids := make([]domain.TaskID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	return ids
+}
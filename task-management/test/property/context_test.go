@@ -0,0 +1,55 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContextCancellation verifies cancelled requests stop without changing state
+func TestContextCancellation(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	t.Run("CancelledUseCase", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := uc.CreateTask(cancelled, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, domain.ErrUnauthenticated)
+
+		tasks, err := repo.GetAllTasks(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("BeginWaitsWithDeadline", func(t *testing.T) {
+		// Hold the only transaction slot
+		require.NoError(t, uow.Begin(ctx))
+		defer uow.Rollback()
+
+		other := memory.NewMemoryUnitOfWork(repo)
+		require.NoError(t, other.Begin(ctx), "units of work are independent")
+		require.NoError(t, other.Rollback())
+
+		timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, uow.Begin(timeout), context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,100 @@
+package property
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/middleware"
+)
+
+// TestCORS verifies preflight requests are answered for allowed origins only
+// and that allowed origins are echoed on regular responses
+func TestCORS(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/tasks/{id}/status", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods("PUT")
+
+	// serve sends a request through the CORS middleware wrapping the router
+	serve := func(config middleware.CORSConfig, method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/tasks/1/status", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rec := httptest.NewRecorder()
+		middleware.CORS(config)(router).ServeHTTP(rec, req)
+		return rec
+	}
+	config := middleware.CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "PUT"},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+		MaxAge:         time.Minute,
+	}
+	preflight := map[string]string{
+		"Access-Control-Request-Method":  "PUT",
+		"Access-Control-Request-Headers": "content-type, authorization",
+	}
+
+	t.Run("PreflightAllowed", func(t *testing.T) {
+		rec := serve(config, http.MethodOptions, "https://app.example.com", preflight)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, PUT", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, Authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "60", rec.Header().Get("Access-Control-Max-Age"))
+		assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+	})
+
+	t.Run("PreflightUnknownOrigin", func(t *testing.T) {
+		rec := serve(config, http.MethodOptions, "https://evil.example.com", preflight)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("PreflightMethodNotAllowed", func(t *testing.T) {
+		rec := serve(config, http.MethodOptions, "https://app.example.com",
+			map[string]string{"Access-Control-Request-Method": "DELETE"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("PreflightHeaderNotAllowed", func(t *testing.T) {
+		rec := serve(config, http.MethodOptions, "https://app.example.com", map[string]string{
+			"Access-Control-Request-Method":  "PUT",
+			"Access-Control-Request-Headers": "X-Debug",
+		})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("OriginEchoed", func(t *testing.T) {
+		rec := serve(config, http.MethodPut, "https://app.example.com", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("UnknownOriginNotEchoed", func(t *testing.T) {
+		rec := serve(config, http.MethodPut, "https://evil.example.com", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("DenyByDefault", func(t *testing.T) {
+		rec := serve(middleware.CORSConfig{}, http.MethodOptions, "https://app.example.com", preflight)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("SameOriginUntouched", func(t *testing.T) {
+		rec := serve(config, http.MethodPut, "", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Vary"))
+	})
+}
@@ -0,0 +1,75 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestCreateTaskStatusReason verifies POST /tasks explains why a task with
+// incomplete dependencies was created blocked
+func TestCreateTaskStatusReason(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(deps ...domain.TaskID) (handlers.CreateTaskResponse, map[string]json.RawMessage) {
+		body, err := json.Marshal(handlers.CreateTaskRequest{
+			Title: "Task", Description: "Desc", Priority: domain.PriorityLow, Assignee: "alice", Dependencies: deps,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.CreateTask(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		var resp handlers.CreateTaskResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		var fields map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &fields))
+		return resp, fields
+	}
+
+	first, fields := create()
+	assert.Equal(t, domain.StatusPending, first.Status)
+	assert.Nil(t, first.StatusReason)
+	assert.NotContains(t, fields, "status_reason")
+
+	second, _ := create()
+	done, _ := create()
+	require.NoError(t, uc.UpdateTaskStatus(ctx, done.ID, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, done.ID, domain.StatusCompleted))
+
+	blocked, _ := create(second.ID, done.ID, first.ID)
+	assert.Equal(t, domain.StatusBlocked, blocked.Status)
+	require.NotNil(t, blocked.StatusReason)
+	assert.NotEmpty(t, blocked.StatusReason.Message)
+	assert.Equal(t, []domain.TaskID{first.ID, second.ID}, blocked.StatusReason.WaitingOn,
+		"only the incomplete dependencies, in ID order")
+
+	ready, _ := create(done.ID)
+	assert.Equal(t, domain.StatusPending, ready.Status)
+	assert.Nil(t, ready.StatusReason)
+}
@@ -0,0 +1,105 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestTasksCreatedBetween verifies the creation range includes its start and
+// excludes its end, so back-to-back periods partition the tasks
+func TestTasksCreatedBetween(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+		SessionDuration: 30 * 24 * time.Hour,
+		Clock:           clock,
+	})
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	createAt := func(at time.Time) domain.TaskID {
+		clock.Set(at)
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		return task.ID
+	}
+	week := 7 * 24 * time.Hour
+	first := createAt(start)
+	justBefore := createAt(start.Add(week - time.Second))
+	boundary := createAt(start.Add(week))
+	later := createAt(start.Add(week + time.Hour))
+
+	between := func(t *testing.T, from, to time.Time) []domain.TaskID {
+		tasks, err := uc.GetTasksCreatedBetween(ctx, from, to)
+		require.NoError(t, err)
+		return taskIDs(tasks)
+	}
+
+	t.Run("Boundaries", func(t *testing.T) {
+		assert.Equal(t, []domain.TaskID{first, justBefore}, between(t, start, start.Add(week)),
+			"the start is inclusive and the end exclusive")
+		assert.Equal(t, []domain.TaskID{boundary, later}, between(t, start.Add(week), start.Add(2*week)))
+		assert.Empty(t, between(t, start, start), "an empty range holds nothing")
+		assert.Equal(t, []domain.TaskID{first}, between(t, start, start.Add(time.Nanosecond)))
+	})
+
+	t.Run("IncludesDone", func(t *testing.T) {
+		require.NoError(t, uc.UpdateTaskStatus(ctx, first, domain.StatusCancelled))
+		assert.Equal(t, []domain.TaskID{first, justBefore}, between(t, start, start.Add(week)))
+	})
+
+	t.Run("InvertedRange", func(t *testing.T) {
+		_, err := uc.GetTasksCreatedBetween(ctx, start.Add(week), start)
+		assert.Error(t, err)
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		handler := handlers.NewTaskHandler(uc)
+		list := func(query string) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			handler.ListTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks"+query, nil))
+			return rec
+		}
+		listed := func(t *testing.T, query string) []domain.TaskID {
+			rec := list(query)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			var tasks []handlers.TaskResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+			ids := make([]domain.TaskID, len(tasks))
+			for i, task := range tasks {
+				ids[i] = task.ID
+			}
+			return ids
+		}
+
+		assert.Equal(t, []domain.TaskID{justBefore}, listed(t, "?created_to=2030-01-08T09:00:00Z"),
+			"the cancelled task stays out without include_done")
+		assert.Equal(t, []domain.TaskID{first, justBefore}, listed(t, "?created_to=2030-01-08T09:00:00Z&include_done=true"))
+		assert.Equal(t, []domain.TaskID{boundary, later}, listed(t, "?created_from=2030-01-08T09:00:00Z"))
+		assert.Equal(t, []domain.TaskID{later}, listed(t, "?created_from=2030-01-08T09:00:01Z&created_to=2030-02-01T00:00:00Z"))
+
+		assert.Equal(t, http.StatusBadRequest, list("?created_from=yesterday").Code)
+		assert.Equal(t, http.StatusBadRequest, list("?created_to=2030-01-08").Code)
+		assert.Equal(t, http.StatusBadRequest, list("?created_from=2030-01-08T09:00:00Z&created_to=2030-01-01T09:00:00Z").Code)
+	})
+}
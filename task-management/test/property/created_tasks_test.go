@@ -0,0 +1,93 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestTasksByCreator verifies GetTasksByCreator lists the tasks a user filed
+// regardless of who they are assigned to, unlike GetTasksByUser
+func TestTasksByCreator(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	router := mux.NewRouter()
+	router.HandleFunc("/users/{id}/created-tasks", handlers.NewTaskHandler(uc).GetTasksByCreator).Methods("GET")
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	loginAs := func(userID domain.UserID) {
+		if current, _ := repo.GetCurrentUser(ctx); current != nil {
+			require.NoError(t, uc.Logout(ctx, *current))
+		}
+		_, err := uc.Authenticate(ctx, userID)
+		require.NoError(t, err)
+	}
+	create := func(assignee domain.UserID) *domain.Task {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityMedium, assignee, nil, nil, nil)
+		require.NoError(t, err)
+		return task
+	}
+
+	loginAs("alice")
+	forBob := create("bob")
+	forSelf := create("alice")
+	archived := create("alice")
+	require.NoError(t, uc.UpdateTaskStatus(ctx, archived.ID, domain.StatusCancelled))
+	require.NoError(t, uc.DeleteTask(ctx, archived.ID))
+	loginAs("bob")
+	byBob := create("alice")
+
+	created, err := uc.GetTasksByCreator(ctx, "alice", "", false, false, usecase.SortByID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{forBob.ID, forSelf.ID}, taskIDs(created))
+
+	created, err = uc.GetTasksByCreator(ctx, "alice", "", true, false, usecase.SortByID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{forBob.ID, forSelf.ID, archived.ID}, taskIDs(created))
+
+	created, err = uc.GetTasksByCreator(ctx, "alice", domain.StatusCancelled, true, false, usecase.SortByID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{archived.ID}, taskIDs(created))
+
+	// Assignment is a different view
+	assigned, err := uc.GetTasksByUser(ctx, "alice", "", false, false, usecase.SortByID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{forSelf.ID, byBob.ID}, taskIDs(assigned))
+
+	t.Run("Handler", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/bob/created-tasks", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var tasks []handlers.TaskResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+		require.Len(t, tasks, 1)
+		assert.Equal(t, byBob.ID, tasks[0].ID)
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/nobody/created-tasks", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/alice/created-tasks?sort=bogus", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
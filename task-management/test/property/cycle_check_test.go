@@ -0,0 +1,162 @@
+package property
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// dependencyState returns a state of n tasks where each task depends on the
+// tasks deps returns for it
+func dependencyState(n int, deps func(id domain.TaskID) []domain.TaskID) *domain.SystemState {
+	state := domain.NewSystemState()
+	for i := 1; i <= n; i++ {
+		id := domain.TaskID(i)
+		task := &domain.Task{ID: id, Dependencies: make(map[domain.TaskID]bool)}
+		for _, depID := range deps(id) {
+			task.Dependencies[depID] = true
+		}
+		state.Tasks[id] = task
+	}
+	state.NextTaskID = domain.TaskID(n + 1)
+	return state
+}
+
+// chainState makes every task depend on the one before it, the worst case
+// for searching from every task in turn
+func chainState(n int) *domain.SystemState {
+	return dependencyState(n, func(id domain.TaskID) []domain.TaskID {
+		if id == 1 {
+			return nil
+		}
+		return []domain.TaskID{id - 1}
+	})
+}
+
+// randomDAGState gives each task up to fanout dependencies on earlier tasks
+func randomDAGState(rng *rand.Rand, n, fanout int) *domain.SystemState {
+	return dependencyState(n, func(id domain.TaskID) []domain.TaskID {
+		var deps []domain.TaskID
+		for i := 0; i < fanout && id > 1; i++ {
+			deps = append(deps, domain.TaskID(rng.Intn(int(id)-1)+1))
+		}
+		return deps
+	})
+}
+
+// hasCycleFromEveryTask is the reference check: a separate depth-first
+// search from every task, O(V·(V+E))
+func hasCycleFromEveryTask(state *domain.SystemState) bool {
+	var visit func(id domain.TaskID, visited, onStack map[domain.TaskID]bool) bool
+	visit = func(id domain.TaskID, visited, onStack map[domain.TaskID]bool) bool {
+		visited[id] = true
+		onStack[id] = true
+		if task, exists := state.Tasks[id]; exists {
+			for depID := range task.Dependencies {
+				if onStack[depID] || (!visited[depID] && visit(depID, visited, onStack)) {
+					return true
+				}
+			}
+		}
+		onStack[id] = false
+		return false
+	}
+	for id := range state.Tasks {
+		if visit(id, map[domain.TaskID]bool{}, map[domain.TaskID]bool{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestCycleCheckLargeGraphs verifies the cycle check agrees with a search
+// from every task on graphs of a few hundred tasks, and still names the cycle
+func TestCycleCheckLargeGraphs(t *testing.T) {
+	checker := invariants.NewInvariantChecker()
+	rng := rand.New(rand.NewSource(42))
+
+	t.Run("Acyclic", func(t *testing.T) {
+		assert.NoError(t, checker.CheckNoCyclicDependencies(chainState(500)))
+		for i := 0; i < 20; i++ {
+			state := randomDAGState(rng, 300, 3)
+			require.False(t, hasCycleFromEveryTask(state))
+			assert.NoError(t, checker.CheckNoCyclicDependencies(state))
+		}
+	})
+
+	t.Run("RandomEdges", func(t *testing.T) {
+		for i := 0; i < 50; i++ {
+			state := randomDAGState(rng, 200, 2)
+			// One edge that may point forward, closing a cycle or not
+			from := domain.TaskID(rng.Intn(200) + 1)
+			state.Tasks[from].Dependencies[domain.TaskID(rng.Intn(200)+1)] = true
+
+			err := checker.CheckNoCyclicDependencies(state)
+			if hasCycleFromEveryTask(state) {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+	})
+
+	t.Run("ReportsCycle", func(t *testing.T) {
+		state := chainState(400)
+		state.Tasks[100].Dependencies[300] = true
+		err := checker.CheckNoCyclicDependencies(state)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cycle: 100 → 300 → 299")
+		assert.Contains(t, err.Error(), "101 → 100")
+	})
+
+	t.Run("SelfDependency", func(t *testing.T) {
+		state := chainState(10)
+		state.Tasks[7].Dependencies[7] = true
+		err := checker.CheckNoCyclicDependencies(state)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cycle: 7 → 7")
+	})
+
+	t.Run("MissingDependency", func(t *testing.T) {
+		state := chainState(10)
+		state.Tasks[5].Dependencies[99] = true
+		assert.NoError(t, checker.CheckNoCyclicDependencies(state),
+			"a dependency on an unknown task cannot close a cycle")
+	})
+}
+
+// BenchmarkCycleCheck compares the cycle check with a search from every task
+// on chains and random graphs of a few hundred tasks
+func BenchmarkCycleCheck(b *testing.B) {
+	checker := invariants.NewInvariantChecker()
+	graphs := []struct {
+		name  string
+		state *domain.SystemState
+	}{
+		{"Chain300", chainState(300)},
+		{"RandomDAG300", randomDAGState(rand.New(rand.NewSource(1)), 300, 3)},
+	}
+
+	for _, graph := range graphs {
+		b.Run(fmt.Sprintf("%s/Check", graph.name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := checker.CheckNoCyclicDependencies(graph.state); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%s/FromEveryTask", graph.name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if hasCycleFromEveryTask(graph.state) {
+					b.Fatal("unexpected cycle")
+				}
+			}
+		})
+	}
+}
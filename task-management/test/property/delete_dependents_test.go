@@ -0,0 +1,143 @@
+package property
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestDeleteTaskWithDependents verifies deleting a task others depend on is
+// refused by default, and with DetachDependentsOnDelete archives it, removes
+// it from its dependents and unblocks those left with completed dependencies
+func TestDeleteTaskWithDependents(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	type fixture struct {
+		repo      *memory.MemoryRepository
+		uc        *usecase.TaskUseCase
+		publisher *recordingPublisher
+		// cancelled is depended upon by ready, orphaned and waiting, all
+		// blocked; ready also depends on a completed task and waiting on a
+		// pending one
+		cancelled, ready, orphaned, waiting domain.TaskID
+	}
+	setup := func(t *testing.T, detach bool) fixture {
+		clock := domain.NewFakeClock(start)
+		repo := memory.NewMemoryRepositoryWithClock(clock)
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			SessionDuration:          24 * time.Hour,
+			Clock:                    clock,
+			DetachDependentsOnDelete: detach,
+		})
+		publisher := &recordingPublisher{}
+		uc.SetEventPublisher(publisher)
+
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleAdmin, JoinedAt: start,
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		create := func(deps ...domain.TaskID) domain.TaskID {
+			task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+			require.NoError(t, err)
+			return task.ID
+		}
+		f := fixture{repo: repo, uc: uc, publisher: publisher}
+		f.cancelled = create()
+		done, pending := create(), create()
+		f.ready = create(f.cancelled, done)
+		f.orphaned = create(f.cancelled)
+		f.waiting = create(f.cancelled, pending)
+
+		require.NoError(t, uc.UpdateTaskStatus(ctx, done, domain.StatusInProgress))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, done, domain.StatusCompleted))
+		_, err = uc.CancelTask(ctx, f.cancelled)
+		require.NoError(t, err)
+		for _, id := range []domain.TaskID{f.ready, f.orphaned, f.waiting} {
+			task, err := repo.GetTask(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, domain.StatusBlocked, task.Status)
+		}
+		return f
+	}
+	get := func(t *testing.T, f fixture, id domain.TaskID) *domain.Task {
+		task, err := f.repo.GetTask(ctx, id)
+		require.NoError(t, err)
+		return task
+	}
+
+	t.Run("Refuse", func(t *testing.T) {
+		f := setup(t, false)
+
+		deletable, reason, err := f.uc.CanDeleteTask(ctx, f.cancelled)
+		require.NoError(t, err)
+		assert.False(t, deletable)
+		assert.Contains(t, reason, "3 tasks depend on it")
+
+		err = f.uc.DeleteTask(ctx, f.cancelled)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "3 tasks depend on it")
+
+		assert.False(t, get(t, f, f.cancelled).Archived)
+		for _, id := range []domain.TaskID{f.ready, f.orphaned, f.waiting} {
+			dependent := get(t, f, id)
+			assert.True(t, dependent.Dependencies[f.cancelled], "task %d keeps the dependency", id)
+			assert.Equal(t, domain.StatusBlocked, dependent.Status)
+		}
+	})
+
+	t.Run("Detach", func(t *testing.T) {
+		f := setup(t, true)
+
+		deletable, _, err := f.uc.CanDeleteTask(ctx, f.cancelled)
+		require.NoError(t, err)
+		assert.True(t, deletable)
+
+		require.NoError(t, f.uc.DeleteTask(ctx, f.cancelled))
+		assert.True(t, get(t, f, f.cancelled).Archived)
+
+		statuses := make(map[domain.TaskID]domain.TaskStatus)
+		for _, id := range []domain.TaskID{f.ready, f.orphaned, f.waiting} {
+			dependent := get(t, f, id)
+			assert.False(t, dependent.Dependencies[f.cancelled], "task %d is detached", id)
+			statuses[id] = dependent.Status
+		}
+		assert.Equal(t, map[domain.TaskID]domain.TaskStatus{
+			f.ready:    domain.StatusPending,
+			f.orphaned: domain.StatusPending,
+			f.waiting:  domain.StatusBlocked,
+		}, statuses, "only dependents with every remaining dependency completed are unblocked")
+		assert.Len(t, get(t, f, f.ready).Dependencies, 1)
+		assert.Len(t, get(t, f, f.waiting).Dependencies, 1)
+
+		unblocked := make(map[domain.TaskID]string)
+		for _, event := range f.publisher.ofType(domain.EventTaskUnblocked) {
+			unblocked[event.TaskID] = event.Data["dependency"]
+		}
+		deleted := strconv.Itoa(int(f.cancelled))
+		assert.Equal(t, map[domain.TaskID]string{
+			f.ready:    deleted,
+			f.orphaned: deleted,
+		}, unblocked)
+	})
+
+	t.Run("PurgeStillRefused", func(t *testing.T) {
+		f := setup(t, true)
+
+		err := f.uc.PurgeTask(ctx, f.cancelled)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "3 tasks depend on it")
+	})
+}
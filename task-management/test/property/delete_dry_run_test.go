@@ -0,0 +1,145 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestDeleteDryRun verifies CanDeleteTask and DELETE /tasks/{id}?dry_run=true
+// report the same verdict DeleteTask would reach without deleting anything
+func TestDeleteDryRun(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	router := mux.NewRouter()
+	router.HandleFunc("/tasks/{id}", handlers.NewTaskHandler(uc).DeleteTask).Methods("DELETE")
+
+	users := []domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "bob", Name: "Bob", Email: "bob@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+	}
+	for i := range users {
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	pending, err := uc.CreateTask(ctx, "Pending", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	done, err := uc.CreateTask(ctx, "Done", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, done.ID, domain.StatusCancelled))
+	required, err := uc.CreateTask(ctx, "Required", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	_, err = uc.CreateTask(ctx, "Dependent", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{required.ID})
+	require.NoError(t, err)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, required.ID, domain.StatusCancelled))
+
+	dryRun := func(id domain.TaskID, query string) (*httptest.ResponseRecorder, handlers.DeleteCheckResponse) {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/tasks/%d?dry_run=true%s", id, query), nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		var resp handlers.DeleteCheckResponse
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec, resp
+	}
+
+	t.Run("Blocked", func(t *testing.T) {
+		ok, reason, err := uc.CanDeleteTask(ctx, pending.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, "can only delete completed or cancelled tasks", reason)
+
+		ok, reason, err = uc.CanDeleteTask(ctx, required.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Contains(t, reason, "1 tasks depend on it")
+
+		// The verdict matches the error DeleteTask returns
+		err = uc.DeleteTask(ctx, required.ID)
+		require.Error(t, err)
+		assert.Equal(t, reason, err.Error())
+
+		rec, resp := dryRun(pending.ID, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, pending.ID, resp.TaskID)
+		assert.False(t, resp.Deletable)
+		assert.Equal(t, "can only delete completed or cancelled tasks", resp.Reason)
+	})
+
+	t.Run("DeletableButNotDeleted", func(t *testing.T) {
+		ok, reason, err := uc.CanDeleteTask(ctx, done.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, reason)
+
+		rec, resp := dryRun(done.ID, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.True(t, resp.Deletable)
+		assert.NotContains(t, rec.Body.String(), "reason")
+
+		stored, err := repo.GetTask(ctx, done.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.Archived)
+	})
+
+	t.Run("HardDeleteNeedsAdmin", func(t *testing.T) {
+		ok, reason, err := uc.CanPurgeTask(ctx, done.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Contains(t, reason, "only admins")
+
+		_, resp := dryRun(done.ID, "&hard=true")
+		assert.False(t, resp.Deletable)
+		assert.Contains(t, resp.Reason, "only admins")
+	})
+
+	t.Run("AlreadyArchived", func(t *testing.T) {
+		archived, err := uc.CreateTask(ctx, "Archived", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, archived.ID, domain.StatusCancelled))
+		require.NoError(t, uc.DeleteTask(ctx, archived.ID))
+
+		ok, reason, err := uc.CanDeleteTask(ctx, archived.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Contains(t, reason, "already archived")
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		require.NoError(t, uc.Logout(ctx, "alice"))
+		_, err := uc.Authenticate(ctx, "bob")
+		require.NoError(t, err)
+
+		ok, reason, err := uc.CanDeleteTask(ctx, done.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Contains(t, reason, "does not have permission")
+	})
+
+	t.Run("UnknownTask", func(t *testing.T) {
+		_, _, err := uc.CanDeleteTask(ctx, 999)
+		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+		rec, _ := dryRun(999, "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
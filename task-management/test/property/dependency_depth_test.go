@@ -0,0 +1,100 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestDependencyDepthLimit verifies CreateTask and AddDependency reject
+// dependency chains longer than MaxDependencyDepth, and that the depth is
+// unlimited by default
+func TestDependencyDepthLimit(t *testing.T) {
+	const limit = 3
+	ctx := context.Background()
+	setup := func(t *testing.T, maxDepth int) *usecase.TaskUseCase {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		config := usecase.DefaultConfig()
+		config.MaxDependencyDepth = maxDepth
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), config)
+
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		return uc
+	}
+	create := func(t *testing.T, uc *usecase.TaskUseCase, deps ...domain.TaskID) (*domain.Task, error) {
+		return uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+	}
+	// chain creates tasks 1..n where each depends on the previous one, a
+	// chain n-1 dependencies deep
+	chain := func(t *testing.T, uc *usecase.TaskUseCase, n int) []*domain.Task {
+		tasks := []*domain.Task{}
+		for i := 0; i < n; i++ {
+			var deps []domain.TaskID
+			if i > 0 {
+				deps = []domain.TaskID{tasks[i-1].ID}
+			}
+			task, err := create(t, uc, deps...)
+			require.NoError(t, err)
+			tasks = append(tasks, task)
+		}
+		return tasks
+	}
+
+	t.Run("CreateTask", func(t *testing.T) {
+		uc := setup(t, limit)
+		tasks := chain(t, uc, limit+1)
+
+		_, err := create(t, uc, tasks[limit].ID)
+		assert.ErrorIs(t, err, domain.ErrDependencyTooDeep)
+
+		// A shorter branch off the same chain is fine
+		_, err = create(t, uc, tasks[limit-1].ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("AddDependency", func(t *testing.T) {
+		uc := setup(t, limit)
+		tasks := chain(t, uc, limit+1)
+		other, err := create(t, uc)
+		require.NoError(t, err)
+
+		// Putting a task below the chain deepens it past the limit
+		_, err = uc.AddDependency(ctx, tasks[0].ID, other.ID)
+		assert.ErrorIs(t, err, domain.ErrDependencyTooDeep)
+
+		// So does putting one on top
+		top, err := create(t, uc)
+		require.NoError(t, err)
+		_, err = uc.AddDependency(ctx, top.ID, tasks[limit].ID)
+		assert.ErrorIs(t, err, domain.ErrDependencyTooDeep)
+
+		// Joining two short chains in the middle is measured end to end
+		upper, err := create(t, uc)
+		require.NoError(t, err)
+		_, err = create(t, uc, upper.ID)
+		require.NoError(t, err)
+		lower := chain(t, uc, limit)
+		_, err = uc.AddDependency(ctx, upper.ID, lower[len(lower)-1].ID)
+		assert.ErrorIs(t, err, domain.ErrDependencyTooDeep)
+		_, err = uc.AddDependency(ctx, upper.ID, lower[len(lower)-2].ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("UnlimitedByDefault", func(t *testing.T) {
+		uc := setup(t, 0)
+		chain(t, uc, 50)
+	})
+}
@@ -0,0 +1,484 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEditDependencies verifies dependencies can be added and removed after
+// creation, with validation, cycle detection and blocked status recomputed
+func TestEditDependencies(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*memory.MemoryRepository, *usecase.TaskUseCase, *invariants.InvariantChecker) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		checker := invariants.NewInvariantChecker()
+		uc := usecase.NewTaskUseCase(uow, checker)
+
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+			require.NoError(t, err)
+		}
+		return repo, uc, checker
+	}
+
+	t.Run("AddIncompleteBlocks", func(t *testing.T) {
+		repo, uc, checker := setup(t)
+
+		task, err := uc.AddDependency(ctx, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusBlocked, task.Status)
+		assert.True(t, task.Dependencies[1])
+
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.NoError(t, checker.CheckAllInvariants(state))
+	})
+
+	t.Run("AddCompletedKeepsStatus", func(t *testing.T) {
+		_, uc, _ := setup(t)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, 1, domain.StatusInProgress))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, 1, domain.StatusCompleted))
+
+		task, err := uc.AddDependency(ctx, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusPending, task.Status)
+	})
+
+	t.Run("RemoveUnblocks", func(t *testing.T) {
+		repo, uc, _ := setup(t)
+		_, err := uc.AddDependency(ctx, 3, 1)
+		require.NoError(t, err)
+		_, err = uc.AddDependency(ctx, 3, 2)
+		require.NoError(t, err)
+
+		task, err := uc.RemoveDependency(ctx, 3, 1)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusBlocked, task.Status, "task 2 is still incomplete")
+
+		task, err = uc.RemoveDependency(ctx, 3, 2)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusPending, task.Status)
+
+		stored, err := repo.GetTask(ctx, 3)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Dependencies)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		repo, uc, _ := setup(t)
+		_, err := uc.AddDependency(ctx, 2, 1)
+		require.NoError(t, err)
+		_, err = uc.AddDependency(ctx, 3, 2)
+		require.NoError(t, err)
+
+		_, err = uc.AddDependency(ctx, 1, 1)
+		assert.ErrorIs(t, err, domain.ErrInvalidDependency, "self-dependency")
+
+		_, err = uc.AddDependency(ctx, 1, 99)
+		assert.ErrorIs(t, err, domain.ErrInvalidDependency, "missing dependency")
+
+		_, err = uc.AddDependency(ctx, 1, 3)
+		assert.ErrorIs(t, err, domain.ErrCyclicDependency)
+
+		_, err = uc.RemoveDependency(ctx, 1, 2)
+		assert.ErrorIs(t, err, domain.ErrInvalidDependency, "not a dependency")
+
+		stored, err := repo.GetTask(ctx, 1)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Dependencies)
+		assert.Equal(t, domain.StatusPending, stored.Status)
+	})
+}
+
+// TestTaskRelations verifies both directions of the dependency relation are
+// reported, with the reverse edges computed from the stored dependencies
+func TestTaskRelations(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(deps ...domain.TaskID) *domain.Task {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+		require.NoError(t, err)
+		return task
+	}
+	first := create()
+	second := create()
+	middle := create(second.ID, first.ID)
+	last := create(middle.ID)
+	other := create(middle.ID)
+
+	relations, err := uc.GetTaskRelations(ctx, middle.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{first.ID, second.ID}, relations.BlockedBy)
+	assert.Equal(t, []domain.TaskID{last.ID, other.ID}, relations.Blocks)
+
+	relations, err = uc.GetTaskRelations(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Empty(t, relations.BlockedBy)
+	assert.Equal(t, []domain.TaskID{middle.ID}, relations.Blocks)
+
+	_, err = uc.GetTaskRelations(ctx, 99)
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+}
+
+// TestCheckDependenciesReport verifies the dependency check lists the tasks
+// it unblocked and, for the tasks still blocked, what they are waiting on
+func TestCheckDependenciesReport(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(deps ...domain.TaskID) *domain.Task {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+		require.NoError(t, err)
+		return task
+	}
+	first := create()
+	second := create()
+	third := create()
+	waitingOnAll := create(third.ID, first.ID, second.ID)
+	waitingOnFirst := create(first.ID)
+
+	// Complete the first dependency behind the use case's back so only the
+	// dependency check can unblock its dependents
+	stored, err := repo.GetTask(ctx, first.ID)
+	require.NoError(t, err)
+	stored.Status = domain.StatusCompleted
+	require.NoError(t, repo.UpdateTask(ctx, stored))
+
+	report, err := uc.CheckDependenciesReport(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{waitingOnFirst.ID}, report.Unblocked)
+	assert.Equal(t, []usecase.BlockedTask{
+		{TaskID: waitingOnAll.ID, WaitingOn: []domain.TaskID{second.ID, third.ID}},
+	}, report.StillBlocked)
+
+	unblocked, err := repo.GetTask(ctx, waitingOnFirst.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, unblocked.Status)
+
+	// A second pass has nothing left to unblock
+	count, err := uc.CheckDependencies(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+// TestBlockedTasks verifies the blocked task view lists each blocked task with
+// the statuses of the dependencies it is still waiting on
+func TestBlockedTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(deps ...domain.TaskID) *domain.Task {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+		require.NoError(t, err)
+		return task
+	}
+	done := create()
+	started := create()
+	pending := create()
+	blocked := create(pending.ID, done.ID, started.ID)
+	create()
+
+	require.NoError(t, uc.UpdateTaskStatus(ctx, done.ID, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, done.ID, domain.StatusCompleted))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, started.ID, domain.StatusInProgress))
+
+	reasons, err := uc.GetBlockedTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, reasons, 1)
+	assert.Equal(t, blocked.ID, reasons[0].Task.ID)
+	assert.Equal(t, []usecase.DependencyStatus{
+		{TaskID: started.ID, Status: domain.StatusInProgress, Assignee: "alice"},
+		{TaskID: pending.ID, Status: domain.StatusPending, Assignee: "alice"},
+	}, reasons[0].WaitingOn)
+}
+
+// TestBlockedTasksCrossUser verifies a task blocked on another user's work
+// names that user as the assignee of the dependency it waits on
+func TestBlockedTasksCrossUser(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	bobsTask, err := uc.CreateTask(ctx, "API", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+	require.NoError(t, err)
+	alicesTask, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	waiting, err := uc.CreateTask(ctx, "UI", "Desc", domain.PriorityLow, "alice", nil, nil,
+		[]domain.TaskID{bobsTask.ID, alicesTask.ID})
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusBlocked, waiting.Status)
+
+	reasons, err := uc.GetBlockedTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, reasons, 1)
+	assert.Equal(t, []usecase.DependencyStatus{
+		{TaskID: bobsTask.ID, Status: domain.StatusPending, Assignee: "bob"},
+		{TaskID: alicesTask.ID, Status: domain.StatusPending, Assignee: "alice"},
+	}, reasons[0].WaitingOn)
+
+	rec := httptest.NewRecorder()
+	handlers.NewTaskHandler(uc).GetBlockedTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks/blocked", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var blocked []handlers.BlockedTaskDetailResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &blocked))
+	require.Len(t, blocked, 1)
+	assert.Equal(t, []handlers.DependencyStatusResponse{
+		{TaskID: bobsTask.ID, Status: domain.StatusPending, Assignee: "bob"},
+		{TaskID: alicesTask.ID, Status: domain.StatusPending, Assignee: "alice"},
+	}, blocked[0].WaitingOn)
+}
+
+// TestTopologicalOrder verifies tasks are ordered dependencies first, layer by
+// layer with ties broken by priority then ID, and that a cycle is reported
+func TestTopologicalOrder(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(priority domain.Priority, deps ...domain.TaskID) domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", priority, "alice", nil, nil, deps)
+		require.NoError(t, err)
+		return task.ID
+	}
+	low := create(domain.PriorityLow)                    // 1
+	high := create(domain.PriorityHigh)                  // 2
+	critical := create(domain.PriorityCritical, low)     // 3
+	medium := create(domain.PriorityMedium)              // 4
+	joined := create(domain.PriorityLow, critical, high) // 5
+	lowSibling := create(domain.PriorityLow, high)       // 6
+	archived := create(domain.PriorityCritical)          // 7
+	require.NoError(t, uc.UpdateTaskStatus(ctx, archived, domain.StatusCancelled))
+	require.NoError(t, uc.DeleteTask(ctx, archived))
+
+	ids := func(tasks []*domain.Task) []domain.TaskID {
+		result := make([]domain.TaskID, len(tasks))
+		for i, task := range tasks {
+			result[i] = task.ID
+		}
+		return result
+	}
+
+	ordered, err := uc.TopologicalOrder(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{
+		high, medium, low, // no dependencies
+		critical, lowSibling, // depend on the first layer
+		joined, // depends on the second layer; the archived task is left out
+	}, ids(ordered))
+
+	// Close a cycle behind the use case's back
+	stored, err := repo.GetTask(ctx, low)
+	require.NoError(t, err)
+	stored.Dependencies = map[domain.TaskID]bool{joined: true}
+	require.NoError(t, repo.UpdateTask(ctx, stored))
+
+	_, err = uc.TopologicalOrder(ctx)
+	assert.ErrorIs(t, err, domain.ErrCyclicDependency)
+}
+
+// TestCyclicDependencyPath verifies a rejected dependency and a cyclic state
+// both report the tasks that form the cycle
+func TestCyclicDependencyPath(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		_, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, "3 → 5 → 3", domain.DependencyCycle{3, 5, 3}.String())
+
+	// 1 depends on 2, which depends on 4
+	_, err = uc.AddDependency(ctx, 1, 2)
+	require.NoError(t, err)
+	_, err = uc.AddDependency(ctx, 2, 4)
+	require.NoError(t, err)
+
+	_, err = uc.AddDependency(ctx, 4, 1)
+	assert.ErrorIs(t, err, domain.ErrCyclicDependency)
+	assert.ErrorContains(t, err, "cycle: 4 → 1 → 2 → 4")
+
+	t.Run("Handler", func(t *testing.T) {
+		router := mux.NewRouter()
+		router.HandleFunc("/tasks/{id}/dependencies/{depId}", handlers.NewTaskHandler(uc).AddDependency).Methods("POST")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tasks/4/dependencies/2", nil))
+		require.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+
+		var resp handlers.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "cyclic_dependency", resp.Code)
+		assert.Contains(t, resp.Details, "cycle: 4 → 2 → 4")
+	})
+
+	t.Run("Invariant", func(t *testing.T) {
+		// Close a cycle behind the use case's back
+		stored, err := repo.GetTask(ctx, 3)
+		require.NoError(t, err)
+		stored.Dependencies = map[domain.TaskID]bool{5: true}
+		require.NoError(t, repo.UpdateTask(ctx, stored))
+		stored, err = repo.GetTask(ctx, 5)
+		require.NoError(t, err)
+		stored.Dependencies = map[domain.TaskID]bool{3: true}
+		require.NoError(t, repo.UpdateTask(ctx, stored))
+
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		err = checker.CheckAllInvariants(state)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "NoCyclicDependencies")
+		assert.Contains(t, err.Error(), "cycle: 3 → 5 → 3")
+
+		err = uc.CheckDependencyGraph(ctx)
+		assert.ErrorIs(t, err, domain.ErrCyclicDependency)
+		assert.ErrorContains(t, err, "cycle: 3 → 5 → 3")
+	})
+}
+
+// TestTransitiveDependencies verifies every direct and indirect dependency of
+// a task is listed once, over a multi-level chain that shares dependencies
+func TestTransitiveDependencies(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(deps ...domain.TaskID) domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+		require.NoError(t, err)
+		return task.ID
+	}
+	// 1 ← 2 ← 3 ← 5 and 1 ← 4 ← 5, so 1 is reached on two paths
+	root := create()
+	second := create(root)
+	third := create(second)
+	side := create(root)
+	top := create(third, side)
+	unrelated := create()
+
+	transitive := func(t *testing.T, id domain.TaskID) []domain.TaskID {
+		deps, err := uc.GetTransitiveDependencies(ctx, id)
+		require.NoError(t, err)
+		return deps
+	}
+	assert.Equal(t, []domain.TaskID{root, second, third, side}, transitive(t, top))
+	assert.Equal(t, []domain.TaskID{root, second}, transitive(t, third))
+	assert.Equal(t, []domain.TaskID{root}, transitive(t, side))
+	assert.Empty(t, transitive(t, root))
+	assert.Empty(t, transitive(t, unrelated))
+
+	_, err = uc.GetTransitiveDependencies(ctx, 99)
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+	t.Run("CycleGuard", func(t *testing.T) {
+		// The use cases reject cycles, so close one in the repository directly
+		task, err := repo.GetTask(ctx, root)
+		require.NoError(t, err)
+		task.Dependencies = map[domain.TaskID]bool{third: true}
+		require.NoError(t, repo.UpdateTask(ctx, task))
+
+		assert.Equal(t, []domain.TaskID{root, second, third, side}, transitive(t, top))
+		assert.Equal(t, []domain.TaskID{root, second}, transitive(t, third),
+			"the task itself is left out even when it depends on itself indirectly")
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		router := mux.NewRouter()
+		router.HandleFunc("/tasks/{id}/dependencies/transitive", handlers.NewTaskHandler(uc).GetTransitiveDependencies).Methods("GET")
+		get := func(id string) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/"+id+"/dependencies/transitive", nil))
+			return rec
+		}
+
+		rec := get("6")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.JSONEq(t, `{"task_id": 6, "dependencies": []}`, rec.Body.String())
+
+		rec = get("5")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp handlers.TransitiveDependenciesResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []domain.TaskID{root, second, third, side}, resp.Dependencies)
+
+		assert.Equal(t, http.StatusNotFound, get("99").Code)
+		assert.Equal(t, http.StatusBadRequest, get("abc").Code)
+	})
+}
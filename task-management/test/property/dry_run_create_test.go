@@ -0,0 +1,145 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestDryRunCreateTask verifies a task creation can be checked, invariants
+// included, without changing any state whether or not it would succeed
+func TestDryRunCreateTask(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := &failingChecker{InvariantChecker: invariants.NewInvariantChecker()}
+	uc := usecase.NewTaskUseCaseWithConfig(uow, checker, usecase.Config{
+		MaxTasks:        3,
+		SessionDuration: 24 * time.Hour,
+		Clock:           clock,
+	})
+	publisher := &recordingPublisher{}
+	uc.SetEventPublisher(publisher)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+	}))
+
+	_, err := uc.DryRunCreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+
+	_, err = uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	first, err := uc.CreateTask(ctx, "First", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	unchanged := func(t *testing.T, before *domain.SystemState, events int) {
+		t.Helper()
+		after, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assertSameJSON(t, before.Tasks, after.Tasks)
+		assert.Equal(t, before.NextTaskID, after.NextTaskID)
+		assert.ElementsMatch(t, before.UserTasks["alice"], after.UserTasks["alice"])
+		assert.Len(t, publisher.events, events, "no events")
+		_, total, err := repo.QueryActivity(ctx, domain.ActivityFilter{}, 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, events, total, "no activity")
+	}
+	snapshot := func(t *testing.T) (*domain.SystemState, int) {
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		return state, len(publisher.events)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		before, events := snapshot(t)
+		task, err := uc.DryRunCreateTask(ctx, "Second", "Desc", domain.PriorityHigh, "alice", nil, nil,
+			[]domain.TaskID{first.ID}, usecase.WithDueIn(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, first.ID+1, task.ID, "the ID it would get")
+		assert.Equal(t, domain.StatusBlocked, task.Status)
+		require.NotNil(t, task.DueDate)
+		unchanged(t, before, events)
+
+		// Creating it for real hands out the same ID
+		created, err := uc.CreateTask(ctx, "Second", "Desc", domain.PriorityHigh, "alice", nil, nil, []domain.TaskID{first.ID})
+		require.NoError(t, err)
+		assert.Equal(t, task.ID, created.ID)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		before, events := snapshot(t)
+		_, err := uc.DryRunCreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{99})
+		assert.ErrorIs(t, err, domain.ErrInvalidDependency)
+		_, err = uc.DryRunCreateTask(ctx, "Task", "Desc", "urgent", "alice", nil, nil, nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidPriority)
+		_, err = uc.DryRunCreateTask(ctx, "Task", "Desc", domain.PriorityLow, "nobody", nil, nil, nil)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		checker.fail = true
+		_, err = uc.DryRunCreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		checker.fail = false
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+		unchanged(t, before, events)
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		handler := handlers.NewTaskHandler(uc)
+		validate := func(body string) (*httptest.ResponseRecorder, handlers.ValidateTaskResponse) {
+			req := httptest.NewRequest(http.MethodPost, "/tasks/validate", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ValidateTask(rec, req)
+			var resp handlers.ValidateTaskResponse
+			if rec.Code == http.StatusOK {
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			}
+			return rec, resp
+		}
+
+		before, events := snapshot(t)
+		rec, resp := validate(`{"title": "Third", "description": "Desc", "priority": "low", "assignee": "alice"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.True(t, resp.Valid)
+		require.NotNil(t, resp.Task)
+		assert.Equal(t, "Third", resp.Task.Title)
+
+		rec, resp = validate(`{"title": "Third", "description": "Desc", "priority": "low", "assignee": "alice", "dependencies": [99]}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.False(t, resp.Valid)
+		assert.Nil(t, resp.Task)
+		assert.Equal(t, "invalid_dependency", resp.Code)
+		assert.NotEmpty(t, resp.Error)
+		unchanged(t, before, events)
+
+		// A third task reaches the limit
+		_, err := uc.CreateTask(ctx, "Third", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		rec, resp = validate(`{"title": "Fifth", "description": "Desc", "priority": "low", "assignee": "alice"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.False(t, resp.Valid)
+		assert.Equal(t, "max_tasks_reached", resp.Code)
+
+		rec, _ = validate(`{"title": "Task", "due_in_days": -1}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		require.NoError(t, uc.Logout(ctx, "alice"))
+		rec, _ = validate(`{"title": "Task", "description": "Desc", "priority": "low", "assignee": "alice"}`)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
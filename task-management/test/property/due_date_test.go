@@ -0,0 +1,80 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequireFutureDueDate verifies the optional past due date validation
+func TestRequireFutureDueDate(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T, requireFuture bool) *usecase.TaskUseCase {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			RequireFutureDueDate: requireFuture,
+		})
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		return uc
+	}
+	justPast := func() *time.Time {
+		due := time.Now().Add(-time.Millisecond)
+		return &due
+	}
+	justFuture := func() *time.Time {
+		due := time.Now().Add(time.Second)
+		return &due
+	}
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		uc := setup(t, false)
+		_, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", justPast(), nil, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("CreateTask", func(t *testing.T) {
+		uc := setup(t, true)
+		_, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", justPast(), nil, nil)
+		assert.ErrorIs(t, err, domain.ErrDueDateInPast)
+
+		_, err = uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", justFuture(), nil, nil)
+		assert.NoError(t, err)
+
+		_, err = uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		assert.NoError(t, err, "no due date is allowed")
+	})
+
+	t.Run("UpdateTaskDetails", func(t *testing.T) {
+		uc := setup(t, true)
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", justFuture(), nil, nil)
+		require.NoError(t, err)
+
+		err = uc.UpdateTaskDetails(ctx, task.ID, "Task", "Desc", justPast())
+		assert.ErrorIs(t, err, domain.ErrDueDateInPast)
+
+		err = uc.UpdateTaskDetails(ctx, task.ID, "Task", "Desc", justFuture())
+		assert.NoError(t, err)
+	})
+
+	t.Run("UnchangedDueDateMayHavePassed", func(t *testing.T) {
+		uc := setup(t, true)
+		due := time.Now().Add(20 * time.Millisecond)
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", &due, nil, nil)
+		require.NoError(t, err)
+
+		time.Sleep(time.Until(due) + time.Millisecond)
+		assert.NoError(t, uc.UpdateTaskDetails(ctx, task.ID, "Renamed", "Desc", &due))
+	})
+}
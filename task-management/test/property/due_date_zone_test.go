@@ -0,0 +1,116 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestDueDateZones verifies due dates submitted with an offset are stored in
+// UTC and judged overdue or due soon the same way whatever the server's zone
+func TestDueDateZones(t *testing.T) {
+	local := time.Local
+	t.Cleanup(func() { time.Local = local })
+
+	zones := []*time.Location{
+		time.UTC,
+		time.FixedZone("UTC+14", 14*60*60),
+		time.FixedZone("UTC-11", -11*60*60),
+	}
+	for _, zone := range zones {
+		t.Run(zone.String(), func(t *testing.T) {
+			time.Local = zone
+			ctx := context.Background()
+			// The clock reads in the server's zone, the due dates in others
+			now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC).In(zone)
+			clock := domain.NewFakeClock(now)
+			repo := memory.NewMemoryRepositoryWithClock(clock)
+			uow := memory.NewMemoryUnitOfWork(repo)
+			checker := invariants.NewInvariantCheckerWithConfig(invariants.Config{
+				DueSoonThreshold: time.Hour,
+				Clock:            clock,
+			})
+			uc := usecase.NewTaskUseCaseWithConfig(uow, checker, usecase.Config{
+				SessionDuration: 24 * time.Hour,
+				Clock:           clock,
+			})
+			handler := handlers.NewTaskHandler(uc)
+
+			require.NoError(t, repo.CreateUser(ctx, &domain.User{
+				ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: now,
+			}))
+			_, err := uc.Authenticate(ctx, "alice")
+			require.NoError(t, err)
+
+			create := func(dueDate string) handlers.CreateTaskResponse {
+				body := `{"title": "Task", "description": "Desc", "priority": "low", "assignee": "alice", "due_date": "` + dueDate + `"}`
+				req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(body))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+				handler.CreateTask(rec, req)
+				require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+				assert.Contains(t, rec.Body.String(), `"due_date":"`, "due date is rendered")
+				assert.NotContains(t, rec.Body.String(), "+09:00", "due date is rendered in UTC")
+				var resp handlers.CreateTaskResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				return resp
+			}
+
+			// 11:00Z, an hour ago, and 12:30Z, half an hour ahead
+			overdue := create("2030-01-01T20:00:00+09:00")
+			dueSoon := create("2030-01-01T06:30:00-06:00")
+
+			stored, err := repo.GetTask(ctx, overdue.ID)
+			require.NoError(t, err)
+			assert.Equal(t, time.UTC, stored.DueDate.Location())
+			assert.Equal(t, time.Date(2030, 1, 1, 11, 0, 0, 0, time.UTC), *stored.DueDate)
+
+			tasks, err := uc.GetOverdueTasksForUser(ctx, "alice")
+			require.NoError(t, err)
+			assert.Equal(t, []domain.TaskID{overdue.ID}, taskIDs(tasks))
+
+			state, err := repo.GetSystemState(ctx)
+			require.NoError(t, err)
+			kinds := make(map[domain.TaskID]string)
+			for _, warning := range checker.LivenessWarnings(state) {
+				if warning.Kind == invariants.WarningOverdue || warning.Kind == invariants.WarningDueSoon {
+					kinds[warning.TaskID] = warning.Kind
+					assert.True(t, strings.HasSuffix(warning.Message, "Z)"), warning.Message)
+				}
+			}
+			assert.Equal(t, map[domain.TaskID]string{
+				overdue.ID: invariants.WarningOverdue,
+				dueSoon.ID: invariants.WarningDueSoon,
+			}, kinds)
+
+			t.Run("Update", func(t *testing.T) {
+				dueDate := time.Date(2030, 1, 2, 9, 0, 0, 0, time.FixedZone("UTC+5:30", 5*60*60+30*60))
+				require.NoError(t, uc.UpdateTaskDetails(ctx, overdue.ID, "Task", "Desc", &dueDate))
+				stored, err := repo.GetTask(ctx, overdue.ID)
+				require.NoError(t, err)
+				assert.Equal(t, time.UTC, stored.DueDate.Location())
+				assert.True(t, stored.DueDate.Equal(dueDate))
+
+				patched := time.Date(2029, 12, 31, 23, 0, 0, 0, time.FixedZone("UTC-3", -3*60*60))
+				task, err := uc.PatchTask(ctx, dueSoon.ID, usecase.TaskPatch{DueDate: &patched})
+				require.NoError(t, err)
+				assert.Equal(t, time.UTC, task.DueDate.Location())
+				assert.True(t, task.IsOverdueAt(now), "due at 02:00Z, before the clock's 12:00Z")
+			})
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestEmailLookup verifies users can be found and assigned by email
+func TestEmailLookup(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*memory.MemoryRepository, *usecase.TaskUseCase) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+		for _, user := range []*domain.User{
+			{ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now()},
+			{ID: "bob", Name: "Bob", Email: "Bob@Example.com", JoinedAt: time.Now()},
+		} {
+			require.NoError(t, repo.CreateUser(ctx, user))
+		}
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		return repo, uc
+	}
+
+	t.Run("CaseInsensitive", func(t *testing.T) {
+		_, uc := setup(t)
+
+		user, err := uc.FindUserByEmail(ctx, " bob@example.COM ")
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("bob"), user.ID)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, uc := setup(t)
+
+		_, err := uc.FindUserByEmail(ctx, "carol@example.com")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("DuplicateRejected", func(t *testing.T) {
+		repo, _ := setup(t)
+
+		err := repo.CreateUser(ctx, &domain.User{ID: "bob2", Name: "Bob", Email: "bob@example.com", JoinedAt: time.Now()})
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+
+		alice, err := repo.GetUser(ctx, "alice")
+		require.NoError(t, err)
+		alice.Email = "BOB@example.com"
+		assert.ErrorIs(t, repo.UpdateUser(ctx, alice), domain.ErrDuplicateEmail)
+	})
+
+	t.Run("EmailChangeReindexed", func(t *testing.T) {
+		repo, uc := setup(t)
+
+		alice, err := repo.GetUser(ctx, "alice")
+		require.NoError(t, err)
+		alice.Email = "alice@corp.example.com"
+		require.NoError(t, repo.UpdateUser(ctx, alice))
+
+		_, err = uc.FindUserByEmail(ctx, "alice@example.com")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		user, err := uc.FindUserByEmail(ctx, "alice@corp.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("alice"), user.ID)
+	})
+
+	t.Run("AssignByEmail", func(t *testing.T) {
+		repo, uc := setup(t)
+
+		task, err := uc.CreateTask(ctx, "Title", "Desc", domain.PriorityLow, "bob@example.com", nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("bob"), task.Assignee)
+
+		require.NoError(t, uc.ReassignTask(ctx, task.ID, "ALICE@example.com"))
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("alice"), stored.Assignee)
+
+		_, err = uc.CreateTask(ctx, "Title", "Desc", domain.PriorityLow, "carol@example.com", nil, nil, nil)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		_, uc := setup(t)
+		handler := handlers.NewTaskHandler(uc)
+
+		rec := httptest.NewRecorder()
+		handler.FindUserByEmail(rec, httptest.NewRequest(http.MethodGet, "/users/by-email?email=bob@example.com", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var user domain.User
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&user))
+		assert.Equal(t, domain.UserID("bob"), user.ID)
+
+		rec = httptest.NewRecorder()
+		handler.FindUserByEmail(rec, httptest.NewRequest(http.MethodGet, "/users/by-email?email=carol@example.com", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.FindUserByEmail(rec, httptest.NewRequest(http.MethodGet, "/users/by-email", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,63 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTypedErrorsThroughUseCases verifies sentinel errors survive use case wrapping
+func TestTypedErrorsThroughUseCases(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+
+	_, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+
+	_, err = uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		title    string
+		priority domain.Priority
+		tags     []domain.Tag
+		deps     []domain.TaskID
+		expected error
+	}{
+		{"TitleEmpty", "", domain.PriorityLow, nil, nil, domain.ErrTitleEmpty},
+		{"InvalidPriority", "Task", "urgent", nil, nil, domain.ErrInvalidPriority},
+		{"InvalidTag", "Task", domain.PriorityLow, []domain.Tag{"chore"}, nil, domain.ErrInvalidTag},
+		{"InvalidDependency", "Task", domain.PriorityLow, nil, []domain.TaskID{999}, domain.ErrInvalidDependency},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := uc.CreateTask(ctx, tc.title, "Desc", tc.priority, "alice", nil, tc.tags, tc.deps)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+
+	t.Run("InvalidTransition", func(t *testing.T) {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		assert.ErrorIs(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCompleted), domain.ErrInvalidTransition)
+	})
+
+	t.Run("TaskNotFound", func(t *testing.T) {
+		assert.ErrorIs(t, uc.UpdateTaskStatus(ctx, 999, domain.StatusInProgress), domain.ErrTaskNotFound)
+	})
+}
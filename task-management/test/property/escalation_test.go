@@ -0,0 +1,156 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestEscalation verifies idle pending tasks are raised one priority level
+// once they cross the escalation age, and only when escalation is enabled
+func TestEscalation(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	setup := func(t *testing.T, enabled bool) (*domain.FakeClock, *memory.MemoryRepository, *usecase.TaskUseCase, *recordingPublisher) {
+		clock := domain.NewFakeClock(start)
+		repo := memory.NewMemoryRepositoryWithClock(clock)
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			SessionDuration:   30 * 24 * time.Hour,
+			Clock:             clock,
+			EscalationEnabled: enabled,
+			EscalationAge:     48 * time.Hour,
+		})
+		publisher := &recordingPublisher{}
+		uc.SetEventPublisher(publisher)
+
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		return clock, repo, uc, publisher
+	}
+
+	t.Run("CrossesThreshold", func(t *testing.T) {
+		clock, repo, uc, publisher := setup(t, true)
+		stale, err := uc.CreateTask(ctx, "Stale", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+
+		priorityOf := func(id domain.TaskID) domain.Priority {
+			task, err := repo.GetTask(ctx, id)
+			require.NoError(t, err)
+			return task.Priority
+		}
+
+		clock.Advance(47 * time.Hour)
+		fresh, err := uc.CreateTask(ctx, "Fresh", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		report, err := uc.CheckDependenciesReport(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, report.Escalated, "no task has crossed the threshold yet")
+
+		clock.Advance(time.Hour)
+		report, err = uc.CheckDependenciesReport(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{stale.ID}, report.Escalated)
+		assert.Equal(t, domain.PriorityMedium, priorityOf(stale.ID))
+		assert.Equal(t, domain.PriorityLow, priorityOf(fresh.ID))
+
+		escalations := publisher.ofType(domain.EventTaskEscalated)
+		require.Len(t, escalations, 1)
+		assert.Equal(t, map[string]string{"from": "low", "to": "medium"}, escalations[0].Data)
+
+		// Escalation counts as an update, so the next level needs another full age
+		escalated, err := uc.EscalateStaleTasks(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, escalated)
+
+		clock.Advance(48 * time.Hour)
+		escalated, err = uc.EscalateStaleTasks(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{stale.ID, fresh.ID}, escalated)
+		assert.Equal(t, domain.PriorityHigh, priorityOf(stale.ID))
+		assert.Equal(t, domain.PriorityMedium, priorityOf(fresh.ID))
+	})
+
+	t.Run("OnlyPendingBelowCritical", func(t *testing.T) {
+		clock, repo, uc, _ := setup(t, true)
+		critical, err := uc.CreateTask(ctx, "Critical", "Desc", domain.PriorityCritical, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		started, err := uc.CreateTask(ctx, "Started", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, started.ID, domain.StatusInProgress))
+
+		clock.Advance(72 * time.Hour)
+		escalated, err := uc.EscalateStaleTasks(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, escalated)
+
+		task, err := repo.GetTask(ctx, critical.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PriorityCritical, task.Priority)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		clock, repo, uc, _ := setup(t, false)
+		task, err := uc.CreateTask(ctx, "Stale", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+
+		clock.Advance(30 * 24 * time.Hour)
+		report, err := uc.CheckDependenciesReport(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, report.Escalated)
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PriorityLow, stored.Priority)
+	})
+
+	t.Run("RolledBack", func(t *testing.T) {
+		clock := domain.NewFakeClock(start)
+		repo := memory.NewMemoryRepositoryWithClock(clock)
+		checker := &failingChecker{InvariantChecker: invariants.NewInvariantChecker()}
+		uc := usecase.NewTaskUseCaseWithConfig(memory.NewMemoryUnitOfWork(repo), checker, usecase.Config{
+			SessionDuration:   30 * 24 * time.Hour,
+			Clock:             clock,
+			EscalationEnabled: true,
+			EscalationAge:     48 * time.Hour,
+		})
+		publisher := &recordingPublisher{}
+		uc.SetEventPublisher(publisher)
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		stale, err := uc.CreateTask(ctx, "Stale", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		clock.Advance(72 * time.Hour)
+
+		// An escalation failing its invariant check is neither kept nor announced
+		checker.fail = true
+		_, err = uc.EscalateStaleTasks(ctx)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+		_, err = uc.CheckDependenciesReport(ctx)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+		task, err := repo.GetTask(ctx, stale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PriorityLow, task.Priority)
+		assert.Empty(t, publisher.ofType(domain.EventTaskEscalated))
+
+		checker.fail = false
+		escalated, err := uc.EscalateStaleTasks(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{stale.ID}, escalated)
+		assert.Len(t, publisher.ofType(domain.EventTaskEscalated), 1)
+	})
+}
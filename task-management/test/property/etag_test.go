@@ -0,0 +1,80 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestTaskETag verifies GET /tasks/{id} returns an ETag and honors
+// If-None-Match with 304 until the task changes
+func TestTaskETag(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	router := mux.NewRouter()
+	router.HandleFunc("/tasks/{id}", handlers.NewTaskHandler(uc).GetTask).Methods("GET")
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	dep, err := uc.CreateTask(ctx, "Dependency", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{dep.ID})
+	require.NoError(t, err)
+
+	get := func(id domain.TaskID, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/tasks/%d", id), nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get(task.ID, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"title":"Task"`)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, etag, get(task.ID, "").Header().Get("ETag"), "the tag is stable")
+
+	for _, header := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		rec := get(task.ID, header)
+		assert.Equal(t, http.StatusNotModified, rec.Code, header)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+	}
+	assert.Equal(t, http.StatusOK, get(task.ID, `"stale"`).Code)
+
+	// Completing the dependency changes the task's dependency_progress
+	require.NoError(t, uc.UpdateTaskStatus(ctx, dep.ID, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, dep.ID, domain.StatusCompleted))
+	rec = get(task.ID, etag)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+
+	// So does editing the task itself
+	etag = rec.Header().Get("ETag")
+	require.NoError(t, uc.UpdateTaskPriority(ctx, task.ID, domain.PriorityHigh))
+	assert.Equal(t, http.StatusOK, get(task.ID, etag).Code)
+
+	assert.Equal(t, http.StatusNotFound, get(999, "").Code)
+}
@@ -0,0 +1,91 @@
+package property
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/events"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestEventStream verifies task events reach WebSocket clients, filtered by
+// assignee, and that disconnected clients are unsubscribed
+func TestEventStream(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	broker := events.NewBroker()
+	uc.SetEventPublisher(broker)
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(handlers.NewEventHandler(broker).Stream))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?assignee=bob", nil)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return broker.Subscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Only events for bob's tasks are delivered
+	_, err = uc.CreateTask(ctx, "Alice's", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	task, err := uc.CreateTask(ctx, "Bob's", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	var event domain.Event
+	require.NoError(t, conn.ReadJSON(&event))
+	assert.Equal(t, domain.EventTaskCreated, event.Type)
+	assert.Equal(t, task.ID, event.TaskID)
+	assert.Equal(t, domain.UserID("bob"), event.Assignee)
+
+	require.NoError(t, uc.ReassignTask(ctx, task.ID, "alice"))
+	require.NoError(t, conn.ReadJSON(&event))
+	assert.Equal(t, domain.EventTaskReassigned, event.Type)
+	assert.Equal(t, "bob", event.Data["from"])
+
+	require.NoError(t, conn.Close())
+	assert.Eventually(t, func() bool { return broker.Subscribers() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+// TestBrokerClose verifies closing the broker ends all subscriptions
+func TestBrokerClose(t *testing.T) {
+	broker := events.NewBroker()
+	stream, unsubscribe := broker.Subscribe(1)
+
+	broker.Publish(domain.Event{Type: domain.EventTaskCreated, TaskID: 1})
+	broker.Publish(domain.Event{Type: domain.EventTaskCreated, TaskID: 2}) // dropped, buffer full
+	broker.Close()
+
+	var received []domain.TaskID
+	for event := range stream {
+		received = append(received, event.TaskID)
+	}
+	assert.Equal(t, []domain.TaskID{1}, received)
+	assert.Equal(t, 0, broker.Subscribers())
+
+	unsubscribe()
+	late, _ := broker.Subscribe(1)
+	_, ok := <-late
+	assert.False(t, ok)
+}
@@ -0,0 +1,89 @@
+package property
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestExportTasks verifies the CSV and JSON task exports
+func TestExportTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	due := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	first, err := uc.CreateTask(ctx, "Fix, then ship", "Desc", domain.PriorityLow, "alice", &due,
+		[]domain.Tag{domain.TagBug, domain.TagFeature}, nil)
+	require.NoError(t, err)
+	_, err = uc.CreateTask(ctx, "Second", "Desc", domain.PriorityCritical, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	export := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ExportTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks/export?"+query, nil))
+		return rec
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		rec := export("format=csv")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, []string{"id", "title", "status", "priority", "assignee", "created_at", "due_date", "tags"}, records[0])
+		assert.Equal(t, []string{
+			"1", "Fix, then ship", "pending", "low", "alice",
+			first.CreatedAt.Format(time.RFC3339), "2030-01-02T03:04:05Z", "bug;feature",
+		}, records[1])
+		assert.Equal(t, "", records[2][6], "missing due date is empty")
+		assert.Equal(t, "", records[2][7], "no tags is empty")
+	})
+
+	t.Run("CSVSorted", func(t *testing.T) {
+		rec := export("format=csv&sort=priority")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "Second", records[1][1])
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		rec := export("format=json")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var tasks []domain.Task
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+		require.Len(t, tasks, 2)
+		assert.Equal(t, "Fix, then ship", tasks[0].Title)
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, export("format=xml").Code)
+	})
+}
@@ -0,0 +1,123 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestFieldLengthLimits verifies titles and descriptions are bounded in
+// characters, exactly at the configured limits
+func TestFieldLengthLimits(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, domain.SetFieldLimits(domain.DefaultFieldLimits()))
+	})
+
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(title, description string) error {
+		_, err := uc.CreateTask(ctx, title, description, domain.PriorityLow, "alice", nil, nil, nil)
+		return err
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		assert.Equal(t, 200, domain.CurrentFieldLimits().MaxTitleLength)
+		assert.Equal(t, 10000, domain.CurrentFieldLimits().MaxDescriptionLength)
+
+		assert.NoError(t, create(strings.Repeat("t", 200), strings.Repeat("d", 10000)))
+		assert.ErrorIs(t, create(strings.Repeat("t", 201), "Desc"), domain.ErrTitleTooLong)
+		assert.ErrorIs(t, create("Title", strings.Repeat("d", 10001)), domain.ErrDescriptionTooLong)
+	})
+
+	t.Run("CountsCharacters", func(t *testing.T) {
+		// 200 three-byte characters are 600 bytes but still within the limit
+		assert.NoError(t, create(strings.Repeat("✓", 200), "Desc"))
+		assert.ErrorIs(t, create(strings.Repeat("✓", 201), "Desc"), domain.ErrTitleTooLong)
+	})
+
+	t.Run("UpdateDetails", func(t *testing.T) {
+		task, err := uc.CreateTask(ctx, "Title", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+
+		err = uc.UpdateTaskDetails(ctx, task.ID, strings.Repeat("t", 201), "Desc", nil)
+		assert.ErrorIs(t, err, domain.ErrTitleTooLong)
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Title", stored.Title)
+
+		title := strings.Repeat("t", 200)
+		_, err = uc.PatchTask(ctx, task.ID, usecase.TaskPatch{Title: &title})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		require.NoError(t, domain.SetFieldLimits(domain.FieldLimits{MaxTitleLength: 5, MaxDescriptionLength: 10}))
+		defer func() {
+			require.NoError(t, domain.SetFieldLimits(domain.DefaultFieldLimits()))
+		}()
+
+		assert.NoError(t, create("12345", "1234567890"))
+		assert.ErrorIs(t, create("123456", "Desc"), domain.ErrTitleTooLong)
+		assert.ErrorIs(t, create("Title", "12345678901"), domain.ErrDescriptionTooLong)
+	})
+
+	t.Run("InvalidLimits", func(t *testing.T) {
+		assert.Error(t, domain.SetFieldLimits(domain.FieldLimits{MaxTitleLength: 0, MaxDescriptionLength: 10}))
+		assert.Error(t, domain.SetFieldLimits(domain.FieldLimits{MaxTitleLength: 10, MaxDescriptionLength: -1}))
+		assert.Equal(t, domain.DefaultFieldLimits(), domain.CurrentFieldLimits(), "the limits are unchanged")
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		post := func(title, description string) (*httptest.ResponseRecorder, handlers.ErrorResponse) {
+			body, err := json.Marshal(handlers.CreateTaskRequest{
+				Title: title, Description: description, Priority: domain.PriorityLow, Assignee: "alice",
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.CreateTask(rec, req)
+			var resp handlers.ErrorResponse
+			if rec.Code != http.StatusCreated {
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			}
+			return rec, resp
+		}
+
+		rec, _ := post(strings.Repeat("t", 200), "Desc")
+		assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		rec, resp := post(strings.Repeat("t", 201), "Desc")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "title_too_long", resp.Code)
+		assert.Contains(t, resp.Details, "201 characters exceed the limit of 200")
+
+		rec, resp = post("Title", strings.Repeat("d", 10001))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "description_too_long", resp.Code)
+	})
+}
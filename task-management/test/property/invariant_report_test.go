@@ -0,0 +1,72 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestInvariantReport verifies GET /admin/invariants reports every invariant
+func TestInvariantReport(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+	handler := handlers.NewInvariantHandler(repo, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	task, err := uc.CreateTask(ctx, "Urgent", "Desc", domain.PriorityCritical, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	report := func(t *testing.T) invariants.Report {
+		rec := httptest.NewRecorder()
+		handler.Report(rec, httptest.NewRequest(http.MethodGet, "/admin/invariants", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var body invariants.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body
+	}
+
+	healthy := report(t)
+	assert.True(t, healthy.Healthy)
+	assert.Len(t, healthy.Invariants, 11)
+	for _, result := range healthy.Invariants {
+		assert.True(t, result.Passed, result.Name)
+		assert.Empty(t, result.Violation)
+	}
+	assert.Equal(t, []string{"1 critical tasks are still pending"}, healthy.LivenessWarnings)
+
+	// Orphaning the task breaks both NoOrphanTasks and TaskOwnership
+	require.NoError(t, repo.RemoveUserTask(ctx, "alice", task.ID))
+
+	broken := report(t)
+	assert.False(t, broken.Healthy)
+	failed := map[string]string{}
+	for _, result := range broken.Invariants {
+		if !result.Passed {
+			failed[result.Name] = result.Violation
+		}
+	}
+	assert.Len(t, failed, 2)
+	assert.Contains(t, failed, "NoOrphanTasks")
+	assert.Contains(t, failed, "TaskOwnership")
+	assert.NotEmpty(t, failed["NoOrphanTasks"])
+}
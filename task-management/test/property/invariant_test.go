@@ -2,7 +2,9 @@
 package property
 
 import (
+	"context"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 
 // TestInvariantsHoldAfterOperations verifies invariants hold after each operation
 func TestInvariantsHoldAfterOperations(t *testing.T) {
+	ctx := context.Background()
 	repo := memory.NewMemoryRepository()
 	uow := memory.NewMemoryUnitOfWork(repo)
 	checker := invariants.NewInvariantChecker()
@@ -30,30 +33,31 @@ func TestInvariantsHoldAfterOperations(t *testing.T) {
 			Email:    string(userID) + "@example.com",
 			JoinedAt: time.Now(),
 		}
-		require.NoError(t, repo.CreateUser(user))
+		require.NoError(t, repo.CreateUser(ctx, user))
 	}
 
 	// Property: Invariants hold after authentication
 	t.Run("InvariantsAfterAuthentication", func(t *testing.T) {
 		for _, userID := range users {
-			session, err := uc.Authenticate(userID)
+			session, err := uc.Authenticate(ctx, userID)
 			assert.NoError(t, err)
 			assert.NotNil(t, session)
 
-			state, _ := repo.GetSystemState()
+			state, _ := repo.GetSystemState(ctx)
 			assert.NoError(t, checker.CheckAllInvariants(state))
 
 			// Cleanup
-			_ = uc.Logout(userID)
+			_ = uc.Logout(ctx, userID)
 		}
 	})
 
 	// Property: Invariants hold after task creation
 	t.Run("InvariantsAfterTaskCreation", func(t *testing.T) {
-		uc.Authenticate("alice")
+		uc.Authenticate(ctx, "alice")
 
 		for i := 0; i < 10; i++ {
 			task, err := uc.CreateTask(
+				ctx,
 				"Task "+string(rune(i)),
 				"Description",
 				randomPriority(),
@@ -66,17 +70,18 @@ func TestInvariantsHoldAfterOperations(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, task)
 
-			state, _ := repo.GetSystemState()
+			state, _ := repo.GetSystemState(ctx)
 			assert.NoError(t, checker.CheckAllInvariants(state))
 		}
 	})
 
 	// Property: Invariants hold after status transitions
 	t.Run("InvariantsAfterStatusTransitions", func(t *testing.T) {
-		uc.Authenticate("alice")
+		uc.Authenticate(ctx, "alice")
 
 		// Create a task
 		task, _ := uc.CreateTask(
+			ctx,
 			"Test Task",
 			"Description",
 			domain.PriorityMedium,
@@ -93,9 +98,9 @@ func TestInvariantsHoldAfterOperations(t *testing.T) {
 		}
 
 		for _, status := range validTransitions {
-			err := uc.UpdateTaskStatus(task.ID, status)
+			err := uc.UpdateTaskStatus(ctx, task.ID, status)
 			if err == nil {
-				state, _ := repo.GetSystemState()
+				state, _ := repo.GetSystemState(ctx)
 				assert.NoError(t, checker.CheckAllInvariants(state))
 			}
 		}
@@ -103,20 +108,20 @@ func TestInvariantsHoldAfterOperations(t *testing.T) {
 
 	// Property: No cyclic dependencies can be created
 	t.Run("NoCyclicDependencies", func(t *testing.T) {
-		uc.Authenticate("alice")
+		uc.Authenticate(ctx, "alice")
 
 		// Create tasks with potential cycles
-		task1, _ := uc.CreateTask("Task1", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{})
-		task2, _ := uc.CreateTask("Task2", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{task1.ID})
-		task3, _ := uc.CreateTask("Task3", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{task2.ID})
+		task1, _ := uc.CreateTask(ctx, "Task1", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{})
+		task2, _ := uc.CreateTask(ctx, "Task2", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{task1.ID})
+		task3, _ := uc.CreateTask(ctx, "Task3", "Desc", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{task2.ID})
 
 		// Attempting to create a cycle should fail
-		_, err := uc.CreateTask("Task4", "Desc", domain.PriorityLow, "alice", nil, nil,
+		_, err := uc.CreateTask(ctx, "Task4", "Desc", domain.PriorityLow, "alice", nil, nil,
 			[]domain.TaskID{task3.ID, task1.ID}) // This would create a cycle
 		assert.NoError(t, err)
 
 		// Even if it doesn't fail explicitly, invariants should catch it
-		state, _ := repo.GetSystemState()
+		state, _ := repo.GetSystemState(ctx)
 		assert.NoError(t, checker.CheckAllInvariants(state))
 	})
 }
@@ -164,8 +169,443 @@ func TestTransitionInvariants(t *testing.T) {
 	}
 }
 
+// TestManualBlockRequiresIncompleteDependencies verifies tasks can only be blocked by real dependencies
+func TestManualBlockRequiresIncompleteDependencies(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	t.Run("NoDependencies", func(t *testing.T) {
+		task, err := uc.CreateTask(ctx, "Free", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+
+		err = uc.UpdateTaskStatus(ctx, task.ID, domain.StatusBlocked)
+		assert.Error(t, err)
+
+		stored, _ := repo.GetTask(ctx, task.ID)
+		assert.Equal(t, domain.StatusPending, stored.Status)
+	})
+
+	t.Run("IncompleteDependencies", func(t *testing.T) {
+		dep, err := uc.CreateTask(ctx, "Dep", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		task, err := uc.CreateTask(ctx, "Waiting", "Desc", domain.PriorityLow, "alice", nil, nil,
+			[]domain.TaskID{dep.ID})
+		require.NoError(t, err)
+		require.Equal(t, domain.StatusBlocked, task.Status)
+
+		require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusPending))
+		assert.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusBlocked))
+	})
+}
+
+// TestMaxTasksLimit verifies task creation stops at the configured limit
+func TestMaxTasksLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCaseWithConfig(uow, checker, usecase.Config{MaxTasks: 2})
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+	}
+
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	assert.Nil(t, task)
+	assert.ErrorIs(t, err, domain.ErrMaxTasksReached)
+	assert.EqualError(t, err, "maximum number of tasks (2) reached")
+
+	tasks, _ := repo.GetAllTasks(ctx)
+	assert.Len(t, tasks, 2)
+}
+
+// TestMaxTasksLimitCountsLiveTasks verifies that with CountLiveTasks purged
+// tasks free capacity under MaxTasks, while IDs keep increasing
+func TestMaxTasksLimitCountsLiveTasks(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T, countLive bool) (*memory.MemoryRepository, *usecase.TaskUseCase) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			MaxTasks:       2,
+			CountLiveTasks: countLive,
+		})
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "root")
+		require.NoError(t, err)
+		return repo, uc
+	}
+	create := func(uc *usecase.TaskUseCase) (*domain.Task, error) {
+		return uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "root", nil, nil, nil)
+	}
+	purge := func(t *testing.T, uc *usecase.TaskUseCase, id domain.TaskID) {
+		require.NoError(t, uc.UpdateTaskStatus(ctx, id, domain.StatusCancelled))
+		require.NoError(t, uc.PurgeTask(ctx, id))
+	}
+
+	t.Run("LiveCount", func(t *testing.T) {
+		repo, uc := setup(t, true)
+		checker := invariants.NewInvariantChecker()
+
+		var created []domain.TaskID
+		for round := 0; round < 3; round++ {
+			for len(created) < 2 {
+				task, err := create(uc)
+				require.NoError(t, err, "round %d", round)
+				created = append(created, task.ID)
+			}
+			_, err := create(uc)
+			assert.ErrorIs(t, err, domain.ErrMaxTasksReached)
+
+			// Deleting a task frees its slot for the next round
+			purge(t, uc, created[0])
+			created = created[1:]
+		}
+
+		task, err := create(uc)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TaskID(5), task.ID, "freed IDs are not reused")
+
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.Len(t, state.Tasks, 2)
+		assert.NoError(t, checker.CheckAllInvariants(state))
+	})
+
+	t.Run("NextTaskID", func(t *testing.T) {
+		_, uc := setup(t, false)
+		first, err := create(uc)
+		require.NoError(t, err)
+		_, err = create(uc)
+		require.NoError(t, err)
+
+		// By default deleted tasks still count toward the limit
+		purge(t, uc, first.ID)
+		_, err = create(uc)
+		assert.ErrorIs(t, err, domain.ErrMaxTasksReached)
+	})
+}
+
+// TestAssigneeExistsInvariant verifies tasks must be assigned to known users
+func TestAssigneeExistsInvariant(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	for _, userID := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: userID, Name: string(userID), Email: string(userID) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	_, err = uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "nobody", nil, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	_, err = uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+	require.NoError(t, err)
+
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.True(t, state.UserExists("bob"))
+	require.NoError(t, checker.CheckAllInvariants(state))
+
+	// Removing the assignee leaves the task assigned to an unknown user
+	require.NoError(t, repo.DeleteUser(ctx, "bob"))
+	state, err = repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	err = checker.CheckAllInvariants(state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AssigneeExists")
+	assert.Contains(t, err.Error(), "bob")
+
+	// Further actions are rejected while the violation persists
+	_, err = uc.CreateTask(ctx, "Other", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+}
+
+// TestSessionConsistencyInvariant verifies the current user must hold a valid
+// session and that sessions belong to known users
+func TestSessionConsistencyInvariant(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	consistent := func(t *testing.T) *domain.SystemState {
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		require.NoError(t, checker.CheckAllInvariants(state))
+		return state
+	}
+	assertViolated := func(t *testing.T, state *domain.SystemState, detail string) {
+		err := checker.CheckAllInvariants(state)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SessionConsistency")
+		assert.Contains(t, err.Error(), detail)
+	}
+
+	t.Run("CurrentUserWithoutSession", func(t *testing.T) {
+		state := consistent(t)
+		delete(state.Sessions, "alice")
+		assertViolated(t, state, "current user alice has no valid session")
+	})
+
+	t.Run("CurrentUserWithInactiveSession", func(t *testing.T) {
+		state := consistent(t)
+		state.Sessions["alice"].Active = false
+		assertViolated(t, state, "current user alice has no valid session")
+	})
+
+	t.Run("CurrentUserWithExpiredSession", func(t *testing.T) {
+		state := consistent(t)
+		state.Sessions["alice"].ExpiresAt = state.Clock.Add(-time.Minute)
+		assertViolated(t, state, "current user alice has no valid session")
+	})
+
+	t.Run("SessionForUnknownUser", func(t *testing.T) {
+		state := consistent(t)
+		state.Sessions["ghost"] = &domain.Session{
+			UserID: "ghost", Token: "t", Active: true, ExpiresAt: state.Clock.Add(time.Hour),
+		}
+		assertViolated(t, state, "unknown user ghost")
+	})
+
+	t.Run("SessionWithoutCurrentUser", func(t *testing.T) {
+		state := consistent(t)
+		state.CurrentUser = nil
+		assert.NoError(t, checker.CheckAllInvariants(state))
+	})
+
+	t.Run("ImportRejected", func(t *testing.T) {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "admin", Name: "Admin", Email: "admin@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now(),
+		}))
+		require.NoError(t, uc.Logout(ctx, "alice"))
+		_, err := uc.Authenticate(ctx, "admin")
+		require.NoError(t, err)
+
+		// The logged-in admin keeps their session, which the imported
+		// users must account for
+		state := consistent(t)
+		delete(state.Users, "admin")
+		err = uc.ImportState(ctx, state)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+		assert.Contains(t, err.Error(), "SessionConsistency")
+	})
+}
+
+// TestDisabledInvariants verifies a checker skips the invariants it is
+// configured to disable and still checks the rest
+func TestDisabledInvariants(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	// Orphan the task, as a migration in progress might
+	require.NoError(t, repo.RemoveUserTask(ctx, "alice", task.ID))
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+
+	defaults := invariants.NewInvariantChecker()
+	for _, name := range invariants.SafetyInvariantNames() {
+		assert.True(t, defaults.IsEnabled(name), "%s is enabled by default", name)
+	}
+	err = defaults.CheckAllInvariants(state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NoOrphanTasks")
+
+	// An orphaned task is also missing from its assignee's list, which
+	// TaskOwnership still reports
+	noOrphans := invariants.NewInvariantCheckerWithConfig(invariants.Config{
+		DisabledInvariants: []string{"NoOrphanTasks"},
+	})
+	assert.False(t, noOrphans.IsEnabled("NoOrphanTasks"))
+	assert.True(t, noOrphans.IsEnabled("TaskOwnership"))
+	err = noOrphans.CheckAllInvariants(state)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "NoOrphanTasks")
+	assert.Contains(t, err.Error(), "TaskOwnership")
+
+	relaxed := invariants.NewInvariantCheckerWithConfig(invariants.Config{
+		DisabledInvariants: []string{"NoOrphanTasks", "TaskOwnership"},
+	})
+	assert.NoError(t, relaxed.CheckAllInvariants(state), "the orphan state passes")
+
+	report := relaxed.Report(state)
+	assert.True(t, report.Healthy)
+	require.Len(t, report.Invariants, len(invariants.SafetyInvariantNames()))
+	for _, result := range report.Invariants {
+		disabled := result.Name == "NoOrphanTasks" || result.Name == "TaskOwnership"
+		assert.Equal(t, disabled, result.Disabled, result.Name)
+		assert.True(t, result.Passed, result.Name)
+	}
+
+	// The other invariants are still enforced
+	state.Tasks[task.ID].Assignee = "nobody"
+	err = relaxed.CheckAllInvariants(state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AssigneeExists")
+}
+
+// TestDueSoonWarnings verifies the due-date reminder window of the liveness checker
+func TestDueSoonWarnings(t *testing.T) {
+	threshold := 48 * time.Hour
+	checker := invariants.NewInvariantCheckerWithConfig(invariants.Config{DueSoonThreshold: threshold})
+
+	stateWithDue := func(due time.Time) *domain.SystemState {
+		state := domain.NewSystemState()
+		state.Tasks[1] = &domain.Task{
+			ID:        1,
+			Status:    domain.StatusInProgress,
+			Priority:  domain.PriorityMedium,
+			CreatedAt: state.Clock,
+			UpdatedAt: state.Clock,
+			DueDate:   &due,
+		}
+		return state
+	}
+	hasDueSoon := func(warnings []string) bool {
+		for _, w := range warnings {
+			if strings.Contains(w, "due soon") {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("JustInsideWindow", func(t *testing.T) {
+		state := stateWithDue(time.Now().Add(threshold - time.Minute))
+		assert.True(t, hasDueSoon(checker.CheckLivenessProperties(state)))
+	})
+
+	t.Run("JustOutsideWindow", func(t *testing.T) {
+		state := stateWithDue(time.Now().Add(threshold + time.Minute))
+		assert.False(t, hasDueSoon(checker.CheckLivenessProperties(state)))
+	})
+
+	t.Run("CompletedTaskIgnored", func(t *testing.T) {
+		state := stateWithDue(time.Now().Add(time.Hour))
+		state.Tasks[1].Status = domain.StatusCompleted
+		assert.False(t, hasDueSoon(checker.CheckLivenessProperties(state)))
+	})
+
+	t.Run("DefaultCheckerDisabled", func(t *testing.T) {
+		state := stateWithDue(time.Now().Add(time.Hour))
+		assert.False(t, hasDueSoon(invariants.NewInvariantChecker().CheckLivenessProperties(state)))
+	})
+
+	t.Run("StructuredWarning", func(t *testing.T) {
+		state := stateWithDue(time.Now().Add(time.Hour))
+		warnings := checker.LivenessWarnings(state)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, invariants.WarningDueSoon, warnings[0].Kind)
+		assert.Equal(t, domain.TaskID(1), warnings[0].TaskID)
+		assert.Equal(t, checker.CheckLivenessProperties(state), []string{warnings[0].Message})
+	})
+}
+
+// TestStuckInProgressWarnings verifies the stale pending and stuck in progress
+// liveness warnings honor their configured thresholds
+func TestStuckInProgressWarnings(t *testing.T) {
+	stateWith := func(status domain.TaskStatus, idle time.Duration) *domain.SystemState {
+		state := domain.NewSystemState()
+		since := state.Clock.Add(-idle)
+		state.Tasks[1] = &domain.Task{
+			ID:        1,
+			Status:    status,
+			Priority:  domain.PriorityMedium,
+			CreatedAt: since,
+			UpdatedAt: since,
+		}
+		return state
+	}
+	kinds := func(checker *invariants.InvariantChecker, state *domain.SystemState) []string {
+		var kinds []string
+		for _, warning := range checker.LivenessWarnings(state) {
+			kinds = append(kinds, warning.Kind)
+		}
+		return kinds
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		checker := invariants.NewInvariantChecker()
+		week := invariants.DefaultStuckInProgressThreshold
+		assert.Empty(t, kinds(checker, stateWith(domain.StatusInProgress, week-time.Hour)))
+		assert.Equal(t, []string{invariants.WarningStuckInProgress}, kinds(checker, stateWith(domain.StatusInProgress, week+time.Hour)))
+		assert.Equal(t, []string{invariants.WarningStalePending}, kinds(checker, stateWith(domain.StatusPending, week+time.Hour)))
+		assert.Empty(t, kinds(checker, stateWith(domain.StatusCompleted, week+time.Hour)))
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		checker := invariants.NewInvariantCheckerWithConfig(invariants.Config{
+			StalePendingThreshold:    time.Hour,
+			StuckInProgressThreshold: 2 * time.Hour,
+		})
+		assert.Equal(t, []string{invariants.WarningStalePending}, kinds(checker, stateWith(domain.StatusPending, 90*time.Minute)))
+		assert.Empty(t, kinds(checker, stateWith(domain.StatusInProgress, 90*time.Minute)))
+
+		state := stateWith(domain.StatusInProgress, 3*time.Hour)
+		warnings := checker.LivenessWarnings(state)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, invariants.WarningStuckInProgress, warnings[0].Kind)
+		assert.Equal(t, domain.TaskID(1), warnings[0].TaskID)
+
+		// A recent update resets the clock even if the task started long ago
+		state.Tasks[1].UpdatedAt = state.Clock.Add(-time.Minute)
+		assert.Empty(t, kinds(checker, state))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		checker := invariants.NewInvariantCheckerWithConfig(invariants.Config{
+			StalePendingThreshold:    -1,
+			StuckInProgressThreshold: -1,
+		})
+		assert.Empty(t, kinds(checker, stateWith(domain.StatusPending, 30*24*time.Hour)))
+		assert.Empty(t, kinds(checker, stateWith(domain.StatusInProgress, 30*24*time.Hour)))
+	})
+}
+
 // TestPropertyTaskOwnership verifies task ownership invariants
 func TestPropertyTaskOwnership(t *testing.T) {
+	ctx := context.Background()
 	repo := memory.NewMemoryRepository()
 	uow := memory.NewMemoryUnitOfWork(repo)
 	checker := invariants.NewInvariantChecker()
@@ -180,15 +620,16 @@ func TestPropertyTaskOwnership(t *testing.T) {
 			Email:    string(userID) + "@example.com",
 			JoinedAt: time.Now(),
 		}
-		repo.CreateUser(user)
+		repo.CreateUser(ctx, user)
 	}
 
 	// Property: Task reassignment maintains ownership invariants
 	t.Run("ReassignmentMaintainsOwnership", func(t *testing.T) {
-		uc.Authenticate("alice")
+		uc.Authenticate(ctx, "alice")
 
 		// Create task assigned to Alice
 		task, err := uc.CreateTask(
+			ctx,
 			"Test Task",
 			"Description",
 			domain.PriorityHigh,
@@ -200,18 +641,18 @@ func TestPropertyTaskOwnership(t *testing.T) {
 		require.NoError(t, err)
 
 		// Check initial ownership
-		state, _ := repo.GetSystemState()
+		state, _ := repo.GetSystemState(ctx)
 		assert.NoError(t, checker.CheckAllInvariants(state))
 
 		aliceTasks := state.GetUserTasks("alice")
 		assert.Contains(t, aliceTasks, task.ID)
 
 		// Reassign to Bob
-		err = uc.ReassignTask(task.ID, "bob")
+		err = uc.ReassignTask(ctx, task.ID, "bob")
 		require.NoError(t, err)
 
 		// Check ownership after reassignment
-		state, _ = repo.GetSystemState()
+		state, _ = repo.GetSystemState(ctx)
 		assert.NoError(t, checker.CheckAllInvariants(state))
 
 		aliceTasks = state.GetUserTasks("alice")
@@ -219,10 +660,31 @@ func TestPropertyTaskOwnership(t *testing.T) {
 		assert.NotContains(t, aliceTasks, task.ID)
 		assert.Contains(t, bobTasks, task.ID)
 	})
+
+	// Property: Reassignment leaves the task in exactly one task list, once
+	t.Run("ReassignmentSingleMembership", func(t *testing.T) {
+		task, err := uc.CreateTask(ctx, "Moved", "Description", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, uc.ReassignTask(ctx, task.ID, "bob"))
+		require.NoError(t, uc.ReassignTask(ctx, task.ID, "bob"))
+
+		state, _ := repo.GetSystemState(ctx)
+		occurrences := map[domain.UserID]int{}
+		for userID, taskIDs := range state.UserTasks {
+			for _, id := range taskIDs {
+				if id == task.ID {
+					occurrences[userID]++
+				}
+			}
+		}
+		assert.Equal(t, map[domain.UserID]int{"bob": 1}, occurrences)
+	})
 }
 
 // TestPropertyConcurrentOperations tests invariants under concurrent operations
 func TestPropertyConcurrentOperations(t *testing.T) {
+	ctx := context.Background()
 	repo := memory.NewMemoryRepository()
 	uow := memory.NewMemoryUnitOfWork(repo)
 	checker := invariants.NewInvariantChecker()
@@ -236,7 +698,7 @@ func TestPropertyConcurrentOperations(t *testing.T) {
 			Email:    string(userID) + "@example.com",
 			JoinedAt: time.Now(),
 		}
-		repo.CreateUser(user)
+		repo.CreateUser(ctx, user)
 	}
 
 	// Run concurrent operations
@@ -247,11 +709,12 @@ func TestPropertyConcurrentOperations(t *testing.T) {
 			uc := usecase.NewTaskUseCase(uow, checker)
 
 			// Authenticate
-			uc.Authenticate(uid)
+			uc.Authenticate(ctx, uid)
 
 			// Create multiple tasks
 			for i := 0; i < 5; i++ {
 				uc.CreateTask(
+					ctx,
 					"Task",
 					"Description",
 					randomPriority(),
@@ -275,7 +738,7 @@ func TestPropertyConcurrentOperations(t *testing.T) {
 	}
 
 	// Check invariants after concurrent operations
-	state, _ := repo.GetSystemState()
+	state, _ := repo.GetSystemState(ctx)
 	assert.NoError(t, checker.CheckAllInvariants(state))
 }
 
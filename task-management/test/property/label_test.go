@@ -0,0 +1,111 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestLabels verifies labels can be replaced, are validated and bounded,
+// and that tasks can be found by label
+func TestLabels(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	first, err := uc.CreateTask(ctx, "First", "Desc", domain.PriorityLow, "alice", nil, []domain.Tag{domain.TagBug}, nil)
+	require.NoError(t, err)
+	second, err := uc.CreateTask(ctx, "Second", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = uc.SetLabels(ctx, first.ID, map[string]string{"sprint": "12", "component": "auth"})
+	require.NoError(t, err)
+	_, err = uc.SetLabels(ctx, second.ID, map[string]string{"sprint": "12"})
+	require.NoError(t, err)
+
+	stored, err := repo.GetTask(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"sprint": "12", "component": "auth"}, stored.Labels)
+	assert.Equal(t, []domain.Tag{domain.TagBug}, stored.Tags, "labels complement tags")
+
+	ids := func(tasks []*domain.Task) []domain.TaskID {
+		result := make([]domain.TaskID, len(tasks))
+		for i, task := range tasks {
+			result[i] = task.ID
+		}
+		return result
+	}
+	found, err := uc.FindByLabel(ctx, "sprint", "12")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{first.ID, second.ID}, ids(found))
+	found, err = uc.FindByLabel(ctx, "component", "auth")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{first.ID}, ids(found))
+	found, err = uc.FindByLabel(ctx, "sprint", "13")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := uc.SetLabels(ctx, first.ID, map[string]string{" ": "x"})
+		assert.ErrorIs(t, err, domain.ErrInvalidLabel)
+
+		tooMany := make(map[string]string, domain.MaxLabels+1)
+		for i := 0; i <= domain.MaxLabels; i++ {
+			tooMany[fmt.Sprintf("key%d", i)] = "v"
+		}
+		_, err = uc.SetLabels(ctx, first.ID, tooMany)
+		assert.ErrorIs(t, err, domain.ErrInvalidLabel)
+
+		stored, err := repo.GetTask(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Len(t, stored.Labels, 2, "rejected updates leave the labels unchanged")
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		router := mux.NewRouter()
+		router.HandleFunc("/tasks/{id}/labels", handlers.NewTaskHandler(uc).SetLabels).Methods("PUT")
+		put := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/tasks/%d/labels", second.ID), strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec
+		}
+
+		rec := put(`{"labels": {"sprint": "13"}}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"labels":{"sprint":"13"}`)
+
+		rec = put(`{"labels": {"": "x"}}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid_label")
+
+		// An empty map clears the labels
+		rec = put(`{"labels": {}}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		stored, err := repo.GetTask(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Labels)
+	})
+}
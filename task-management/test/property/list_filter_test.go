@@ -0,0 +1,72 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestListHidesDoneTasks verifies GET /tasks leaves out completed and
+// cancelled tasks unless include_done=true, and that other listings keep them
+func TestListHidesDoneTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		_, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+	}
+	require.NoError(t, uc.UpdateTaskStatus(ctx, 1, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, 1, domain.StatusCompleted))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, 2, domain.StatusCancelled))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, 3, domain.StatusInProgress))
+
+	list := func(t *testing.T, target string) []domain.TaskID {
+		rec := httptest.NewRecorder()
+		handler.ListTasks(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var body []struct {
+			ID domain.TaskID `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		ids := make([]domain.TaskID, len(body))
+		for i, task := range body {
+			ids[i] = task.ID
+		}
+		return ids
+	}
+
+	assert.Equal(t, []domain.TaskID{3, 4}, list(t, "/tasks"))
+	assert.Equal(t, []domain.TaskID{3, 4}, list(t, "/tasks?include_done=false"))
+	assert.Equal(t, []domain.TaskID{1, 2, 3, 4}, list(t, "/tasks?include_done=true"))
+
+	// Done tasks stay visible outside the default listing
+	userTasks, err := uc.GetTasksByUser(ctx, "alice", "", false, false, usecase.SortByID)
+	require.NoError(t, err)
+	assert.Len(t, userTasks, 4)
+	completed, err := repo.GetTask(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCompleted, completed.Status)
+}
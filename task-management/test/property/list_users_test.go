@@ -0,0 +1,131 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestListUsers verifies users can be filtered by role and join date, sorted
+// by name or join date with ties broken by ID, and paged with a total count
+func TestListUsers(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+		SessionDuration: 24 * time.Hour,
+		Clock:           clock,
+	})
+
+	users := []domain.User{
+		{ID: "erin", Name: "erin", Role: domain.RoleAdmin, JoinedAt: start.Add(2 * time.Hour)},
+		{ID: "dave", Name: "Dave", Role: domain.RoleMember, JoinedAt: start},
+		{ID: "carol", Name: "Carol", JoinedAt: start.Add(3 * time.Hour)},
+		{ID: "bob", Name: "Bob", Role: domain.RoleMember, JoinedAt: start.Add(time.Hour)},
+		{ID: "alice", Name: "Alice", Role: domain.RoleAdmin, JoinedAt: start.Add(time.Hour)},
+		{ID: "amy", Name: "alice", Role: domain.RoleMember, JoinedAt: start.Add(4 * time.Hour)},
+	}
+	for i := range users {
+		users[i].Email = string(users[i].ID) + "@example.com"
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+
+	_, _, err := uc.ListUsers(ctx, domain.UserFilter{}, 0, 0)
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	_, err = uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	ids := func(users []*domain.User) []domain.UserID {
+		result := make([]domain.UserID, 0, len(users))
+		for _, user := range users {
+			result = append(result, user.ID)
+		}
+		return result
+	}
+	list := func(filter domain.UserFilter, offset, limit int) ([]domain.UserID, int) {
+		users, total, err := uc.ListUsers(ctx, filter, offset, limit)
+		require.NoError(t, err)
+		return ids(users), total
+	}
+
+	listed, total := list(domain.UserFilter{}, 0, 0)
+	assert.Equal(t, []domain.UserID{"alice", "amy", "bob", "carol", "dave", "erin"}, listed, "by ID by default")
+	assert.Equal(t, 6, total)
+
+	listed, _ = list(domain.UserFilter{Sort: domain.UserSortByName}, 0, 0)
+	assert.Equal(t, []domain.UserID{"alice", "amy", "bob", "carol", "dave", "erin"}, listed,
+		"names compare case-insensitively, ties by ID")
+
+	listed, _ = list(domain.UserFilter{Sort: domain.UserSortByJoinedAt}, 0, 0)
+	assert.Equal(t, []domain.UserID{"dave", "alice", "bob", "erin", "carol", "amy"}, listed)
+
+	listed, total = list(domain.UserFilter{Role: domain.RoleMember}, 0, 0)
+	assert.Equal(t, []domain.UserID{"amy", "bob", "carol", "dave"}, listed, "users without a role are members")
+	assert.Equal(t, 4, total)
+
+	listed, _ = list(domain.UserFilter{JoinedAfter: start.Add(time.Hour)}, 0, 0)
+	assert.Equal(t, []domain.UserID{"amy", "carol", "erin"}, listed, "joined_after is exclusive")
+
+	// Pages cover the listing without gaps or repeats
+	var paged []domain.UserID
+	for offset := 0; offset < 6; offset += 4 {
+		page, total := list(domain.UserFilter{Sort: domain.UserSortByJoinedAt}, offset, 4)
+		assert.Equal(t, 6, total)
+		paged = append(paged, page...)
+	}
+	assert.Equal(t, []domain.UserID{"dave", "alice", "bob", "erin", "carol", "amy"}, paged)
+	listed, total = list(domain.UserFilter{}, 10, 4)
+	assert.Empty(t, listed)
+	assert.Equal(t, 6, total)
+
+	_, _, err = uc.ListUsers(ctx, domain.UserFilter{Sort: "email"}, 0, 0)
+	assert.ErrorIs(t, err, domain.ErrInvalidSortKey)
+	_, _, err = uc.ListUsers(ctx, domain.UserFilter{}, 0, usecase.MaxUserLimit+1)
+	assert.ErrorIs(t, err, domain.ErrInvalidPage)
+	_, _, err = uc.ListUsers(ctx, domain.UserFilter{}, -1, 0)
+	assert.ErrorIs(t, err, domain.ErrInvalidPage)
+
+	t.Run("Handler", func(t *testing.T) {
+		handler := handlers.NewTaskHandler(uc)
+		get := func(query string) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			handler.ListUsers(rec, httptest.NewRequest(http.MethodGet, "/users"+query, nil))
+			return rec
+		}
+
+		rec := get("?role=member&joined_after=2030-01-01T09:30:00Z&sort=name&offset=1&limit=2")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "3", rec.Header().Get("X-Total-Count"))
+		var page []*domain.User
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		assert.Equal(t, []domain.UserID{"bob", "carol"}, ids(page))
+
+		rec = get("?offset=10")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "6", rec.Header().Get("X-Total-Count"))
+		assert.JSONEq(t, "[]", rec.Body.String())
+
+		rec = get("?sort=email")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid_sort_key")
+		rec = get("?limit=1000")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid_page")
+		assert.Equal(t, http.StatusBadRequest, get("?joined_after=yesterday").Code)
+		assert.Equal(t, http.StatusBadRequest, get("?role=owner").Code)
+	})
+}
@@ -0,0 +1,138 @@
+package property
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// streamedWarning is one server-sent event of the liveness stream
+type streamedWarning struct {
+	event   string
+	warning invariants.LivenessWarning
+}
+
+// TestLivenessStream verifies the liveness stream sends each warning once
+// when it appears and once when it is resolved, however many checks see it,
+// and stops when the client disconnects
+func TestLivenessStream(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantCheckerWithConfig(invariants.Config{Clock: clock})
+	uc := usecase.NewTaskUseCaseWithConfig(uow, checker, usecase.Config{
+		SessionDuration: 24 * time.Hour,
+		Clock:           clock,
+	})
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	past := start.Add(-time.Hour)
+	overdue := func() domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", &past, nil, nil)
+		require.NoError(t, err)
+		return task.ID
+	}
+	first := overdue()
+
+	handler := handlers.NewInvariantHandler(repo, checker)
+	handler.SetStreamInterval(5 * time.Millisecond)
+	stopped := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(stopped)
+		handler.LivenessStream(w, r)
+	}))
+	defer server.Close()
+
+	streamCtx, disconnect := context.WithCancel(ctx)
+	defer disconnect()
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	received := make(chan streamedWarning, 16)
+	go func() {
+		defer close(received)
+		scanner := bufio.NewScanner(resp.Body)
+		var event string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var warning invariants.LivenessWarning
+				if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &warning) == nil {
+					received <- streamedWarning{event, warning}
+				}
+			}
+		}
+	}()
+	next := func() streamedWarning {
+		select {
+		case sw := <-received:
+			return sw
+		case <-time.After(2 * time.Second):
+			t.Fatal("no event received")
+			return streamedWarning{}
+		}
+	}
+	quiet := func() {
+		select {
+		case sw := <-received:
+			t.Fatalf("unexpected event %s for task %d", sw.event, sw.warning.TaskID)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	sw := next()
+	assert.Equal(t, "warning", sw.event)
+	assert.Equal(t, invariants.WarningOverdue, sw.warning.Kind)
+	assert.Equal(t, first, sw.warning.TaskID)
+	quiet()
+
+	// Completing the task resolves its warning; a new overdue task appears
+	require.NoError(t, uc.UpdateTaskStatus(ctx, first, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, first, domain.StatusCompleted))
+	sw = next()
+	assert.Equal(t, "resolved", sw.event)
+	assert.Equal(t, first, sw.warning.TaskID)
+	quiet()
+
+	second := overdue()
+	sw = next()
+	assert.Equal(t, "warning", sw.event)
+	assert.Equal(t, second, sw.warning.TaskID)
+	quiet()
+
+	disconnect()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the stream kept running after the client disconnected")
+	}
+}
@@ -0,0 +1,52 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/notifications"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAssignmentNotifications verifies assignees are notified of tasks others give them
+func TestAssignmentNotifications(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	store := notifications.NewStore()
+	uc.SetNotificationService(store)
+
+	for _, userID := range []domain.UserID{"alice", "bob", "charlie"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: userID, Name: string(userID), Email: string(userID) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	own, err := uc.CreateTask(ctx, "Own", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, store.Notifications("alice"), "creating a task for yourself does not notify")
+
+	delegated, err := uc.CreateTask(ctx, "Delegated", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+	require.NoError(t, err)
+	bobs := store.Notifications("bob")
+	require.Len(t, bobs, 1)
+	assert.Equal(t, domain.UserID("bob"), bobs[0].UserID)
+	assert.Contains(t, bobs[0].Message, `"Delegated"`)
+
+	require.NoError(t, uc.ReassignTask(ctx, own.ID, "charlie"))
+	assert.Len(t, store.Notifications("charlie"), 1)
+
+	// Reassigning to yourself does not notify
+	require.NoError(t, uc.ReassignTask(ctx, delegated.ID, "alice"))
+	assert.Empty(t, store.Notifications("alice"))
+	assert.Len(t, store.Notifications("bob"), 1)
+}
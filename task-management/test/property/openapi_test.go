@@ -0,0 +1,102 @@
+package property
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// openAPIDoc is the subset of an OpenAPI 3 document the test inspects
+type openAPIDoc struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Type       string                     `json:"type"`
+			Enum       []string                   `json:"enum"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+// TestOpenAPI verifies GET /openapi.json serves a spec describing the routes,
+// request bodies, enums and error shape, with every reference resolvable
+func TestOpenAPI(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	uc := usecase.NewTaskUseCase(memory.NewMemoryUnitOfWork(repo), invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	rec := httptest.NewRecorder()
+	handler.OpenAPI(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var doc openAPIDoc
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.True(t, strings.HasPrefix(doc.OpenAPI, "3."))
+
+	for path, methods := range map[string][]string{
+		"/tasks":                           {"get", "post"},
+		"/tasks/{id}":                      {"patch", "delete"},
+		"/tasks/{id}/status":               {"put"},
+		"/tasks/{id}/dependencies/{depId}": {"post", "delete"},
+		"/auth/login":                      {"post"},
+		"/openapi.json":                    {"get"},
+	} {
+		require.Contains(t, doc.Paths, path)
+		for _, method := range methods {
+			assert.Contains(t, doc.Paths[path], method, path)
+		}
+	}
+
+	schemas := doc.Components.Schemas
+	assert.Equal(t, []string{"pending", "in_progress", "completed", "cancelled", "blocked"}, schemas["TaskStatus"].Enum)
+	assert.Equal(t, []string{"low", "medium", "high", "critical"}, schemas["Priority"].Enum)
+	assert.Equal(t, []string{"bug", "feature", "enhancement", "documentation"}, schemas["Tag"].Enum)
+
+	require.Contains(t, schemas, "CreateTaskRequest")
+	for _, field := range []string{"title", "description", "priority", "assignee", "due_date", "tags", "dependencies"} {
+		assert.Contains(t, schemas["CreateTaskRequest"].Properties, field)
+	}
+	assert.JSONEq(t, `{"$ref": "#/components/schemas/Priority"}`, string(schemas["CreateTaskRequest"].Properties["priority"]))
+
+	require.Contains(t, schemas, "ErrorResponse")
+	for _, field := range []string{"error", "code", "details"} {
+		assert.Contains(t, schemas["ErrorResponse"].Properties, field)
+	}
+
+	// TaskResponse flattens the embedded task alongside dependency_progress
+	assert.Contains(t, schemas["TaskResponse"].Properties, "status")
+	assert.Contains(t, schemas["TaskResponse"].Properties, "dependency_progress")
+
+	for _, ref := range findRefs(rec.Body.String()) {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		assert.Contains(t, schemas, name, "unresolved reference %s", ref)
+	}
+}
+
+// findRefs returns every $ref target in a JSON document
+func findRefs(body string) []string {
+	var refs []string
+	const marker = `"$ref":"`
+	for {
+		i := strings.Index(body, marker)
+		if i < 0 {
+			return refs
+		}
+		body = body[i+len(marker):]
+		end := strings.IndexByte(body, '"')
+		refs = append(refs, body[:end])
+		body = body[end:]
+	}
+}
@@ -0,0 +1,101 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestOverdueTasksForUser verifies GetOverdueTasksForUser returns the user's
+// open tasks past their due date, most overdue first, matching the liveness
+// checker's overdue warnings
+func TestOverdueTasksForUser(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+	router := mux.NewRouter()
+	router.HandleFunc("/users/{id}/tasks/overdue", handlers.NewTaskHandler(uc).GetOverdueTasksForUser).Methods("GET")
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	now := time.Now()
+	due := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+	create := func(assignee domain.UserID, dueDate *time.Time) *domain.Task {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityMedium, assignee, dueDate, nil, nil)
+		require.NoError(t, err)
+		return task
+	}
+
+	oneDay := create("alice", due(-24*time.Hour))
+	threeDays := create("alice", due(-72*time.Hour))
+	twoDays := create("alice", due(-48*time.Hour))
+	inProgress := create("alice", due(-time.Hour))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, inProgress.ID, domain.StatusInProgress))
+	create("alice", due(24*time.Hour))
+	create("alice", nil)
+	completed := create("alice", due(-96*time.Hour))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, completed.ID, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, completed.ID, domain.StatusCompleted))
+	bobs := create("bob", due(-24*time.Hour))
+
+	overdue, err := uc.GetOverdueTasksForUser(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{threeDays.ID, twoDays.ID, oneDay.ID, inProgress.ID}, taskIDs(overdue))
+
+	// The liveness checker flags the same tasks, plus bob's
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	var flagged []domain.TaskID
+	for _, warning := range checker.LivenessWarnings(state) {
+		if warning.Kind == invariants.WarningOverdue {
+			flagged = append(flagged, warning.TaskID)
+		}
+	}
+	assert.ElementsMatch(t, append(taskIDs(overdue), bobs.ID), flagged)
+
+	t.Run("Handler", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/bob/tasks/overdue", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var tasks []handlers.TaskResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+		require.Len(t, tasks, 1)
+		assert.Equal(t, bobs.ID, tasks[0].ID)
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/nobody/tasks/overdue", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func taskIDs(tasks []*domain.Task) []domain.TaskID {
+	ids := make([]domain.TaskID, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
@@ -0,0 +1,135 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestPasswordLogin verifies login succeeds only with the user's password,
+// failing with 401 invalid_credentials otherwise, unless authentication is
+// disabled for development
+func TestPasswordLogin(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T, noAuth bool) (*memory.MemoryRepository, *usecase.TaskUseCase) {
+		clock := domain.NewFakeClock(start)
+		repo := memory.NewMemoryRepositoryWithClock(clock)
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			SessionDuration: 24 * time.Hour,
+			Clock:           clock,
+			NoAuth:          noAuth,
+		})
+
+		hash, err := domain.HashPassword("alice-dev")
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleAdmin, JoinedAt: start, PasswordHash: hash,
+		}))
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "bob", Name: "Bob", Email: "bob@example.com", JoinedAt: start,
+		}))
+		return repo, uc
+	}
+	login := func(t *testing.T, uc *usecase.TaskUseCase, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handlers.NewTaskHandler(uc).Login(rec, req)
+		return rec
+	}
+
+	t.Run("Credentials", func(t *testing.T) {
+		repo, uc := setup(t, false)
+
+		rejected := []string{
+			`{"user_id": "alice", "password": "wrong"}`,
+			`{"user_id": "alice"}`,
+			`{"user_id": "bob", "password": ""}`,
+			`{"user_id": "mallory", "password": "alice-dev"}`,
+		}
+		for _, body := range rejected {
+			rec := login(t, uc, body)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code, body)
+			assert.Contains(t, rec.Body.String(), "invalid_credentials", body)
+		}
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, state.Sessions, "a failed login opens no session")
+
+		rec := login(t, uc, `{"user_id": "alice", "password": "alice-dev"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var session domain.Session
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &session))
+		assert.Equal(t, domain.UserID("alice"), session.UserID)
+		assert.NotEmpty(t, session.Token)
+
+		_, err = uc.Login(ctx, "alice", "wrong", false)
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		_, uc := setup(t, true)
+
+		rec := login(t, uc, `{"user_id": "bob"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		rec = login(t, uc, `{"user_id": "alice", "password": "wrong"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		rec = login(t, uc, `{"user_id": "mallory"}`)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "unknown users still cannot log in")
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		_, uc := setup(t, false)
+
+		user, created, err := uc.RegisterUser(ctx, "dave", "Dave", "dave@example.com", "s3cret")
+		require.NoError(t, err)
+		require.True(t, created)
+		body, err := json.Marshal(user)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), user.PasswordHash, "the hash is never serialized")
+
+		_, err = uc.Login(ctx, "dave", "other", false)
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		_, err = uc.Login(ctx, "dave", "s3cret", false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		_, uc := setup(t, false)
+		_, err := uc.Login(ctx, "alice", "alice-dev", false)
+		require.NoError(t, err)
+
+		exported, err := uc.ExportState(ctx)
+		require.NoError(t, err)
+		data, err := json.Marshal(exported)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "$2a$", "exported state carries no hashes")
+
+		var imported domain.SystemState
+		require.NoError(t, json.Unmarshal(data, &imported))
+		require.NoError(t, uc.ImportState(ctx, &imported))
+		require.NoError(t, uc.Logout(ctx, "alice"))
+
+		_, err = uc.Login(ctx, "alice", "wrong", false)
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		_, err = uc.Login(ctx, "alice", "alice-dev", false)
+		assert.NoError(t, err, "imported users keep their password")
+	})
+}
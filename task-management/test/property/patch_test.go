@@ -0,0 +1,120 @@
+package property
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestPatchTask verifies partial updates only change the provided fields
+func TestPatchTask(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*memory.MemoryRepository, *usecase.TaskUseCase, *domain.Task) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		due := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		task, err := uc.CreateTask(ctx, "Title", "Desc", domain.PriorityLow, "alice", &due,
+			[]domain.Tag{domain.TagBug}, nil)
+		require.NoError(t, err)
+		return repo, uc, task
+	}
+
+	t.Run("OnlyPriority", func(t *testing.T) {
+		repo, uc, task := setup(t)
+		priority := domain.PriorityHigh
+
+		_, err := uc.PatchTask(ctx, task.ID, usecase.TaskPatch{Priority: &priority})
+		require.NoError(t, err)
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PriorityHigh, stored.Priority)
+		assert.Equal(t, "Title", stored.Title)
+		assert.Equal(t, "Desc", stored.Description)
+		assert.Equal(t, []domain.Tag{domain.TagBug}, stored.Tags)
+		require.NotNil(t, stored.DueDate)
+		assert.True(t, task.DueDate.Equal(*stored.DueDate))
+	})
+
+	t.Run("InvalidPatchRejected", func(t *testing.T) {
+		repo, uc, task := setup(t)
+		empty := ""
+
+		_, err := uc.PatchTask(ctx, task.ID, usecase.TaskPatch{Title: &empty})
+		assert.ErrorIs(t, err, domain.ErrTitleEmpty)
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Title", stored.Title)
+	})
+
+	// patchJSON sends a PATCH request with the given body through the handler
+	patchJSON := func(t *testing.T, uc *usecase.TaskUseCase, taskID domain.TaskID, body string) *httptest.ResponseRecorder {
+		id := strconv.Itoa(int(taskID))
+		req := httptest.NewRequest(http.MethodPatch, "/tasks/"+id, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+		handlers.NewTaskHandler(uc).PatchTask(rec, req)
+		return rec
+	}
+
+	t.Run("DueDateOmittedIsUnchanged", func(t *testing.T) {
+		repo, uc, task := setup(t)
+
+		rec := patchJSON(t, uc, task.ID, `{"title": "Renamed"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", stored.Title)
+		require.NotNil(t, stored.DueDate)
+		assert.True(t, task.DueDate.Equal(*stored.DueDate))
+	})
+
+	t.Run("DueDateNullClears", func(t *testing.T) {
+		repo, uc, task := setup(t)
+
+		rec := patchJSON(t, uc, task.ID, `{"due_date": null}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.DueDate)
+		assert.Equal(t, "Title", stored.Title)
+	})
+
+	t.Run("DueDateSet", func(t *testing.T) {
+		repo, uc, task := setup(t)
+
+		rec := patchJSON(t, uc, task.ID, `{"due_date": "2030-01-02T03:04:05Z"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.DueDate)
+		assert.True(t, time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC).Equal(*stored.DueDate))
+	})
+}
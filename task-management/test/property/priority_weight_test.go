@@ -0,0 +1,88 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestPriorityWeight verifies priority weights rise with urgency, drive
+// priority sorting and are reported in task responses
+func TestPriorityWeight(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, domain.SetVocabulary(domain.DefaultVocabulary()))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		assert.Equal(t, 1, domain.PriorityLow.Weight())
+		assert.Equal(t, 2, domain.PriorityMedium.Weight())
+		assert.Equal(t, 3, domain.PriorityHigh.Weight())
+		assert.Equal(t, 4, domain.PriorityCritical.Weight())
+		assert.Equal(t, 0, domain.Priority("urgent").Weight(), "unknown priorities weigh least")
+	})
+
+	t.Run("follows the vocabulary order", func(t *testing.T) {
+		require.NoError(t, domain.SetVocabulary(domain.Vocabulary{
+			Priorities: []domain.Priority{"p3", "p2", "p1", "p0", "p00"},
+			Tags:       []domain.Tag{domain.TagBug},
+		}))
+		defer func() { require.NoError(t, domain.SetVocabulary(domain.DefaultVocabulary())) }()
+
+		priorities := domain.CurrentVocabulary().Priorities
+		for i := 1; i < len(priorities); i++ {
+			assert.Greater(t, priorities[i].Weight(), priorities[i-1].Weight())
+		}
+		assert.Equal(t, 5, domain.Priority("p00").Weight())
+		assert.True(t, domain.Priority("p00").IsHighest())
+		assert.Equal(t, 0, domain.PriorityCritical.Weight())
+	})
+
+	t.Run("sorting and responses", func(t *testing.T) {
+		ctx := context.Background()
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		for _, priority := range []domain.Priority{
+			domain.PriorityMedium, domain.PriorityCritical, domain.PriorityLow, domain.PriorityHigh,
+		} {
+			_, err := uc.CreateTask(ctx, "Task", "Desc", priority, "alice", nil, nil, nil)
+			require.NoError(t, err)
+		}
+
+		tasks, err := uc.ListTasks(ctx, usecase.SortByPriority, usecase.ListFilter{})
+		require.NoError(t, err)
+		require.Len(t, tasks, 4)
+		for i := 1; i < len(tasks); i++ {
+			assert.Greater(t, tasks[i-1].Priority.Weight(), tasks[i].Priority.Weight(), "heaviest first")
+		}
+
+		rec := httptest.NewRecorder()
+		handlers.NewTaskHandler(uc).ListTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks?sort=priority", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var listed []map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+		var weights []string
+		for _, task := range listed {
+			weights = append(weights, string(task["priority_weight"]))
+		}
+		assert.Equal(t, []string{"4", "3", "2", "1"}, weights)
+	})
+}
@@ -0,0 +1,94 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestDependencyProgress verifies the fraction of completed dependencies
+func TestDependencyProgress(t *testing.T) {
+	allTasks := map[domain.TaskID]*domain.Task{
+		1: {ID: 1, Status: domain.StatusCompleted},
+		2: {ID: 2, Status: domain.StatusCompleted},
+		3: {ID: 3, Status: domain.StatusInProgress},
+		4: {ID: 4, Status: domain.StatusPending},
+	}
+	deps := func(ids ...domain.TaskID) map[domain.TaskID]bool {
+		result := make(map[domain.TaskID]bool, len(ids))
+		for _, id := range ids {
+			result[id] = true
+		}
+		return result
+	}
+
+	cases := []struct {
+		name     string
+		deps     map[domain.TaskID]bool
+		expected float64
+	}{
+		{"NoDependencies", nil, 1.0},
+		{"Zero", deps(3, 4), 0.0},
+		{"Partial", deps(1, 3, 4, 2), 0.5},
+		{"Full", deps(1, 2), 1.0},
+		{"MissingCountsAsIncomplete", deps(1, 99), 0.5},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &domain.Task{ID: 10, Dependencies: tc.deps}
+			assert.InDelta(t, tc.expected, task.DependencyProgress(allTasks), 1e-9)
+		})
+	}
+
+	t.Run("IncludedInResponses", func(t *testing.T) {
+		ctx := context.Background()
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		first, err := uc.CreateTask(ctx, "First", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		second, err := uc.CreateTask(ctx, "Second", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		_, err = uc.CreateTask(ctx, "Third", "Desc", domain.PriorityLow, "alice", nil, nil,
+			[]domain.TaskID{first.ID, second.ID})
+		require.NoError(t, err)
+
+		require.NoError(t, uc.UpdateTaskStatus(ctx, first.ID, domain.StatusInProgress))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, first.ID, domain.StatusCompleted))
+
+		rec := httptest.NewRecorder()
+		handlers.NewTaskHandler(uc).ListTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks?include_done=true", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var body []struct {
+			ID                 domain.TaskID `json:"id"`
+			Title              string        `json:"title"`
+			DependencyProgress float64       `json:"dependency_progress"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body, 3)
+		assert.Equal(t, "First", body[0].Title)
+		assert.InDelta(t, 1.0, body[0].DependencyProgress, 1e-9)
+		assert.InDelta(t, 1.0, body[1].DependencyProgress, 1e-9)
+		assert.InDelta(t, 0.5, body[2].DependencyProgress, 1e-9)
+	})
+}
@@ -0,0 +1,161 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestBacklogRank verifies tasks can be reordered within their assignee's
+// backlog by moving only the task itself, and that GetTasksByUser returns
+// them in rank order
+func TestBacklogRank(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, count int) (*usecase.TaskUseCase, *memory.MemoryRepository, []domain.TaskID) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+		for _, id := range []domain.UserID{"alice", "bob"} {
+			require.NoError(t, repo.CreateUser(ctx, &domain.User{
+				ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+			}))
+		}
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		var ids []domain.TaskID
+		for i := 0; i < count; i++ {
+			task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+			require.NoError(t, err)
+			ids = append(ids, task.ID)
+		}
+		return uc, repo, ids
+	}
+	backlog := func(t *testing.T, uc *usecase.TaskUseCase, userID domain.UserID) []domain.TaskID {
+		tasks, err := uc.GetTasksByUser(ctx, userID, "", false, false, usecase.SortByRank)
+		require.NoError(t, err)
+		var ids []domain.TaskID
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+	rankOf := func(t *testing.T, repo *memory.MemoryRepository, id domain.TaskID) float64 {
+		task, err := repo.GetTask(ctx, id)
+		require.NoError(t, err)
+		return task.Rank
+	}
+
+	t.Run("NewTasksJoinTheEnd", func(t *testing.T) {
+		uc, repo, ids := setup(t, 3)
+		assert.Equal(t, ids, backlog(t, uc, "alice"))
+		for i, id := range ids {
+			assert.Equal(t, float64(i+1)*domain.RankGap, rankOf(t, repo, id))
+		}
+	})
+
+	t.Run("InsertBetweenTwoTasks", func(t *testing.T) {
+		uc, repo, ids := setup(t, 3)
+		first, second, third := ids[0], ids[1], ids[2]
+
+		moved, err := uc.MoveTaskBetween(ctx, third, first, second)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{first, third, second}, backlog(t, uc, "alice"))
+		assert.Equal(t, (rankOf(t, repo, first)+rankOf(t, repo, second))/2, moved.Rank)
+
+		// Only the moved task changed
+		assert.Equal(t, domain.RankGap, rankOf(t, repo, first))
+		assert.Equal(t, 2*domain.RankGap, rankOf(t, repo, second))
+
+		// Either neighbour may be left out to move to the start or end
+		_, err = uc.MoveTaskBetween(ctx, first, second, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{third, second, first}, backlog(t, uc, "alice"))
+		_, err = uc.MoveTaskBetween(ctx, first, 0, third)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{first, third, second}, backlog(t, uc, "alice"))
+
+		_, err = uc.MoveTaskBetween(ctx, second, 0, 0)
+		assert.ErrorIs(t, err, domain.ErrInvalidRank)
+		_, err = uc.MoveTaskBetween(ctx, second, third, first)
+		assert.ErrorIs(t, err, domain.ErrInvalidRank, "before must directly follow after")
+		_, err = uc.MoveTaskBetween(ctx, second, second, 0)
+		assert.ErrorIs(t, err, domain.ErrInvalidRank)
+	})
+
+	t.Run("MoveUpAndDown", func(t *testing.T) {
+		uc, _, ids := setup(t, 3)
+		first, second, third := ids[0], ids[1], ids[2]
+
+		_, err := uc.MoveTask(ctx, third, usecase.MoveUp)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{first, third, second}, backlog(t, uc, "alice"))
+		_, err = uc.MoveTask(ctx, third, usecase.MoveUp)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{third, first, second}, backlog(t, uc, "alice"))
+		_, err = uc.MoveTask(ctx, third, usecase.MoveUp)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{third, first, second}, backlog(t, uc, "alice"), "the first task stays first")
+
+		_, err = uc.MoveTask(ctx, first, usecase.MoveDown)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{third, second, first}, backlog(t, uc, "alice"))
+		_, err = uc.MoveTask(ctx, first, usecase.MoveDown)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{third, second, first}, backlog(t, uc, "alice"), "the last task stays last")
+
+		_, err = uc.MoveTask(ctx, first, "sideways")
+		assert.ErrorIs(t, err, domain.ErrInvalidRank)
+	})
+
+	t.Run("RenumbersWhenGapsRunOut", func(t *testing.T) {
+		uc, repo, ids := setup(t, 3)
+		first, second, third := ids[0], ids[1], ids[2]
+
+		// Repeatedly inserting at the same spot halves the gap each time
+		// until no float lies between the neighbours
+		moving := []domain.TaskID{second, third}
+		for i := 0; i < 80; i++ {
+			mover, other := moving[i%2], moving[(i+1)%2]
+			_, err := uc.MoveTaskBetween(ctx, mover, first, other)
+			require.NoError(t, err)
+			assert.Equal(t, []domain.TaskID{first, mover, other}, backlog(t, uc, "alice"))
+		}
+		for _, id := range ids {
+			rank := rankOf(t, repo, id)
+			assert.Greater(t, rank, 0.0)
+		}
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.NoError(t, invariants.NewInvariantChecker().CheckAllInvariants(state))
+	})
+
+	t.Run("SetRankAndReassign", func(t *testing.T) {
+		uc, repo, ids := setup(t, 3)
+		first, second, third := ids[0], ids[1], ids[2]
+
+		_, err := uc.SetTaskRank(ctx, first, 2.5*domain.RankGap)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{second, first, third}, backlog(t, uc, "alice"))
+
+		bobs, err := uc.CreateTask(ctx, "Bob's", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RankGap, bobs.Rank, "each assignee has their own backlog")
+
+		require.NoError(t, uc.ReassignTask(ctx, second, "bob"))
+		assert.Equal(t, []domain.TaskID{bobs.ID, second}, backlog(t, uc, "bob"))
+		assert.Equal(t, 2*domain.RankGap, rankOf(t, repo, second))
+
+		_, err = uc.MoveTaskBetween(ctx, first, bobs.ID, 0)
+		assert.ErrorIs(t, err, domain.ErrInvalidRank, "tasks only move within their assignee's backlog")
+	})
+}
@@ -0,0 +1,61 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestReadinessProbe verifies /ready reports invariant violations with 503
+func TestReadinessProbe(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	ready := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		return rec
+	}
+
+	rec := ready()
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Orphan the task so NoOrphanTasks no longer holds
+	require.NoError(t, repo.RemoveUserTask(ctx, "alice", task.ID))
+
+	rec = ready()
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var body handlers.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "not_ready", body.Code)
+	assert.Contains(t, body.Details, "NoOrphanTasks")
+
+	t.Run("CancelledRequest", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		assert.ErrorIs(t, uc.CheckReadiness(cancelled), context.Canceled)
+	})
+}
@@ -0,0 +1,121 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/repository"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestReadOnlyRepository verifies the read-only view serves every read from
+// the wrapped repository and rejects every write without changing it
+func TestReadOnlyRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uc := usecase.NewTaskUseCase(memory.NewMemoryUnitOfWork(repo), invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	session, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	_, err = uc.AddComment(ctx, task.ID, "First")
+	require.NoError(t, err)
+
+	view := repository.NewReadOnlyRepository(repo)
+
+	t.Run("Reads", func(t *testing.T) {
+		got, err := view.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, task.Title, got.Title)
+
+		tasks, err := view.GetTasksByUser(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{task.ID}, taskIDs(tasks))
+
+		user, err := view.GetByEmail(ctx, "ALICE@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("alice"), user.ID)
+
+		got2, err := view.GetSession(ctx, session.Token)
+		require.NoError(t, err)
+		assert.Equal(t, session.UserID, got2.UserID)
+
+		comments, err := view.GetCommentsByTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Len(t, comments, 1)
+
+		history, err := view.GetStatusHistory(ctx, task.ID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, history)
+
+		viewState, err := view.GetSystemState(ctx)
+		require.NoError(t, err)
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, state.Tasks, viewState.Tasks)
+		assert.Equal(t, state.NextTaskID, viewState.NextTaskID)
+	})
+
+	t.Run("Writes", func(t *testing.T) {
+		before, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		userID := domain.UserID("bob")
+
+		writes := map[string]func() error{
+			"CreateTask": func() error { return view.CreateTask(ctx, &domain.Task{ID: 99}) },
+			"UpdateTask": func() error { return view.UpdateTask(ctx, &domain.Task{ID: task.ID, Title: "Changed"}) },
+			"DeleteTask": func() error { return view.DeleteTask(ctx, task.ID) },
+			"BulkUpdateStatus": func() error {
+				return view.BulkUpdateStatus(ctx, []domain.TaskID{task.ID}, domain.StatusCompleted)
+			},
+			"CreateUser":         func() error { return view.CreateUser(ctx, &domain.User{ID: userID}) },
+			"UpdateUser":         func() error { return view.UpdateUser(ctx, &domain.User{ID: "alice"}) },
+			"DeleteUser":         func() error { return view.DeleteUser(ctx, "alice") },
+			"CreateSession":      func() error { return view.CreateSession(ctx, &domain.Session{UserID: userID}) },
+			"UpdateSession":      func() error { return view.UpdateSession(ctx, session) },
+			"DeleteSession":      func() error { return view.DeleteSession(ctx, session.Token) },
+			"DeleteUserSessions": func() error { return view.DeleteUserSessions(ctx, "alice") },
+			"CreateComment":      func() error { return view.CreateComment(ctx, &domain.Comment{TaskID: task.ID}) },
+			"DeleteTaskComments": func() error { return view.DeleteTaskComments(ctx, task.ID) },
+			"CreateAttachment":   func() error { return view.CreateAttachment(ctx, &domain.Attachment{TaskID: task.ID}) },
+			"DeleteTaskAttachments": func() error {
+				return view.DeleteTaskAttachments(ctx, task.ID)
+			},
+			"SaveSystemState": func() error { return view.SaveSystemState(ctx, domain.NewSystemState()) },
+			"IncrementNextTaskID": func() error {
+				_, err := view.IncrementNextTaskID(ctx)
+				return err
+			},
+			"SetCurrentUser": func() error { return view.SetCurrentUser(ctx, nil) },
+			"AddUserTask":    func() error { return view.AddUserTask(ctx, userID, task.ID) },
+			"RemoveUserTask": func() error { return view.RemoveUserTask(ctx, "alice", task.ID) },
+			"RebuildUserTaskIndex": func() error {
+				_, err := view.RebuildUserTaskIndex(ctx)
+				return err
+			},
+			"RecordActivity": func() error { return view.RecordActivity(ctx, &domain.Activity{TaskID: task.ID}) },
+		}
+		for name, write := range writes {
+			assert.ErrorIs(t, write(), domain.ErrReadOnly, name)
+		}
+
+		after, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		after.Clock = before.Clock
+		assert.Equal(t, before, after, "rejected writes leave the wrapped repository unchanged")
+		comments, err := repo.GetCommentsByTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Len(t, comments, 1)
+	})
+}
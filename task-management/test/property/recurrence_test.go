@@ -0,0 +1,87 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecurringTaskRegenerates verifies completing a recurring task creates
+// exactly one pending successor with the due date shifted by the interval
+func TestRecurringTaskRegenerates(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	due := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+	task, err := uc.CreateTask(ctx, "Report", "Weekly report", domain.PriorityHigh, "alice", &due,
+		[]domain.Tag{domain.TagDocumentation}, nil, usecase.WithRecurrence(domain.RecurrenceWeekly))
+	require.NoError(t, err)
+
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCompleted))
+
+	tasks, err := repo.GetAllTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2, "exactly one successor should be created")
+
+	var successor *domain.Task
+	for _, candidate := range tasks {
+		if candidate.ID != task.ID {
+			successor = candidate
+		}
+	}
+	require.NotNil(t, successor)
+	assert.Equal(t, task.ID, successor.RecurredFrom)
+	assert.Equal(t, domain.StatusPending, successor.Status)
+	assert.Equal(t, domain.UserID("alice"), successor.Assignee)
+	assert.Equal(t, domain.PriorityHigh, successor.Priority)
+	assert.Equal(t, []domain.Tag{domain.TagDocumentation}, successor.Tags)
+	assert.Equal(t, domain.RecurrenceWeekly, successor.Recurrence)
+	require.NotNil(t, successor.DueDate)
+	assert.True(t, due.AddDate(0, 0, 7).Equal(*successor.DueDate))
+
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, checker.CheckAllInvariants(state))
+
+	t.Run("NonRecurringTaskDoesNotRegenerate", func(t *testing.T) {
+		once, err := uc.CreateTask(ctx, "Once", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, once.ID, domain.StatusInProgress))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, once.ID, domain.StatusCompleted))
+
+		tasks, err := repo.GetAllTasks(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 3)
+	})
+
+	t.Run("InvalidRecurrence", func(t *testing.T) {
+		_, err := uc.CreateTask(ctx, "Bad", "Desc", domain.PriorityLow, "alice", nil, nil, nil,
+			usecase.WithRecurrence("hourly"))
+		assert.ErrorIs(t, err, domain.ErrInvalidRecurrence)
+	})
+}
+
+// TestRecurrenceMonthlyInterval checks the monthly interval follows calendar months
+func TestRecurrenceMonthlyInterval(t *testing.T) {
+	start := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC), domain.RecurrenceMonthly.Next(start))
+	assert.Equal(t, time.Date(2024, time.March, 16, 0, 0, 0, 0, time.UTC), domain.RecurrenceDaily.Next(start))
+	assert.Equal(t, start, domain.RecurrenceNone.Next(start))
+}
@@ -0,0 +1,80 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestRegisterUser verifies users can be registered at runtime, idempotently,
+// and that duplicate IDs and emails are rejected with 409
+func TestRegisterUser(t *testing.T) {
+	ctx := context.Background()
+	clock := domain.NewFakeClock(time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC))
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{Clock: clock})
+	handler := handlers.NewTaskHandler(uc)
+
+	register := func(id, name, email string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(handlers.RegisterUserRequest{ID: domain.UserID(id), Name: name, Email: email})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.RegisterUser(rec, req)
+		return rec
+	}
+
+	rec := register("dave", "Dave", "dave@example.com")
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var user domain.User
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &user))
+	assert.Equal(t, domain.UserID("dave"), user.ID)
+	assert.Equal(t, domain.RoleMember, user.Role)
+	assert.True(t, user.JoinedAt.Equal(clock.Now()))
+
+	stored, err := repo.GetUser(ctx, "dave")
+	require.NoError(t, err)
+	assert.Equal(t, "dave@example.com", stored.Email)
+
+	// Repeating the registration returns the existing user
+	clock.Advance(time.Hour)
+	rec = register("dave", "Dave", "DAVE@example.com")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &user))
+	assert.True(t, user.JoinedAt.Equal(stored.JoinedAt))
+
+	rec = register("dave", "David", "david@example.com")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "duplicate_user")
+
+	rec = register("erin", "Erin", "Dave@Example.com")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "duplicate_email")
+	_, err = repo.GetUser(ctx, "erin")
+	assert.Error(t, err, "a rejected registration stores nothing")
+
+	assert.Equal(t, http.StatusBadRequest, register("", "Nobody", "nobody@example.com").Code)
+	assert.Equal(t, http.StatusBadRequest, register("frank", " ", "frank@example.com").Code)
+	assert.Equal(t, http.StatusBadRequest, register("frank", "Frank", "").Code)
+
+	// Registered users can log in and own tasks
+	_, err = uc.Authenticate(ctx, "dave")
+	require.NoError(t, err)
+	_, err = uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "dave", nil, nil, nil)
+	assert.NoError(t, err)
+}
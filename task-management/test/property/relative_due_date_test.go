@@ -0,0 +1,106 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestRelativeDueDate verifies a task can be created due some time after its
+// creation instead of at an absolute time, but not both
+func TestRelativeDueDate(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+		SessionDuration:      24 * time.Hour,
+		RequireFutureDueDate: true,
+		Clock:                clock,
+	})
+	handler := handlers.NewTaskHandler(uc)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(fields string) *httptest.ResponseRecorder {
+		body := `{"title": "Task", "description": "Desc", "priority": "low", "assignee": "alice"` + fields + `}`
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.CreateTask(rec, req)
+		return rec
+	}
+	dueDate := func(t *testing.T, rec *httptest.ResponseRecorder) *time.Time {
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		var resp handlers.CreateTaskResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp.DueDate
+	}
+
+	t.Run("Absolute", func(t *testing.T) {
+		due := dueDate(t, create(`, "due_date": "2030-02-01T00:00:00Z"`))
+		require.NotNil(t, due)
+		assert.True(t, time.Date(2030, 2, 1, 0, 0, 0, 0, time.UTC).Equal(*due))
+	})
+
+	t.Run("Days", func(t *testing.T) {
+		due := dueDate(t, create(`, "due_in_days": 3`))
+		require.NotNil(t, due)
+		assert.True(t, start.Add(72*time.Hour).Equal(*due))
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		due := dueDate(t, create(`, "due_in": "36h30m"`))
+		require.NotNil(t, due)
+		assert.True(t, start.Add(time.Hour+36*time.Hour+30*time.Minute).Equal(*due), "measured from the creation time")
+	})
+
+	t.Run("None", func(t *testing.T) {
+		assert.Nil(t, dueDate(t, create(``)))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		for name, fields := range map[string]string{
+			"DateAndDays":      `, "due_date": "2030-02-01T00:00:00Z", "due_in_days": 3`,
+			"DateAndDuration":  `, "due_date": "2030-02-01T00:00:00Z", "due_in": "24h"`,
+			"DaysAndDuration":  `, "due_in_days": 1, "due_in": "24h"`,
+			"NegativeDays":     `, "due_in_days": -1`,
+			"NegativeDuration": `, "due_in": "-2h"`,
+			"BadDuration":      `, "due_in": "three days"`,
+		} {
+			rec := create(fields)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+		}
+
+		tasks, err := repo.GetAllTasks(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 4, "no task was created")
+	})
+
+	t.Run("UseCaseOption", func(t *testing.T) {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil,
+			usecase.WithDueIn(2*time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, task.DueDate)
+		assert.True(t, task.CreatedAt.Add(2*time.Hour).Equal(*task.DueDate))
+	})
+}
@@ -0,0 +1,128 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestReopenTask verifies only admins and creators can reopen completed or
+// cancelled tasks, that a reason is required and recorded in a task.reopened
+// event, and that UpdateTaskStatus still refuses to leave a final status
+func TestReopenTask(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	publisher := &recordingPublisher{}
+	uc.SetEventPublisher(publisher)
+
+	users := []domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "bob", Name: "Bob", Email: "bob@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+	}
+	for i := range users {
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+	loginAs := func(userID domain.UserID) {
+		if current, _ := repo.GetCurrentUser(ctx); current != nil {
+			require.NoError(t, uc.Logout(ctx, *current))
+		}
+		_, err := uc.Authenticate(ctx, userID)
+		require.NoError(t, err)
+	}
+
+	// Alice creates a task for Bob, who completes it
+	loginAs("alice")
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityMedium, "bob", nil, nil, nil)
+	require.NoError(t, err)
+	cancelled, err := uc.CreateTask(ctx, "Cancelled", "Desc", domain.PriorityMedium, "bob", nil, nil, nil)
+	require.NoError(t, err)
+	loginAs("bob")
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCompleted))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, cancelled.ID, domain.StatusCancelled))
+
+	t.Run("UpdateTaskStatusStaysStrict", func(t *testing.T) {
+		err := uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress)
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("AssigneeCannotReopen", func(t *testing.T) {
+		_, err := uc.ReopenTask(ctx, task.ID, "not done")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only admins and the creator")
+		assert.Empty(t, publisher.ofType(domain.EventTaskReopened))
+	})
+
+	t.Run("ReasonRequired", func(t *testing.T) {
+		loginAs("alice")
+		_, err := uc.ReopenTask(ctx, task.ID, "  ")
+		assert.ErrorIs(t, err, domain.ErrReasonRequired)
+	})
+
+	t.Run("CreatorReopens", func(t *testing.T) {
+		loginAs("alice")
+		reopened, err := uc.ReopenTask(ctx, task.ID, " tests fail on main ")
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, reopened.Status)
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, stored.Status)
+
+		history, err := repo.GetStatusHistory(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, history[len(history)-1].Status)
+
+		events := publisher.ofType(domain.EventTaskReopened)
+		require.Len(t, events, 1)
+		assert.Equal(t, task.ID, events[0].TaskID)
+		assert.Equal(t, domain.UserID("alice"), events[0].Actor)
+		assert.Equal(t, map[string]string{
+			"from":   string(domain.StatusCompleted),
+			"to":     string(domain.StatusInProgress),
+			"reason": "tests fail on main",
+		}, events[0].Data)
+
+		// Only final statuses can be reopened
+		_, err = uc.ReopenTask(ctx, task.ID, "again")
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("AdminReopensCancelledTask", func(t *testing.T) {
+		loginAs("root")
+		reopened, err := uc.ReopenTask(ctx, cancelled.ID, "cancelled by mistake")
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusInProgress, reopened.Status)
+
+		events := publisher.ofType(domain.EventTaskReopened)
+		require.Len(t, events, 2)
+		assert.Equal(t, domain.UserID("root"), events[1].Actor)
+		assert.Equal(t, string(domain.StatusCancelled), events[1].Data["from"])
+	})
+
+	t.Run("ArchivedTask", func(t *testing.T) {
+		loginAs("alice")
+		archived, err := uc.CreateTask(ctx, "Archived", "Desc", domain.PriorityMedium, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, archived.ID, domain.StatusCancelled))
+		require.NoError(t, uc.DeleteTask(ctx, archived.ID))
+
+		_, err = uc.ReopenTask(ctx, archived.ID, "still needed")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "restore it first")
+	})
+
+	_, err = uc.ReopenTask(ctx, 999, "missing")
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+}
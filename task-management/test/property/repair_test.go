@@ -0,0 +1,86 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRepairIndex corrupts the userTasks index and verifies RepairIndex restores it
+func TestRepairIndex(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	users := []domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+		{ID: "bob", Name: "Bob", Email: "bob@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+	}
+	for i := range users {
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	first, err := uc.CreateTask(ctx, "First", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	second, err := uc.CreateTask(ctx, "Second", "Desc", domain.PriorityLow, "bob", nil, nil, nil)
+	require.NoError(t, err)
+
+	// Orphan one task, misfile another and point an entry at a missing task
+	require.NoError(t, repo.RemoveUserTask(ctx, "alice", first.ID))
+	require.NoError(t, repo.AddUserTask(ctx, "alice", second.ID))
+	require.NoError(t, repo.AddUserTask(ctx, "bob", 99))
+
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	require.Error(t, checker.CheckAllInvariants(state))
+
+	changed, err := uc.RepairIndex(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, changed)
+
+	state, err = repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, checker.CheckAllInvariants(state))
+	assert.ElementsMatch(t, []domain.TaskID{first.ID}, state.GetUserTasks("alice"))
+	assert.ElementsMatch(t, []domain.TaskID{second.ID}, state.GetUserTasks("bob"))
+
+	// A consistent index needs no changes
+	changed, err = uc.RepairIndex(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, changed)
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		require.NoError(t, uc.Logout(ctx, "alice"))
+		_, err := uc.Authenticate(ctx, "bob")
+		require.NoError(t, err)
+
+		_, err = uc.RepairIndex(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("RolledBack", func(t *testing.T) {
+		require.NoError(t, uc.Logout(ctx, "bob"))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		require.NoError(t, repo.RemoveUserTask(ctx, "alice", first.ID))
+
+		// A repair that fails its invariant check leaves the index as it was
+		failing := usecase.NewTaskUseCase(uow, &failingChecker{InvariantChecker: checker, fail: true})
+		_, err = failing.RepairIndex(ctx)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, state.GetUserTasks("alice"))
+	})
+}
@@ -0,0 +1,84 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+)
+
+// TestRepositoryCreationOrder verifies the in-memory repository lists tasks in
+// the order they were created, the same way on every call
+func TestRepositoryCreationOrder(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+
+	// IDs out of sequence tell creation order apart from ID order
+	created := []domain.TaskID{7, 3, 12, 1, 9, 4, 11, 2, 8, 5}
+	for _, id := range created {
+		require.NoError(t, repo.CreateTask(ctx, &domain.Task{
+			ID: id, Title: "Task", Status: domain.StatusPending, Priority: domain.PriorityLow,
+			Assignee: "alice", CreatedBy: "bob", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+			Dependencies: map[domain.TaskID]bool{},
+		}))
+	}
+
+	listings := func(t *testing.T) [][]domain.TaskID {
+		byUser, err := repo.GetTasksByUser(ctx, "alice")
+		require.NoError(t, err)
+		byCreator, err := repo.GetTasksByCreator(ctx, "bob")
+		require.NoError(t, err)
+		byStatus, err := repo.GetTasksByStatus(ctx, domain.StatusPending)
+		require.NoError(t, err)
+		return [][]domain.TaskID{taskIDs(byUser), taskIDs(byCreator), taskIDs(byStatus)}
+	}
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, [][]domain.TaskID{created, created, created}, listings(t))
+	}
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, repo.DeleteTask(ctx, 12))
+		remaining := []domain.TaskID{7, 3, 1, 9, 4, 11, 2, 8, 5}
+		assert.Equal(t, [][]domain.TaskID{remaining, remaining, remaining}, listings(t))
+
+		// A task recreated under a deleted ID goes to the end
+		require.NoError(t, repo.CreateTask(ctx, &domain.Task{
+			ID: 12, Title: "Again", Status: domain.StatusPending, Priority: domain.PriorityLow,
+			Assignee: "alice", CreatedBy: "bob", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}))
+		recreated := append(remaining, 12)
+		assert.Equal(t, [][]domain.TaskID{recreated, recreated, recreated}, listings(t))
+	})
+
+	t.Run("RollbackAndRestore", func(t *testing.T) {
+		before := listings(t)
+		uow := memory.NewMemoryUnitOfWork(repo)
+		require.NoError(t, uow.Begin(ctx))
+		require.NoError(t, uow.Tasks().DeleteTask(ctx, 7))
+		require.NoError(t, uow.Tasks().CreateTask(ctx, &domain.Task{
+			ID: 20, Title: "Dropped", Status: domain.StatusPending, Priority: domain.PriorityLow,
+			Assignee: "alice", CreatedBy: "bob", CreatedAt: time.Now(), UpdatedAt: time.Now(),
+		}))
+		require.NoError(t, uow.Rollback())
+		assert.Equal(t, before, listings(t))
+
+		scope := uow.BeginScope(ctx)
+		require.NoError(t, uow.Tasks().DeleteTask(scope, 3))
+		uow.EndScope(scope, true)
+		assert.Equal(t, before, listings(t))
+	})
+
+	t.Run("SavedState", func(t *testing.T) {
+		// A saved state carries no creation order, so it is listed by ID
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveSystemState(ctx, state))
+		byID := []domain.TaskID{1, 2, 3, 4, 5, 7, 8, 9, 11, 12}
+		assert.Equal(t, [][]domain.TaskID{byID, byID, byID}, listings(t))
+	})
+}
@@ -0,0 +1,89 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestRequestBodyValidation verifies JSON request bodies are rejected with a
+// precise status and error code instead of being partially applied
+func TestRequestBodyValidation(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	// createTask posts the body to the create handler with the given Content-Type
+	createTask := func(contentType, body string) (int, handlers.ErrorResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		handler.CreateTask(rec, req)
+
+		var resp handlers.ErrorResponse
+		if rec.Code >= http.StatusBadRequest {
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		}
+		return rec.Code, resp
+	}
+	valid := `{"title": "T", "description": "D", "priority": "low", "assignee": "alice"}`
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		code        string
+	}{
+		{"Valid", "application/json", valid, http.StatusCreated, ""},
+		{"ValidWithCharset", "application/json; charset=utf-8", valid, http.StatusCreated, ""},
+		{"MissingContentType", "", valid, http.StatusUnsupportedMediaType, "unsupported_media_type"},
+		{"WrongContentType", "text/plain", valid, http.StatusUnsupportedMediaType, "unsupported_media_type"},
+		{"UnknownField", "application/json",
+			`{"title": "T", "description": "D", "priorty": "high", "assignee": "alice"}`,
+			http.StatusBadRequest, "unknown_field"},
+		{"Malformed", "application/json", `{"title": "T",`, http.StatusBadRequest, "malformed_json"},
+		{"SyntaxError", "application/json", `{"title" "T"}`, http.StatusBadRequest, "malformed_json"},
+		{"WrongType", "application/json", `{"title": 42}`, http.StatusBadRequest, "invalid_field_type"},
+		{"Empty", "application/json", ``, http.StatusBadRequest, "empty_body"},
+		{"TrailingData", "application/json", valid + `{}`, http.StatusBadRequest, "invalid_body"},
+		{"TooLarge", "application/json",
+			`{"title": "` + strings.Repeat("x", 2<<20) + `"}`,
+			http.StatusRequestEntityTooLarge, "body_too_large"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, resp := createTask(tc.contentType, tc.body)
+			assert.Equal(t, tc.status, status, resp.Details)
+			assert.Equal(t, tc.code, resp.Code)
+		})
+	}
+
+	t.Run("UnknownFieldNamed", func(t *testing.T) {
+		_, resp := createTask("application/json", `{"title": "T", "priorty": "high"}`)
+		assert.Contains(t, resp.Details, "priorty")
+	})
+}
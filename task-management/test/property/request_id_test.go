@@ -0,0 +1,91 @@
+package property
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/api/http/middleware"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/requestid"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestRequestID verifies request IDs are taken from the request or generated,
+// echoed in the response, and carried through the context into task events
+func TestRequestID(t *testing.T) {
+	t.Run("PropagatedOrGenerated", func(t *testing.T) {
+		var seen string
+		handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = requestid.FromContext(r.Context())
+		}))
+		serve := func(id string) string {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if id != "" {
+				req.Header.Set(requestid.Header, id)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, seen, rec.Header().Get(requestid.Header), "the response echoes the ID in the context")
+			return seen
+		}
+
+		assert.Equal(t, "trace-123", serve("trace-123"))
+
+		generated := serve("")
+		assert.Len(t, generated, 32)
+		assert.NotEqual(t, generated, serve(""), "each request gets its own ID")
+
+		for _, malformed := range []string{"two words", "line\nbreak", strings.Repeat("x", 129)} {
+			id := serve(malformed)
+			assert.NotEqual(t, malformed, id)
+			assert.Len(t, id, 32)
+		}
+	})
+
+	t.Run("TaggedOnEvents", func(t *testing.T) {
+		ctx := context.Background()
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+		publisher := &recordingPublisher{}
+		uc.SetEventPublisher(publisher)
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		router := mux.NewRouter()
+		router.HandleFunc("/tasks", handlers.NewTaskHandler(uc).CreateTask).Methods("POST")
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(
+			`{"title": "Task", "description": "Desc", "priority": "low", "assignee": "alice"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(requestid.Header, "incident-42")
+		rec := httptest.NewRecorder()
+		middleware.RequestID(router).ServeHTTP(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, "incident-42", rec.Header().Get(requestid.Header))
+
+		created := publisher.ofType(domain.EventTaskCreated)
+		require.Len(t, created, 1)
+		assert.Equal(t, "incident-42", created[0].RequestID)
+
+		// Events raised outside a request carry no ID
+		_, err = uc.CreateTask(ctx, "Other", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		created = publisher.ofType(domain.EventTaskCreated)
+		require.Len(t, created, 2)
+		assert.Empty(t, created[1].RequestID)
+	})
+}
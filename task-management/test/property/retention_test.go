@@ -0,0 +1,136 @@
+package property
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPurgeOldTasks verifies the retention policy deletes only old finished
+// tasks and keeps those that other tasks still depend on
+func TestPurgeOldTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	users := []domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+	}
+	for i := range users {
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(title string, deps []domain.TaskID) *domain.Task {
+		task, err := uc.CreateTask(ctx, title, "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+		require.NoError(t, err)
+		return task
+	}
+	// age backdates a task's last update
+	age := func(taskID domain.TaskID, by time.Duration) {
+		task, err := repo.GetTask(ctx, taskID)
+		require.NoError(t, err)
+		task.CreatedAt = task.CreatedAt.Add(-by)
+		task.UpdatedAt = task.UpdatedAt.Add(-by)
+		require.NoError(t, repo.UpdateTask(ctx, task))
+	}
+
+	oldCancelled := create("Old cancelled", nil)
+	dependedUpon := create("Depended upon", nil)
+	dependent := create("Dependent", []domain.TaskID{dependedUpon.ID})
+	recentCancelled := create("Recent cancelled", nil)
+	oldPending := create("Old pending", nil)
+
+	for _, task := range []*domain.Task{oldCancelled, dependedUpon, recentCancelled} {
+		require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCancelled))
+	}
+	_, err = uc.AddComment(ctx, oldCancelled.ID, "done with this")
+	require.NoError(t, err)
+	for _, task := range []*domain.Task{oldCancelled, dependedUpon, oldPending} {
+		age(task.ID, 48*time.Hour)
+	}
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		_, err := uc.PurgeOldTasks(ctx, 24*time.Hour)
+		assert.Error(t, err)
+	})
+
+	require.NoError(t, uc.Logout(ctx, "alice"))
+	_, err = uc.Authenticate(ctx, "root")
+	require.NoError(t, err)
+
+	purged, err := uc.PurgeOldTasks(ctx, 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	tasks, err := repo.GetAllTasks(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, tasks, oldCancelled.ID)
+	assert.Contains(t, tasks, dependedUpon.ID, "tasks others depend on are kept")
+	assert.Contains(t, tasks, dependent.ID)
+	assert.Contains(t, tasks, recentCancelled.ID, "recently updated tasks are kept")
+	assert.Contains(t, tasks, oldPending.ID, "unfinished tasks are kept")
+
+	comments, err := repo.GetCommentsByTask(ctx, oldCancelled.ID)
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, checker.CheckAllInvariants(state))
+
+	t.Run("FreedOnceDependentIsGone", func(t *testing.T) {
+		stored, err := repo.GetTask(ctx, dependent.ID)
+		require.NoError(t, err)
+		stored.Status = domain.StatusCancelled
+		require.NoError(t, repo.UpdateTask(ctx, stored))
+		age(dependent.ID, 48*time.Hour)
+
+		purged, err := uc.SweepOldTasks(ctx, 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged, "the dependent goes first")
+
+		purged, err = uc.SweepOldTasks(ctx, 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged, "then the task it depended on")
+
+		tasks, err := repo.GetAllTasks(ctx)
+		require.NoError(t, err)
+		assert.NotContains(t, tasks, dependedUpon.ID)
+	})
+}
+
+// TestRetentionIntervalFlag verifies the server refuses to start with a
+// retention policy but no positive interval to apply it at
+func TestRetentionIntervalFlag(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the server")
+	}
+	server := filepath.Join(t.TempDir(), "server")
+	build := exec.Command("go", "build", "-o", server, "../../cmd/server")
+	out, err := build.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	for _, interval := range []string{"0", "-1m"} {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		out, err := exec.CommandContext(ctx, server, "-retention=24h", "-retention-interval="+interval).CombinedOutput()
+		cancel()
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr, "the server must not start with -retention-interval=%s", interval)
+		assert.Equal(t, 1, exitErr.ExitCode())
+		assert.Contains(t, string(out), "invalid -retention-interval")
+	}
+}
@@ -0,0 +1,170 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestTaskRollup verifies a task's rollup sums the hours of its whole subtask
+// subtree and counts the completed subtasks, leaving out archived subtasks and
+// terminating on a cycle of parents
+func TestTaskRollup(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(hours float64, parent domain.TaskID) domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil,
+			usecase.WithEstimatedHours(hours), usecase.WithParent(parent))
+		require.NoError(t, err)
+		return task.ID
+	}
+	complete := func(id domain.TaskID) {
+		require.NoError(t, uc.UpdateTaskStatus(ctx, id, domain.StatusInProgress))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, id, domain.StatusCompleted))
+	}
+
+	// root has subtasks design and build; design has a subtask of its own,
+	// and root an archived one
+	root := create(2, 0)
+	design := create(3, root)
+	build := create(1, root)
+	sketch := create(4, design)
+	archived := create(100, root)
+	_, err = uc.LogTime(ctx, design, 1)
+	require.NoError(t, err)
+	_, err = uc.LogTime(ctx, sketch, 2.5)
+	require.NoError(t, err)
+	complete(design)
+	complete(sketch)
+	complete(archived)
+	require.NoError(t, uc.DeleteTask(ctx, archived))
+
+	rollup, err := uc.GetTaskWithRollup(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, root, rollup.Task.ID)
+	assert.Equal(t, 10.0, rollup.EstimatedHours)
+	assert.Equal(t, 3.5, rollup.ActualHours)
+	assert.Equal(t, 2, rollup.CompletedSubtasks)
+	assert.Equal(t, 3, rollup.TotalSubtasks)
+
+	rollup, err = uc.GetTaskWithRollup(ctx, build)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, rollup.EstimatedHours, "a task without subtasks rolls up its own hours")
+	assert.Zero(t, rollup.TotalSubtasks)
+
+	_, err = uc.GetTaskWithRollup(ctx, 99)
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+	t.Run("InvalidParent", func(t *testing.T) {
+		for _, parent := range []domain.TaskID{99, archived} {
+			_, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil, usecase.WithParent(parent))
+			assert.ErrorIs(t, err, domain.ErrInvalidParent, "parent %d", parent)
+		}
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		router := mux.NewRouter()
+		router.HandleFunc("/tasks/{id}/rollup", handlers.NewTaskHandler(uc).GetTaskRollup).Methods("GET")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/"+strconv.Itoa(int(design))+"/rollup", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var response struct {
+			ID       domain.TaskID           `json:"id"`
+			ParentID domain.TaskID           `json:"parent_id"`
+			Rollup   handlers.RollupResponse `json:"rollup"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, design, response.ID)
+		assert.Equal(t, root, response.ParentID)
+		assert.Equal(t, handlers.RollupResponse{
+			EstimatedHours: 7, ActualHours: 3.5, CompletedSubtasks: 1, TotalSubtasks: 1,
+		}, response.Rollup)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		// CreateTask cannot close a loop of parents, so one is written directly
+		task, err := repo.GetTask(ctx, root)
+		require.NoError(t, err)
+		task.ParentID = sketch
+		require.NoError(t, repo.UpdateTask(ctx, task))
+
+		rollup, err := uc.GetTaskWithRollup(ctx, root)
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, rollup.EstimatedHours, "each task is counted once")
+		assert.Equal(t, 3, rollup.TotalSubtasks)
+	})
+}
+
+// TestPurgeDetachesSubtasks verifies permanently deleting a parent, by hand or
+// by the retention sweep, makes its subtasks top-level tasks
+func TestPurgeDetachesSubtasks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(parent domain.TaskID) domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil,
+			usecase.WithParent(parent))
+		require.NoError(t, err)
+		return task.ID
+	}
+	parentOf := func(id domain.TaskID) domain.TaskID {
+		task, err := repo.GetTask(ctx, id)
+		require.NoError(t, err)
+		return task.ParentID
+	}
+
+	purged := create(0)
+	child := create(purged)
+	grandchild := create(child)
+	swept := create(0)
+	sweptChild := create(swept)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, swept, domain.StatusCancelled))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, purged, domain.StatusCancelled))
+
+	require.NoError(t, uc.PurgeTask(ctx, purged))
+	assert.Zero(t, parentOf(child))
+	assert.Equal(t, child, parentOf(grandchild), "only direct subtasks are detached")
+
+	// Every cancelled task is old enough for a zero retention
+	count, err := uc.SweepOldTasks(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Zero(t, parentOf(sweptChild))
+
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, checker.CheckAllInvariants(state))
+}
@@ -0,0 +1,301 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestSessionInfo verifies GET /auth/me only accepts valid session tokens
+func TestSessionInfo(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+
+	me := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/auth/me", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.Me(rec, req)
+		return rec
+	}
+
+	session, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	t.Run("ValidToken", func(t *testing.T) {
+		rec := me("Bearer " + session.Token)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var info handlers.SessionInfoResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+		assert.Equal(t, domain.UserID("alice"), info.UserID)
+		assert.True(t, session.ExpiresAt.Equal(info.ExpiresAt))
+	})
+
+	t.Run("MissingOrUnknownToken", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, me("").Code)
+		assert.Equal(t, http.StatusUnauthorized, me("Bearer nope").Code)
+	})
+
+	t.Run("ExpiredToken", func(t *testing.T) {
+		stored, err := repo.GetSession(ctx, session.Token)
+		require.NoError(t, err)
+		stored.ExpiresAt = time.Now().Add(-time.Minute)
+		require.NoError(t, repo.UpdateSession(ctx, stored))
+		defer func() {
+			stored.ExpiresAt = session.ExpiresAt
+			require.NoError(t, repo.UpdateSession(ctx, stored))
+		}()
+
+		assert.Equal(t, http.StatusUnauthorized, me("Bearer "+session.Token).Code)
+	})
+
+	t.Run("LoggedOut", func(t *testing.T) {
+		require.NoError(t, uc.Logout(ctx, "alice"))
+
+		_, err := uc.GetSession(ctx, session.Token)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+		assert.Equal(t, http.StatusUnauthorized, me("Bearer "+session.Token).Code)
+	})
+}
+
+// TestSessionDuration verifies the default, extended and configured session expiry
+func TestSessionDuration(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T, config usecase.Config) *usecase.TaskUseCase {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), config)
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		return uc
+	}
+	lifetime := func(session *domain.Session) time.Duration {
+		return session.ExpiresAt.Sub(session.CreatedAt)
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		uc := setup(t, usecase.Config{})
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, usecase.DefaultSessionDuration, lifetime(session))
+	})
+
+	t.Run("RememberMe", func(t *testing.T) {
+		uc := setup(t, usecase.Config{})
+		session, err := uc.AuthenticateWithRememberMe(ctx, "alice", true)
+		require.NoError(t, err)
+		assert.Equal(t, usecase.DefaultRememberMeDuration, lifetime(session))
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		uc := setup(t, usecase.Config{SessionDuration: time.Hour, RememberMeDuration: 7 * 24 * time.Hour})
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, lifetime(session))
+		require.NoError(t, uc.Logout(ctx, "alice"))
+
+		session, err = uc.AuthenticateWithRememberMe(ctx, "alice", true)
+		require.NoError(t, err)
+		assert.Equal(t, 7*24*time.Hour, lifetime(session))
+	})
+}
+
+// TestLogoutByToken verifies POST /auth/logout revokes the bearer token's
+// session idempotently and POST /auth/logout-all revokes every session
+func TestLogoutByToken(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*memory.MemoryRepository, *usecase.TaskUseCase, *handlers.TaskHandler) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		return repo, uc, handlers.NewTaskHandler(uc)
+	}
+	post := func(handle http.HandlerFunc, target, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handle(rec, req)
+		return rec
+	}
+
+	t.Run("Logout", func(t *testing.T) {
+		repo, uc, handler := setup(t)
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		rec := post(handler.Logout, "/auth/logout", session.Token)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		_, err = uc.GetSession(ctx, session.Token)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+		current, err := repo.GetCurrentUser(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, current)
+
+		// Logging out again is a no-op rather than an error
+		rec = post(handler.Logout, "/auth/logout", session.Token)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		assert.Equal(t, http.StatusUnauthorized, post(handler.Logout, "/auth/logout", "nope").Code)
+
+		// The user can log in again afterwards
+		_, err = uc.Authenticate(ctx, "alice")
+		assert.NoError(t, err)
+	})
+
+	t.Run("LogoutAll", func(t *testing.T) {
+		repo, uc, handler := setup(t)
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		other := &domain.Session{
+			UserID: "alice", Token: "other-device", Active: true,
+			CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+		}
+		require.NoError(t, repo.CreateSession(ctx, other))
+
+		rec := post(handler.LogoutAll, "/auth/logout-all", session.Token)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		for _, token := range []string{session.Token, other.Token} {
+			_, err := uc.GetSession(ctx, token)
+			assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+		}
+		active, err := repo.GetActiveSessions(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, active)
+		current, err := repo.GetCurrentUser(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, current)
+
+		assert.Equal(t, http.StatusUnauthorized, post(handler.LogoutAll, "/auth/logout-all", session.Token).Code)
+		assert.Equal(t, http.StatusUnauthorized, post(handler.LogoutAll, "/auth/logout-all", "").Code)
+	})
+}
+
+// TestRefreshSession verifies a valid session can be extended without logging
+// in again, and an expired or unknown one cannot
+func TestRefreshSession(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	setup := func(t *testing.T) (*domain.FakeClock, *usecase.TaskUseCase, *handlers.TaskHandler) {
+		clock := domain.NewFakeClock(start)
+		repo := memory.NewMemoryRepositoryWithClock(clock)
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			SessionDuration:    time.Hour,
+			RememberMeDuration: 24 * time.Hour,
+			Clock:              clock,
+		})
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+		}))
+		return clock, uc, handlers.NewTaskHandler(uc)
+	}
+
+	t.Run("NearExpiry", func(t *testing.T) {
+		clock, uc, _ := setup(t)
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		clock.Advance(59 * time.Minute)
+		refreshed, err := uc.RefreshSession(ctx, session.Token)
+		require.NoError(t, err)
+		assert.Equal(t, session.Token, refreshed.Token)
+		assert.True(t, start.Add(59*time.Minute+time.Hour).Equal(refreshed.ExpiresAt))
+
+		// Still valid past the original expiry, and usable to create tasks
+		clock.Advance(30 * time.Minute)
+		current, err := uc.GetSession(ctx, session.Token)
+		require.NoError(t, err)
+		assert.True(t, refreshed.ExpiresAt.Equal(current.ExpiresAt))
+		_, err = uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("AfterExpiry", func(t *testing.T) {
+		clock, uc, _ := setup(t)
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		clock.Advance(time.Hour + time.Second)
+		_, err = uc.RefreshSession(ctx, session.Token)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+		_, err = uc.GetSession(ctx, session.Token)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated, "the session stays expired")
+	})
+
+	t.Run("LoggedOutOrUnknown", func(t *testing.T) {
+		_, uc, _ := setup(t)
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		require.NoError(t, uc.LogoutSession(ctx, session.Token))
+
+		_, err = uc.RefreshSession(ctx, session.Token)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+		_, err = uc.RefreshSession(ctx, "nope")
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+		_, err = uc.RefreshSession(ctx, "")
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("RememberMeKeepsLongerExpiry", func(t *testing.T) {
+		clock, uc, _ := setup(t)
+		session, err := uc.AuthenticateWithRememberMe(ctx, "alice", true)
+		require.NoError(t, err)
+
+		clock.Advance(time.Hour)
+		refreshed, err := uc.RefreshSession(ctx, session.Token)
+		require.NoError(t, err)
+		assert.True(t, session.ExpiresAt.Equal(refreshed.ExpiresAt))
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		clock, uc, handler := setup(t)
+		session, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		refresh := func(authorization string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+			req.Header.Set("Authorization", authorization)
+			rec := httptest.NewRecorder()
+			handler.RefreshSession(rec, req)
+			return rec
+		}
+
+		clock.Advance(50 * time.Minute)
+		rec := refresh("Bearer " + session.Token)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var refreshed domain.Session
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &refreshed))
+		assert.True(t, start.Add(50*time.Minute+time.Hour).Equal(refreshed.ExpiresAt))
+
+		assert.Equal(t, http.StatusUnauthorized, refresh("Bearer nope").Code)
+		clock.Advance(2 * time.Hour)
+		assert.Equal(t, http.StatusUnauthorized, refresh("Bearer "+session.Token).Code)
+	})
+}
@@ -0,0 +1,200 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestSnoozeTask verifies snoozed tasks are hidden from listings until their
+// snooze elapses, after which the liveness check surfaces them
+func TestSnoozeTask(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	setup := func(t *testing.T) (*domain.FakeClock, *memory.MemoryRepository, *usecase.TaskUseCase, *recordingPublisher) {
+		clock := domain.NewFakeClock(start)
+		repo := memory.NewMemoryRepositoryWithClock(clock)
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+			SessionDuration: 30 * 24 * time.Hour,
+			Clock:           clock,
+		})
+		publisher := &recordingPublisher{}
+		uc.SetEventPublisher(publisher)
+
+		for _, id := range []domain.UserID{"alice", "bob"} {
+			require.NoError(t, repo.CreateUser(ctx, &domain.User{
+				ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: start,
+			}))
+		}
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		return clock, repo, uc, publisher
+	}
+	create := func(t *testing.T, uc *usecase.TaskUseCase) *domain.Task {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		return task
+	}
+	at := func(d time.Duration) *time.Time {
+		t := start.Add(d)
+		return &t
+	}
+
+	t.Run("HiddenUntilElapsed", func(t *testing.T) {
+		clock, repo, uc, publisher := setup(t)
+		snoozed := create(t, uc)
+		awake := create(t, uc)
+
+		task, err := uc.SnoozeTask(ctx, snoozed.ID, at(24*time.Hour))
+		require.NoError(t, err)
+		assert.True(t, start.Add(24*time.Hour).Equal(*task.SnoozedUntil))
+		events := publisher.ofType(domain.EventTaskSnoozed)
+		require.Len(t, events, 1)
+		assert.Equal(t, "2030-01-02T09:00:00Z", events[0].Data["until"])
+
+		listed := func(include bool) [][]domain.TaskID {
+			all, err := uc.ListTasks(ctx, usecase.SortByID, usecase.ListFilter{IncludeSnoozed: include})
+			require.NoError(t, err)
+			assigned, err := uc.GetTasksByUser(ctx, "alice", "", false, include, usecase.SortByID)
+			require.NoError(t, err)
+			created, err := uc.GetTasksByCreator(ctx, "alice", "", false, include, usecase.SortByID)
+			require.NoError(t, err)
+			return [][]domain.TaskID{taskIDs(all), taskIDs(assigned), taskIDs(created)}
+		}
+		both := []domain.TaskID{snoozed.ID, awake.ID}
+		only := []domain.TaskID{awake.ID}
+
+		assert.Equal(t, [][]domain.TaskID{only, only, only}, listed(false))
+		assert.Equal(t, [][]domain.TaskID{both, both, both}, listed(true))
+
+		warnings := func() []invariants.LivenessWarning {
+			state, err := repo.GetSystemState(ctx)
+			require.NoError(t, err)
+			var elapsed []invariants.LivenessWarning
+			for _, warning := range invariants.NewInvariantChecker().LivenessWarnings(state) {
+				if warning.Kind == invariants.WarningSnoozeElapsed {
+					elapsed = append(elapsed, warning)
+				}
+			}
+			return elapsed
+		}
+		clock.Advance(24*time.Hour - time.Second)
+		assert.Equal(t, [][]domain.TaskID{only, only, only}, listed(false))
+		assert.Empty(t, warnings())
+
+		// The snooze ends at the given time
+		clock.Advance(time.Second)
+		assert.Equal(t, [][]domain.TaskID{both, both, both}, listed(false))
+		elapsed := warnings()
+		require.Len(t, elapsed, 1)
+		assert.Equal(t, snoozed.ID, elapsed[0].TaskID)
+
+		// Picking the task up again silences the warning
+		require.NoError(t, uc.UpdateTaskStatus(ctx, snoozed.ID, domain.StatusInProgress))
+		assert.Empty(t, warnings())
+	})
+
+	t.Run("Wake", func(t *testing.T) {
+		_, _, uc, publisher := setup(t)
+		task := create(t, uc)
+		_, err := uc.SnoozeTask(ctx, task.ID, at(time.Hour))
+		require.NoError(t, err)
+
+		woken, err := uc.SnoozeTask(ctx, task.ID, nil)
+		require.NoError(t, err)
+		assert.Nil(t, woken.SnoozedUntil)
+		listed, err := uc.ListTasks(ctx, usecase.SortByID, usecase.ListFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TaskID{task.ID}, taskIDs(listed))
+		events := publisher.ofType(domain.EventTaskSnoozed)
+		require.Len(t, events, 2)
+		assert.Equal(t, "", events[1].Data["until"])
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		_, repo, uc, _ := setup(t)
+		task := create(t, uc)
+
+		_, err := uc.SnoozeTask(ctx, task.ID, at(0))
+		assert.ErrorIs(t, err, domain.ErrSnoozeInPast)
+		_, err = uc.SnoozeTask(ctx, task.ID, at(-time.Hour))
+		assert.ErrorIs(t, err, domain.ErrSnoozeInPast)
+		_, err = uc.SnoozeTask(ctx, 999, at(time.Hour))
+		assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+
+		done := create(t, uc)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, done.ID, domain.StatusCancelled))
+		_, err = uc.SnoozeTask(ctx, done.ID, at(time.Hour))
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+		require.NoError(t, uc.Logout(ctx, "alice"))
+		_, err = uc.Authenticate(ctx, "bob")
+		require.NoError(t, err)
+		_, err = uc.SnoozeTask(ctx, task.ID, at(time.Hour))
+		assert.Error(t, err, "bob does not own the task")
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.SnoozedUntil)
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		_, _, uc, _ := setup(t)
+		task := create(t, uc)
+		handler := handlers.NewTaskHandler(uc)
+		router := mux.NewRouter()
+		router.HandleFunc("/tasks", handler.ListTasks).Methods("GET")
+		router.HandleFunc("/tasks/{id}/snooze", handler.SnoozeTask).Methods("POST")
+
+		snooze := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/tasks/1/snooze", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec
+		}
+		list := func(query string) []handlers.TaskResponse {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks"+query, nil))
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			var tasks []handlers.TaskResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+			return tasks
+		}
+
+		rec := snooze(`{"until": "2030-01-01T08:00:00Z"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "snooze_in_past")
+
+		rec = snooze(`{"until": "2030-01-05T09:00:00Z"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp handlers.TaskResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.NotNil(t, resp.SnoozedUntil)
+		assert.Equal(t, task.ID, resp.ID)
+
+		assert.Empty(t, list(""))
+		listed := list("?include_snoozed=true")
+		require.Len(t, listed, 1)
+		assert.Equal(t, task.ID, listed[0].ID)
+
+		rec = snooze(`{"until": null}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Len(t, list(""), 1)
+	})
+}
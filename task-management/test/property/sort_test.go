@@ -0,0 +1,71 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListTasksSorting verifies each sort order and that ties fall back to ID order
+func TestListTasksSorting(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(48 * time.Hour)
+	specs := []struct {
+		priority domain.Priority
+		due      *time.Time
+	}{
+		{domain.PriorityLow, &later},
+		{domain.PriorityCritical, nil},
+		{domain.PriorityHigh, &soon},
+		{domain.PriorityCritical, &later},
+		{domain.PriorityMedium, nil},
+	}
+	for _, spec := range specs {
+		_, err := uc.CreateTask(ctx, "Task", "Desc", spec.priority, "alice", spec.due, nil, nil)
+		require.NoError(t, err)
+	}
+	require.NoError(t, uc.UpdateTaskStatus(ctx, 5, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, 1, domain.StatusCancelled))
+
+	ids := func(order usecase.TaskSort) []domain.TaskID {
+		tasks, err := uc.ListTasks(ctx, order, usecase.ListFilter{IncludeDone: true})
+		require.NoError(t, err)
+		result := make([]domain.TaskID, len(tasks))
+		for i, task := range tasks {
+			result[i] = task.ID
+		}
+		return result
+	}
+
+	assert.Equal(t, []domain.TaskID{1, 2, 3, 4, 5}, ids(usecase.SortByID))
+	assert.Equal(t, []domain.TaskID{2, 4, 3, 5, 1}, ids(usecase.SortByPriority))
+	assert.Equal(t, []domain.TaskID{3, 1, 4, 2, 5}, ids(usecase.SortByDueDate))
+	assert.Equal(t, []domain.TaskID{1, 2, 3, 4, 5}, ids(usecase.SortByCreatedAt))
+	assert.Equal(t, []domain.TaskID{2, 3, 4, 5, 1}, ids(usecase.SortByStatus))
+
+	// Sorting is deterministic across calls
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []domain.TaskID{2, 4, 3, 5, 1}, ids(usecase.SortByPriority))
+	}
+
+	_, err = uc.ListTasks(ctx, "title", usecase.ListFilter{})
+	assert.ErrorIs(t, err, domain.ErrInvalidSortKey)
+}
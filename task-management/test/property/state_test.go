@@ -0,0 +1,164 @@
+package property
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestStateExportImport verifies an exported state imports back unchanged,
+// leaving out session tokens and keeping the caller's sessions, and that
+// imports violating an invariant are rejected without changes
+func TestStateExportImport(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	handler := handlers.NewTaskHandler(uc)
+	router := mux.NewRouter()
+	router.HandleFunc("/admin/export", handler.ExportState).Methods("GET")
+	router.HandleFunc("/admin/import", handler.ImportState).Methods("POST")
+
+	users := []domain.User{
+		{ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleMember, JoinedAt: time.Now()},
+		{ID: "root", Name: "Root", Email: "root@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now()},
+	}
+	for i := range users {
+		require.NoError(t, repo.CreateUser(ctx, &users[i]))
+	}
+	aliceSession, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	dep, err := uc.CreateTask(ctx, "Dependency", "Desc", domain.PriorityLow, "alice", nil, []domain.Tag{domain.TagBug}, nil)
+	require.NoError(t, err)
+	_, err = uc.CreateTask(ctx, "Task", "Desc", domain.PriorityHigh, "root", nil, nil, []domain.TaskID{dep.ID})
+	require.NoError(t, err)
+
+	export := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/export", nil))
+		return rec
+	}
+	importState := func(body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/import", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusForbidden, export().Code, "members cannot export")
+
+	rootSession, err := uc.Authenticate(ctx, "root")
+	require.NoError(t, err)
+	rec := export()
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	exported := rec.Body.Bytes()
+	var before domain.SystemState
+	require.NoError(t, json.Unmarshal(exported, &before))
+	require.NotEmpty(t, before.Sessions)
+	for userID, session := range before.Sessions {
+		assert.Empty(t, session.Token, "the session of %s is exported without its token", userID)
+	}
+	assert.NotContains(t, string(exported), rootSession.Token)
+
+	// Changes made after the export are undone by importing it
+	_, err = uc.CreateTask(ctx, "Later", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, uc.ReassignTask(ctx, dep.ID, "root"))
+
+	rec = importState(exported)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = export()
+	require.Equal(t, http.StatusOK, rec.Code)
+	var after domain.SystemState
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &after))
+
+	assert.Equal(t, before.NextTaskID, after.NextTaskID)
+	assertSameJSON(t, before.Tasks, after.Tasks)
+	assertSameJSON(t, before.Users, after.Users)
+	require.Len(t, after.UserTasks, len(before.UserTasks))
+	for userID, taskIDs := range before.UserTasks {
+		assert.ElementsMatch(t, taskIDs, after.UserTasks[userID], userID)
+	}
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, invariants.NewInvariantChecker().CheckAllInvariants(state))
+	user, err := repo.GetByEmail(ctx, "alice@example.com")
+	require.NoError(t, err, "the email index is rebuilt")
+	assert.Equal(t, domain.UserID("alice"), user.ID)
+
+	// The caller stays logged in and every session survives, whatever
+	// sessions the imported state names
+	var forged domain.SystemState
+	require.NoError(t, json.Unmarshal(exported, &forged))
+	alice := domain.UserID("alice")
+	forged.CurrentUser = &alice
+	forged.Sessions = map[domain.UserID]*domain.Session{
+		"alice": {UserID: "alice", Token: "forged", Active: true, ExpiresAt: time.Now().Add(time.Hour)},
+	}
+	body, err := json.Marshal(forged)
+	require.NoError(t, err)
+	rec = importState(body)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	currentUser, err := repo.GetCurrentUser(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, currentUser)
+	assert.Equal(t, domain.UserID("root"), *currentUser)
+	for _, token := range []string{aliceSession.Token, rootSession.Token} {
+		_, err := repo.GetSession(ctx, token)
+		assert.NoError(t, err)
+	}
+	_, err = repo.GetSession(ctx, "forged")
+	assert.Error(t, err)
+
+	// A task assigned to an unknown user violates AssigneeExists
+	var invalid domain.SystemState
+	require.NoError(t, json.Unmarshal(exported, &invalid))
+	invalid.Tasks[dep.ID].Assignee = "ghost"
+	body, err = json.Marshal(invalid)
+	require.NoError(t, err)
+	rec = importState(body)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "invariant_violation")
+
+	// So does a task missing from the userTasks index
+	require.NoError(t, json.Unmarshal(exported, &invalid))
+	invalid.UserTasks = nil
+	body, err = json.Marshal(invalid)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, importState(body).Code)
+
+	rec = export()
+	require.Equal(t, http.StatusOK, rec.Code)
+	var unchanged domain.SystemState
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &unchanged))
+	assertSameJSON(t, before.Tasks, unchanged.Tasks)
+
+	assert.Equal(t, http.StatusBadRequest, importState([]byte(`{"tasks": `)).Code)
+}
+
+func assertSameJSON(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
@@ -0,0 +1,116 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestGetStats verifies the headline counts agree with the tasks and
+// sessions they summarize
+func TestGetStats(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+		SessionDuration: time.Hour,
+		Clock:           clock,
+	})
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: start,
+		}))
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		stats, err := uc.GetStats(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, stats.Total)
+		assert.Len(t, stats.ByStatus, 5, "every built-in status is listed")
+		assert.Zero(t, stats.ByStatus[domain.StatusPending])
+		assert.Len(t, stats.ByPriority, 4, "every priority is listed")
+	})
+
+	// Bob's session has expired by the time alice logs in
+	_, err := uc.Authenticate(ctx, "bob")
+	require.NoError(t, err)
+	clock.Advance(2 * time.Hour)
+	_, err = uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(priority domain.Priority, due *time.Time) domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", priority, "alice", due, nil, nil)
+		require.NoError(t, err)
+		return task.ID
+	}
+	past := clock.Now().Add(-time.Hour)
+	future := clock.Now().Add(24 * time.Hour)
+	overdue := create(domain.PriorityHigh, &past)
+	create(domain.PriorityHigh, &future)
+	create(domain.PriorityLow, nil)
+	completed := create(domain.PriorityCritical, &past)
+	archived := create(domain.PriorityMedium, &past)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, overdue, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, completed, domain.StatusInProgress))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, completed, domain.StatusCompleted))
+	require.NoError(t, uc.UpdateTaskStatus(ctx, archived, domain.StatusCancelled))
+	require.NoError(t, uc.DeleteTask(ctx, archived))
+
+	stats, err := uc.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.Total)
+	assert.Equal(t, map[domain.TaskStatus]int{
+		domain.StatusPending:    2,
+		domain.StatusInProgress: 1,
+		domain.StatusBlocked:    0,
+		domain.StatusCompleted:  1,
+		domain.StatusCancelled:  0,
+	}, stats.ByStatus)
+	assert.Equal(t, map[domain.Priority]int{
+		domain.PriorityLow:      1,
+		domain.PriorityMedium:   0,
+		domain.PriorityHigh:     2,
+		domain.PriorityCritical: 1,
+	}, stats.ByPriority)
+	assert.Equal(t, 1, stats.Overdue, "completed and archived tasks are not overdue")
+	assert.Equal(t, 1, stats.Archived)
+	assert.Equal(t, 1, stats.ActiveSessions, "bob's expired session is not counted")
+
+	// The total matches the full listing of unarchived tasks
+	listed, err := uc.ListTasks(ctx, usecase.SortByID, usecase.ListFilter{IncludeDone: true, IncludeSnoozed: true})
+	require.NoError(t, err)
+	assert.Len(t, listed, stats.Total)
+
+	t.Run("Handler", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handlers.NewTaskHandler(uc).GetStats(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var resp handlers.StatsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, handlers.StatsResponse{
+			Total:          stats.Total,
+			ByStatus:       stats.ByStatus,
+			ByPriority:     stats.ByPriority,
+			Overdue:        1,
+			Archived:       1,
+			ActiveSessions: 1,
+		}, resp)
+		assert.Contains(t, rec.Body.String(), `"in_progress":1`)
+	})
+}
@@ -0,0 +1,60 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStatusDurations verifies the time spent in each status is derived from
+// the recorded status history, with the current status running until now
+func TestStatusDurations(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+		SessionDuration: 24 * time.Hour,
+		Clock:           clock,
+	})
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	task, err := uc.CreateTask(ctx, "Title", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	clock.Advance(2 * time.Hour)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+	clock.Advance(30 * time.Minute)
+	require.NoError(t, uc.BulkUpdateStatus(ctx, []domain.TaskID{task.ID}, domain.StatusPending))
+	clock.Advance(time.Hour)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+	clock.Advance(15 * time.Minute)
+
+	durations, err := uc.GetStatusDurations(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[domain.TaskStatus]time.Duration{
+		domain.StatusPending:    3 * time.Hour,
+		domain.StatusInProgress: 45 * time.Minute,
+	}, durations)
+
+	history, err := repo.GetStatusHistory(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 4)
+	assert.Equal(t, start, history[0].At)
+
+	_, err = uc.GetStatusDurations(ctx, 9999)
+	assert.ErrorIs(t, err, domain.ErrTaskNotFound)
+}
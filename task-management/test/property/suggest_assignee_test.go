@@ -0,0 +1,115 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestSuggestAssignee verifies the least loaded user is suggested, counting
+// only open tasks and breaking ties by user ID, and that tasks created without
+// an assignee go to them
+func TestSuggestAssignee(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T, users ...domain.UserID) (*memory.MemoryRepository, *usecase.TaskUseCase) {
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+		for _, id := range users {
+			require.NoError(t, repo.CreateUser(ctx, &domain.User{
+				ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+			}))
+		}
+		return repo, uc
+	}
+	assign := func(t *testing.T, uc *usecase.TaskUseCase, assignee domain.UserID, count int) []*domain.Task {
+		var tasks []*domain.Task
+		for i := 0; i < count; i++ {
+			task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, assignee, nil, nil, nil)
+			require.NoError(t, err)
+			tasks = append(tasks, task)
+		}
+		return tasks
+	}
+	suggest := func(t *testing.T, uc *usecase.TaskUseCase) domain.UserID {
+		assignee, err := uc.SuggestAssignee(ctx)
+		require.NoError(t, err)
+		return assignee
+	}
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		_, uc := setup(t, "alice")
+		_, err := uc.SuggestAssignee(ctx)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	})
+
+	t.Run("UnevenLoad", func(t *testing.T) {
+		_, uc := setup(t, "alice", "bob", "carol")
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		// Nobody has work yet, so the lowest user ID wins
+		assert.Equal(t, domain.UserID("alice"), suggest(t, uc))
+
+		assign(t, uc, "alice", 3)
+		bobs := assign(t, uc, "bob", 2)
+		assign(t, uc, "carol", 1)
+		assert.Equal(t, domain.UserID("carol"), suggest(t, uc))
+
+		// Finished work no longer counts: bob drops to 1 open task and ties
+		// with carol, and the lower ID wins
+		require.NoError(t, uc.Logout(ctx, "alice"))
+		_, err = uc.Authenticate(ctx, "bob")
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, bobs[0].ID, domain.StatusCancelled))
+		assert.Equal(t, domain.UserID("bob"), suggest(t, uc))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, bobs[1].ID, domain.StatusInProgress))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, bobs[1].ID, domain.StatusCompleted))
+		assert.Equal(t, domain.UserID("bob"), suggest(t, uc))
+	})
+
+	t.Run("CreateWithoutAssignee", func(t *testing.T) {
+		repo, uc := setup(t, "alice", "bob", "carol")
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		assign(t, uc, "alice", 1)
+		assign(t, uc, "bob", 2)
+
+		var assignees []domain.UserID
+		for _, task := range assign(t, uc, "", 4) {
+			assignees = append(assignees, task.Assignee)
+		}
+		// carol catches up with alice, then the two take turns before bob
+		assert.Equal(t, []domain.UserID{"carol", "alice", "carol", "alice"}, assignees)
+
+		state, err := repo.GetSystemState(ctx)
+		require.NoError(t, err)
+		assert.NoError(t, invariants.NewInvariantChecker().CheckAllInvariants(state))
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		_, uc := setup(t, "alice", "bob")
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+		assign(t, uc, "alice", 1)
+
+		rec := httptest.NewRecorder()
+		handlers.NewTaskHandler(uc).SuggestAssignee(rec, httptest.NewRequest(http.MethodGet, "/users/suggest-assignee", nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp handlers.SuggestAssigneeResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, domain.UserID("bob"), resp.Assignee)
+	})
+}
@@ -0,0 +1,104 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/handlers"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestFindByTags verifies tasks can be filtered on having all or any of a set
+// of tags, over tasks whose tag sets overlap
+func TestFindByTags(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(tags ...domain.Tag) domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, tags, nil)
+		require.NoError(t, err)
+		return task.ID
+	}
+	bug := create(domain.TagBug)
+	bugFeature := create(domain.TagBug, domain.TagFeature)
+	feature := create(domain.TagFeature)
+	all := create(domain.TagBug, domain.TagFeature, domain.TagDocumentation)
+	docs := create(domain.TagDocumentation)
+	create()
+
+	find := func(t *testing.T, matchAll bool, tags ...domain.Tag) []domain.TaskID {
+		tasks, err := uc.FindByTags(ctx, tags, matchAll)
+		require.NoError(t, err)
+		return taskIDs(tasks)
+	}
+
+	t.Run("All", func(t *testing.T) {
+		assert.Equal(t, []domain.TaskID{bugFeature, all}, find(t, true, domain.TagBug, domain.TagFeature))
+		assert.Equal(t, []domain.TaskID{all}, find(t, true, domain.TagFeature, domain.TagDocumentation))
+		assert.Equal(t, []domain.TaskID{bug, bugFeature, all}, find(t, true, domain.TagBug))
+		assert.Empty(t, find(t, true, domain.TagEnhancement, domain.TagBug))
+	})
+
+	t.Run("Any", func(t *testing.T) {
+		assert.Equal(t, []domain.TaskID{bug, bugFeature, feature, all}, find(t, false, domain.TagBug, domain.TagFeature))
+		assert.Equal(t, []domain.TaskID{bugFeature, feature, all, docs}, find(t, false, domain.TagDocumentation, domain.TagFeature))
+		assert.Empty(t, find(t, false, domain.TagEnhancement))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		_, err := uc.FindByTags(ctx, []domain.Tag{domain.TagBug, "bogus"}, false)
+		assert.ErrorIs(t, err, domain.ErrInvalidTag)
+		_, err = uc.FindByTags(ctx, nil, true)
+		assert.ErrorIs(t, err, domain.ErrInvalidTag)
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		require.NoError(t, uc.UpdateTaskStatus(ctx, bugFeature, domain.StatusCancelled))
+		handler := handlers.NewTaskHandler(uc)
+		list := func(query string) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			handler.ListTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks"+query, nil))
+			return rec
+		}
+		listed := func(t *testing.T, query string) []domain.TaskID {
+			rec := list(query)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			var tasks []handlers.TaskResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+			ids := make([]domain.TaskID, len(tasks))
+			for i, task := range tasks {
+				ids[i] = task.ID
+			}
+			return ids
+		}
+
+		assert.Equal(t, []domain.TaskID{bug, feature, all}, listed(t, "?tags=bug,feature"))
+		assert.Equal(t, []domain.TaskID{bug, feature, all}, listed(t, "?tags=bug,feature&tag_match=any"))
+		assert.Equal(t, []domain.TaskID{all}, listed(t, "?tags=bug,feature&tag_match=all"))
+		assert.Equal(t, []domain.TaskID{bugFeature, all}, listed(t, "?tags=bug,feature&tag_match=all&include_done=true"),
+			"the other list filters still apply")
+
+		rec := list("?tags=bug,bogus")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid_tag")
+		assert.Equal(t, http.StatusBadRequest, list("?tags=bug&tag_match=some").Code)
+	})
+}
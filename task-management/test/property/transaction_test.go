@@ -0,0 +1,384 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/api/http/middleware"
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingChecker wraps the real checker and can be switched to reject every state
+type failingChecker struct {
+	*invariants.InvariantChecker
+	fail bool
+}
+
+func (c *failingChecker) CheckAllInvariants(state *domain.SystemState) error {
+	if c.fail {
+		return fmt.Errorf("forced invariant failure")
+	}
+	return c.InvariantChecker.CheckAllInvariants(state)
+}
+
+// TestRollbackOnInvariantViolation verifies failed operations leave no trace in the repository
+func TestRollbackOnInvariantViolation(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := &failingChecker{InvariantChecker: invariants.NewInvariantChecker()}
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	publisher := &recordingPublisher{}
+	uc.SetEventPublisher(publisher)
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	task, err := uc.CreateTask(ctx, "Kept", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	checker.fail = true
+
+	t.Run("CreateTaskNotPersisted", func(t *testing.T) {
+		_, err := uc.CreateTask(ctx, "Dropped", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		assert.Error(t, err)
+
+		tasks, _ := repo.GetAllTasks(ctx)
+		assert.Len(t, tasks, 1)
+		nextID, _ := repo.GetNextTaskID(ctx)
+		assert.Equal(t, task.ID+1, nextID)
+		userTasks, _ := repo.GetUserTasks(ctx, "alice")
+		assert.Equal(t, []domain.TaskID{task.ID}, userTasks)
+	})
+
+	t.Run("StatusUpdateNotPersisted", func(t *testing.T) {
+		assert.Error(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusPending, stored.Status)
+	})
+
+	t.Run("PriorityUpdateNotPersisted", func(t *testing.T) {
+		err := uc.UpdateTaskPriority(ctx, task.ID, domain.PriorityHigh)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PriorityLow, stored.Priority)
+	})
+
+	t.Run("ReassignmentNotPersisted", func(t *testing.T) {
+		err := uc.ReassignTask(ctx, task.ID, "bob")
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserID("alice"), stored.Assignee)
+		userTasks, _ := repo.GetUserTasks(ctx, "alice")
+		assert.Equal(t, []domain.TaskID{task.ID}, userTasks)
+		bobTasks, _ := repo.GetUserTasks(ctx, "bob")
+		assert.Empty(t, bobTasks)
+		assert.Empty(t, publisher.ofType(domain.EventTaskReassigned), "no event for a rolled back reassignment")
+	})
+
+	t.Run("DetailsUpdateNotPersisted", func(t *testing.T) {
+		err := uc.UpdateTaskDetails(ctx, task.ID, "Changed", "Changed", nil)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Kept", stored.Title)
+		assert.Equal(t, "Desc", stored.Description)
+	})
+
+	t.Run("PatchNotPersisted", func(t *testing.T) {
+		title, hours := "Patched", 3.0
+		_, err := uc.PatchTask(ctx, task.ID, usecase.TaskPatch{Title: &title, EstimatedHours: &hours})
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Kept", stored.Title)
+		assert.Zero(t, stored.EstimatedHours)
+	})
+
+	t.Run("AppliedOnceInvariantsHold", func(t *testing.T) {
+		checker.fail = false
+		defer func() { checker.fail = true }()
+
+		require.NoError(t, uc.UpdateTaskPriority(ctx, task.ID, domain.PriorityHigh))
+		require.NoError(t, uc.UpdateTaskDetails(ctx, task.ID, "Changed", "Changed", nil))
+		hours := 3.0
+		_, err := uc.PatchTask(ctx, task.ID, usecase.TaskPatch{EstimatedHours: &hours})
+		require.NoError(t, err)
+		require.NoError(t, uc.ReassignTask(ctx, task.ID, "bob"))
+
+		stored, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PriorityHigh, stored.Priority)
+		assert.Equal(t, "Changed", stored.Title)
+		assert.Equal(t, 3.0, stored.EstimatedHours)
+		assert.Equal(t, domain.UserID("bob"), stored.Assignee)
+	})
+}
+
+// TestArchiveRollbackOnInvariantViolation verifies archiving, restoring,
+// purging and sweeping tasks are rolled back when the invariants fail afterwards
+func TestArchiveRollbackOnInvariantViolation(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := &failingChecker{InvariantChecker: invariants.NewInvariantChecker()}
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", Role: domain.RoleAdmin, JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	cancelled := func() domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCancelled))
+		return task.ID
+	}
+	live, archived := cancelled(), cancelled()
+	require.NoError(t, uc.DeleteTask(ctx, archived))
+
+	checker.fail = true
+
+	t.Run("DeleteNotPersisted", func(t *testing.T) {
+		err := uc.DeleteTask(ctx, live)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+
+		stored, err := repo.GetTask(ctx, live)
+		require.NoError(t, err)
+		assert.False(t, stored.Archived)
+		userTasks, _ := repo.GetUserTasks(ctx, "alice")
+		assert.Equal(t, []domain.TaskID{live}, userTasks)
+	})
+
+	t.Run("RestoreNotPersisted", func(t *testing.T) {
+		_, err := uc.RestoreTask(ctx, archived)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+
+		stored, err := repo.GetTask(ctx, archived)
+		require.NoError(t, err)
+		assert.True(t, stored.Archived)
+	})
+
+	t.Run("PurgeNotPersisted", func(t *testing.T) {
+		err := uc.PurgeTask(ctx, archived)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+
+		_, err = repo.GetTask(ctx, archived)
+		assert.NoError(t, err)
+	})
+
+	t.Run("SweepNotPersisted", func(t *testing.T) {
+		_, err := uc.SweepOldTasks(ctx, 0)
+		assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+
+		tasks, err := repo.GetAllTasks(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+	})
+
+	t.Run("AppliedOnceInvariantsHold", func(t *testing.T) {
+		checker.fail = false
+
+		require.NoError(t, uc.DeleteTask(ctx, live))
+		_, err := uc.RestoreTask(ctx, archived)
+		require.NoError(t, err)
+
+		stored, err := repo.GetTask(ctx, live)
+		require.NoError(t, err)
+		assert.True(t, stored.Archived)
+		stored, err = repo.GetTask(ctx, archived)
+		require.NoError(t, err)
+		assert.False(t, stored.Archived)
+	})
+}
+
+// TestUnitOfWorkScopeRollback verifies rolling a scope back discards every
+// change committed in it, as the fail-closed invariant middleware relies on
+func TestUnitOfWorkScopeRollback(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	kept, err := uc.CreateTask(ctx, "Kept", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	scope := uow.BeginScope(ctx)
+	_, err = uc.CreateTask(scope, "Discarded", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, uc.UpdateTaskStatus(scope, kept.ID, domain.StatusInProgress))
+	uow.EndScope(scope, true)
+
+	tasks, err := repo.GetAllTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, domain.StatusPending, tasks[kept.ID].Status)
+
+	nextID, err := repo.GetNextTaskID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, kept.ID+1, nextID)
+
+	state, err := repo.GetSystemState(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, invariants.NewInvariantChecker().CheckAllInvariants(state))
+
+	// A scope that is not rolled back keeps its changes
+	scope = uow.BeginScope(ctx)
+	require.NoError(t, uc.UpdateTaskStatus(scope, kept.ID, domain.StatusInProgress))
+	uow.EndScope(scope, false)
+	stored, err := repo.GetTask(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusInProgress, stored.Status)
+}
+
+// TestFailClosedRollbackKeepsSweep verifies a retention sweep started while a
+// fail-closed request is running waits for it, so rolling the request back
+// does not discard the sweep
+func TestFailClosedRollbackKeepsSweep(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantChecker()
+	uc := usecase.NewTaskUseCase(uow, checker)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+	old, err := uc.CreateTask(ctx, "Old", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, old.ID, domain.StatusCancelled))
+	kept, err := uc.CreateTask(ctx, "Kept", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	// The request renames a task through the use case, then assigns it to an
+	// unknown user, violating AssigneeExists, and holds on until the sweep
+	// has been started
+	guard := middleware.NewInvariantGuard(uow, checker, middleware.InvariantModeFailClosed)
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := guard.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, uc.UpdateTaskDetails(r.Context(), kept.ID, "Renamed", "Desc", nil))
+		task, err := repo.GetTask(ctx, kept.ID)
+		require.NoError(t, err)
+		task.Assignee = "ghost"
+		require.NoError(t, repo.UpdateTask(ctx, task))
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/tasks/2", nil))
+	}()
+	<-started
+
+	// Every completed or cancelled task is old enough for a zero retention
+	swept := make(chan int, 1)
+	go func() {
+		purged, err := uc.SweepOldTasks(ctx, 0)
+		assert.NoError(t, err)
+		swept <- purged
+	}()
+	select {
+	case <-swept:
+		t.Fatal("the sweep ran during the fail-closed request")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-served
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invariant_violation")
+	assert.Equal(t, 1, <-swept)
+
+	tasks, err := repo.GetAllTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1, "the sweep survives the rollback")
+	assert.Equal(t, domain.UserID("alice"), tasks[kept.ID].Assignee, "the request is rolled back")
+	assert.Equal(t, "Kept", tasks[kept.ID].Title)
+}
+
+// TestCheckDependenciesRollbackOnInvariantViolation verifies a dependency check
+// whose result violates the invariants unblocks nothing and publishes nothing
+func TestCheckDependenciesRollbackOnInvariantViolation(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := &failingChecker{InvariantChecker: invariants.NewInvariantChecker()}
+	uc := usecase.NewTaskUseCase(uow, checker)
+	publisher := &recordingPublisher{}
+	uc.SetEventPublisher(publisher)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	// Two blocked tasks with nothing left to wait on
+	var ready []domain.TaskID
+	for i := 0; i < 2; i++ {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+		require.NoError(t, err)
+		task.Status = domain.StatusBlocked
+		require.NoError(t, repo.UpdateTask(ctx, task))
+		ready = append(ready, task.ID)
+	}
+	status := func(id domain.TaskID) domain.TaskStatus {
+		task, err := repo.GetTask(ctx, id)
+		require.NoError(t, err)
+		return task.Status
+	}
+
+	checker.fail = true
+	_, err = uc.CheckDependenciesReport(ctx)
+	assert.ErrorIs(t, err, domain.ErrInvariantViolation)
+	for _, id := range ready {
+		assert.Equal(t, domain.StatusBlocked, status(id))
+	}
+	assert.Empty(t, publisher.ofType(domain.EventTaskStatusChanged))
+	assert.Empty(t, publisher.ofType(domain.EventTaskUnblocked))
+
+	checker.fail = false
+	report, err := uc.CheckDependenciesReport(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ready, report.Unblocked)
+	for _, id := range ready {
+		assert.Equal(t, domain.StatusPending, status(id))
+	}
+	assert.Len(t, publisher.ofType(domain.EventTaskStatusChanged), 2)
+	assert.Len(t, publisher.ofType(domain.EventTaskUnblocked), 2)
+}
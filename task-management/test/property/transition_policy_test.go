@@ -0,0 +1,58 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCustomTransitionPolicy verifies a workflow requiring review before completion
+func TestCustomTransitionPolicy(t *testing.T) {
+	ctx := context.Background()
+	const statusReview domain.TaskStatus = "review"
+
+	policy := domain.NewTransitionPolicy(
+		domain.ValidTransition{From: domain.StatusPending, To: domain.StatusInProgress},
+		domain.ValidTransition{From: domain.StatusInProgress, To: statusReview},
+		domain.ValidTransition{From: statusReview, To: domain.StatusInProgress},
+		domain.ValidTransition{From: statusReview, To: domain.StatusCompleted},
+		domain.ValidTransition{From: domain.StatusPending, To: domain.StatusCancelled},
+	)
+
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	checker := invariants.NewInvariantCheckerWithConfig(invariants.Config{TransitionPolicy: policy})
+	uc := usecase.NewTaskUseCaseWithConfig(uow, checker, usecase.Config{TransitionPolicy: policy})
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "alice", Email: "alice@example.com", JoinedAt: time.Now(),
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+	assert.Error(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCompleted), "completion requires review")
+
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, statusReview))
+	require.NoError(t, uc.UpdateTaskDetails(ctx, task.ID, "Task", "Reviewed", nil))
+
+	state, _ := repo.GetSystemState(ctx)
+	assert.NoError(t, checker.CheckAllInvariants(state))
+
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCompleted))
+
+	// The default policy is unchanged and does not know the review state
+	assert.True(t, domain.IsValidTransition(domain.StatusInProgress, domain.StatusCompleted))
+	assert.Error(t, invariants.NewInvariantChecker().CheckTransitionInvariant(domain.StatusInProgress, statusReview))
+	assert.NoError(t, checker.CheckTransitionInvariant(domain.StatusInProgress, statusReview))
+}
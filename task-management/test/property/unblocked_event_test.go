@@ -0,0 +1,107 @@
+package property
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestTaskUnblockedEvent verifies exactly one task.unblocked event is
+// published for each task that becomes ready, naming the dependency that
+// made it ready, whether CheckDependencies or a removed dependency unblocks it
+func TestTaskUnblockedEvent(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := domain.NewFakeClock(start)
+	repo := memory.NewMemoryRepositoryWithClock(clock)
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCaseWithConfig(uow, invariants.NewInvariantChecker(), usecase.Config{
+		SessionDuration: 24 * time.Hour,
+		Clock:           clock,
+	})
+	publisher := &recordingPublisher{}
+	uc.SetEventPublisher(publisher)
+
+	require.NoError(t, repo.CreateUser(ctx, &domain.User{
+		ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: start,
+	}))
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	create := func(deps ...domain.TaskID) domain.TaskID {
+		task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, deps)
+		require.NoError(t, err)
+		return task.ID
+	}
+	complete := func(id domain.TaskID) {
+		clock.Advance(time.Minute)
+		require.NoError(t, uc.UpdateTaskStatus(ctx, id, domain.StatusInProgress))
+		require.NoError(t, uc.UpdateTaskStatus(ctx, id, domain.StatusCompleted))
+	}
+	unblocked := func() map[domain.TaskID][]string {
+		events := make(map[domain.TaskID][]string)
+		for _, event := range publisher.ofType(domain.EventTaskUnblocked) {
+			events[event.TaskID] = append(events[event.TaskID], event.Data["dependency"])
+		}
+		return events
+	}
+	id := func(id domain.TaskID) string { return strconv.Itoa(int(id)) }
+
+	a, b, c, g := create(), create(), create(), create()
+	bothDone := create(a, b)
+	oneDone := create(c)
+	stillWaiting := create(a, g)
+
+	// a completes before b, so b is what readies bothDone
+	complete(a)
+	complete(b)
+	complete(c)
+	assert.Empty(t, unblocked(), "completing a dependency alone does not unblock")
+
+	report, err := uc.CheckDependenciesReport(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TaskID{bothDone, oneDone}, report.Unblocked)
+	assert.Equal(t, map[domain.TaskID][]string{
+		bothDone: {id(b)},
+		oneDone:  {id(c)},
+	}, unblocked())
+
+	// Nothing is left to unblock, so nothing more is published
+	count, err := uc.CheckDependencies(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Len(t, unblocked(), 2)
+
+	t.Run("RemovedDependency", func(t *testing.T) {
+		task, err := uc.RemoveDependency(ctx, stillWaiting, g)
+		require.NoError(t, err)
+		require.Equal(t, domain.StatusPending, task.Status)
+
+		assert.Equal(t, map[domain.TaskID][]string{
+			bothDone:     {id(b)},
+			oneDone:      {id(c)},
+			stillWaiting: {id(g)},
+		}, unblocked())
+
+		events := publisher.ofType(domain.EventTaskUnblocked)
+		last := events[len(events)-1]
+		assert.Equal(t, domain.UserID("alice"), last.Actor)
+		assert.Equal(t, domain.UserID("alice"), last.Assignee)
+	})
+
+	t.Run("Logged", func(t *testing.T) {
+		activities, total, err := uc.GetActivity(ctx, domain.ActivityFilter{Action: domain.EventTaskUnblocked}, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Len(t, activities, 3)
+	})
+}
@@ -0,0 +1,88 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+)
+
+// TestVocabulary verifies a configured vocabulary replaces the allowed
+// priorities and tags, and that their order drives ranking and escalation
+func TestVocabulary(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, domain.SetVocabulary(domain.DefaultVocabulary()))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		assert.Equal(t, 0, domain.PriorityLow.Rank())
+		assert.Equal(t, 3, domain.PriorityCritical.Rank())
+		assert.Equal(t, -1, domain.Priority("p0").Rank())
+		assert.Equal(t, domain.PriorityMedium, domain.PriorityLow.Escalated())
+		assert.Equal(t, domain.PriorityCritical, domain.PriorityCritical.Escalated())
+		assert.True(t, domain.PriorityCritical.IsHighest())
+		assert.False(t, domain.PriorityHigh.IsHighest())
+	})
+
+	t.Run("invalid vocabularies are rejected", func(t *testing.T) {
+		assert.ErrorIs(t, domain.SetVocabulary(domain.Vocabulary{}), domain.ErrInvalidPriority)
+		assert.ErrorIs(t, domain.SetVocabulary(domain.Vocabulary{
+			Priorities: []domain.Priority{"p1", "p1"},
+		}), domain.ErrInvalidPriority)
+		assert.ErrorIs(t, domain.SetVocabulary(domain.Vocabulary{
+			Priorities: []domain.Priority{"p1"},
+			Tags:       []domain.Tag{"chore", ""},
+		}), domain.ErrInvalidTag)
+		assert.Equal(t, domain.DefaultVocabulary(), domain.CurrentVocabulary(), "the vocabulary is unchanged")
+	})
+
+	t.Run("custom vocabulary", func(t *testing.T) {
+		custom := domain.Vocabulary{
+			Priorities: []domain.Priority{"p3", "p2", "p1", "p0"},
+			Tags:       []domain.Tag{domain.TagBug, "chore"},
+		}
+		require.NoError(t, domain.SetVocabulary(custom))
+		custom.Priorities[0] = "changed"
+		assert.Equal(t, domain.Priority("p3"), domain.CurrentVocabulary().Priorities[0], "the registry keeps its own copy")
+
+		assert.Equal(t, 0, domain.Priority("p3").Rank())
+		assert.Equal(t, 3, domain.Priority("p0").Rank())
+		assert.Equal(t, -1, domain.PriorityLow.Rank())
+		assert.Equal(t, domain.Priority("p1"), domain.Priority("p2").Escalated())
+		assert.True(t, domain.Priority("p0").IsHighest())
+		assert.False(t, domain.PriorityCritical.IsHighest())
+
+		ctx := context.Background()
+		repo := memory.NewMemoryRepository()
+		uow := memory.NewMemoryUnitOfWork(repo)
+		uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: "alice", Name: "Alice", Email: "alice@example.com", JoinedAt: time.Now(),
+		}))
+		_, err := uc.Authenticate(ctx, "alice")
+		require.NoError(t, err)
+
+		task, err := uc.CreateTask(ctx, "Chore", "Desc", "p2", "alice", nil, []domain.Tag{"chore"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Priority("p2"), task.Priority)
+
+		_, err = uc.CreateTask(ctx, "Old", "Desc", domain.PriorityHigh, "alice", nil, nil, nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidPriority)
+		_, err = uc.CreateTask(ctx, "Old", "Desc", "p2", "alice", nil, []domain.Tag{domain.TagFeature}, nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidTag)
+
+		urgent, err := uc.CreateTask(ctx, "Urgent", "Desc", "p0", "alice", nil, nil, nil)
+		require.NoError(t, err)
+		tasks, err := uc.ListTasks(ctx, "priority", usecase.ListFilter{})
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		assert.Equal(t, urgent.ID, tasks[0].ID, "the last priority sorts first")
+	})
+}
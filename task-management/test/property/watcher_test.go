@@ -0,0 +1,73 @@
+package property
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhatti/sample-task-management/internal/domain"
+	"github.com/bhatti/sample-task-management/internal/infrastructure/memory"
+	"github.com/bhatti/sample-task-management/internal/usecase"
+	"github.com/bhatti/sample-task-management/pkg/invariants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	events []domain.Event
+}
+
+func (p *recordingPublisher) Publish(event domain.Event) {
+	p.events = append(p.events, event)
+}
+
+func (p *recordingPublisher) ofType(eventType domain.EventType) []domain.Event {
+	var matching []domain.Event
+	for _, event := range p.events {
+		if event.Type == eventType {
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+// TestWatchersNotifiedOfStatusChanges verifies watchers receive status change events
+func TestWatchersNotifiedOfStatusChanges(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryRepository()
+	uow := memory.NewMemoryUnitOfWork(repo)
+	uc := usecase.NewTaskUseCase(uow, invariants.NewInvariantChecker())
+	publisher := &recordingPublisher{}
+	uc.SetEventPublisher(publisher)
+
+	for _, id := range []domain.UserID{"alice", "bob"} {
+		require.NoError(t, repo.CreateUser(ctx, &domain.User{
+			ID: id, Name: string(id), Email: string(id) + "@example.com", JoinedAt: time.Now(),
+		}))
+	}
+	_, err := uc.Authenticate(ctx, "alice")
+	require.NoError(t, err)
+
+	task, err := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityLow, "alice", nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = uc.AddWatcher(ctx, task.ID, "unknown")
+	assert.Error(t, err)
+
+	watched, err := uc.AddWatcher(ctx, task.ID, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UserID{"bob"}, watched.WatcherIDs())
+
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusInProgress))
+	changes := publisher.ofType(domain.EventTaskStatusChanged)
+	require.Len(t, changes, 1)
+	assert.Equal(t, []domain.UserID{"bob"}, changes[0].Recipients)
+	assert.Equal(t, "in_progress", changes[0].Data["to"])
+
+	_, err = uc.RemoveWatcher(ctx, task.ID, "bob")
+	require.NoError(t, err)
+	require.NoError(t, uc.UpdateTaskStatus(ctx, task.ID, domain.StatusCompleted))
+	changes = publisher.ofType(domain.EventTaskStatusChanged)
+	require.Len(t, changes, 2)
+	assert.Empty(t, changes[1].Recipients, "unwatched tasks notify no one")
+}
@@ -2,6 +2,7 @@
 package refinement
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -43,10 +44,11 @@ type TLATask struct {
 
 // TestRefinementMapping verifies the mapping between Go and TLA+ states
 func TestRefinementMapping(t *testing.T) {
+	ctx := context.Background()
 	t.Run("InitialStateRefinement", func(t *testing.T) {
 		// Go initial state
 		goRepo := memory.NewMemoryRepository()
-		goState, err := goRepo.GetSystemState()
+		goState, err := goRepo.GetSystemState(ctx)
 		require.NoError(t, err)
 
 		// TLA+ initial state
@@ -72,11 +74,12 @@ func TestRefinementMapping(t *testing.T) {
 		uc := usecase.NewTaskUseCase(uow, checker)
 
 		// Authenticate
-		_, err := uc.Authenticate("alice")
+		_, err := uc.Authenticate(ctx, "alice")
 		require.NoError(t, err)
 
 		// Create task in Go
 		goTask, err := uc.CreateTask(
+			ctx,
 			"Test Task",
 			"Description",
 			domain.PriorityHigh,
@@ -110,6 +113,7 @@ func TestRefinementMapping(t *testing.T) {
 
 // TestActionRefinement verifies Go actions refine TLA+ actions
 func TestActionRefinement(t *testing.T) {
+	ctx := context.Background()
 	t.Run("AuthenticateRefinement", func(t *testing.T) {
 		goRepo := memory.NewMemoryRepository()
 		setupTestUsers(t, goRepo)
@@ -137,7 +141,7 @@ func TestActionRefinement(t *testing.T) {
 				name:   "Already authenticated",
 				userID: "alice",
 				setupFunc: func() {
-					uc.Authenticate("alice")
+					uc.Authenticate(ctx, "alice")
 				},
 				shouldSucceed: false,
 				tlaCondition:  "~sessions[user] violated",
@@ -161,14 +165,14 @@ func TestActionRefinement(t *testing.T) {
 
 				tc.setupFunc()
 
-				session, err := uc.Authenticate(tc.userID)
+				session, err := uc.Authenticate(ctx, tc.userID)
 
 				if tc.shouldSucceed {
 					assert.NoError(t, err)
 					assert.NotNil(t, session)
 
 					// Verify postconditions match TLA+
-					currentUser, _ := goRepo.GetCurrentUser()
+					currentUser, _ := goRepo.GetCurrentUser(ctx)
 					assert.NotNil(t, currentUser)
 					assert.Equal(t, tc.userID, *currentUser)
 				} else {
@@ -201,7 +205,7 @@ func TestActionRefinement(t *testing.T) {
 			{
 				name: "Valid task creation",
 				setupFunc: func() {
-					uc.Authenticate("alice")
+					uc.Authenticate(ctx, "alice")
 				},
 				title:         "Task1",
 				priority:      domain.PriorityMedium,
@@ -223,7 +227,7 @@ func TestActionRefinement(t *testing.T) {
 			{
 				name: "Invalid dependency",
 				setupFunc: func() {
-					uc.Authenticate("alice")
+					uc.Authenticate(ctx, "alice")
 				},
 				title:         "Task1",
 				priority:      domain.PriorityMedium,
@@ -245,6 +249,7 @@ func TestActionRefinement(t *testing.T) {
 				tc.setupFunc()
 
 				task, err := uc.CreateTask(
+					ctx,
 					tc.title,
 					"Description",
 					tc.priority,
@@ -259,7 +264,7 @@ func TestActionRefinement(t *testing.T) {
 					assert.NotNil(t, task)
 
 					// Verify postconditions
-					state, _ := goRepo.GetSystemState()
+					state, _ := goRepo.GetSystemState(ctx)
 					assert.Contains(t, state.Tasks, task.ID)
 					assert.Contains(t, state.GetUserTasks(tc.assignee), task.ID)
 				} else {
@@ -279,8 +284,9 @@ func TestActionRefinement(t *testing.T) {
 		uc := usecase.NewTaskUseCase(uow, checker)
 
 		// Setup: Create a task
-		uc.Authenticate("alice")
+		uc.Authenticate(ctx, "alice")
 		task, _ := uc.CreateTask(
+			ctx,
 			"Test Task",
 			"Description",
 			domain.PriorityMedium,
@@ -302,13 +308,13 @@ func TestActionRefinement(t *testing.T) {
 		for _, trans := range validTransitions {
 			// Set initial status
 			task.Status = trans.from
-			goRepo.UpdateTask(task)
+			goRepo.UpdateTask(ctx, task)
 
-			err := uc.UpdateTaskStatus(task.ID, trans.to)
+			err := uc.UpdateTaskStatus(ctx, task.ID, trans.to)
 			assert.NoError(t, err, "Valid transition %s -> %s should succeed", trans.from, trans.to)
 
 			// Verify status changed
-			updatedTask, _ := goRepo.GetTask(task.ID)
+			updatedTask, _ := goRepo.GetTask(ctx, task.ID)
 			assert.Equal(t, trans.to, updatedTask.Status)
 		}
 
@@ -324,9 +330,9 @@ func TestActionRefinement(t *testing.T) {
 		for _, trans := range invalidTransitions {
 			// Set initial status
 			task.Status = trans.from
-			goRepo.UpdateTask(task)
+			goRepo.UpdateTask(ctx, task)
 
-			err := uc.UpdateTaskStatus(task.ID, trans.to)
+			err := uc.UpdateTaskStatus(ctx, task.ID, trans.to)
 			assert.Error(t, err, "Invalid transition %s -> %s should fail", trans.from, trans.to)
 		}
 	})
@@ -334,13 +340,14 @@ func TestActionRefinement(t *testing.T) {
 
 // TestInvariantRefinement verifies Go invariants refine TLA+ invariants
 func TestInvariantRefinement(t *testing.T) {
+	ctx := context.Background()
 	goRepo := memory.NewMemoryRepository()
 	setupTestUsers(t, goRepo)
 
 	checker := invariants.NewInvariantChecker()
 
 	t.Run("EmptyStateInvariants", func(t *testing.T) {
-		state, err := goRepo.GetSystemState()
+		state, err := goRepo.GetSystemState(ctx)
 		require.NoError(t, err)
 
 		// All invariants should hold for empty state
@@ -358,21 +365,22 @@ func TestInvariantRefinement(t *testing.T) {
 		// Perform a sequence of operations
 		operations := []func() error{
 			func() error {
-				_, err := uc.Authenticate("alice")
+				_, err := uc.Authenticate(ctx, "alice")
 				return err
 			},
 			func() error {
 				_, err := uc.CreateTask(
+					ctx,
 					"Task1", "Desc1", domain.PriorityHigh,
 					"alice", nil, []domain.Tag{domain.TagFeature}, []domain.TaskID{},
 				)
 				return err
 			},
 			func() error {
-				return uc.UpdateTaskStatus(1, domain.StatusInProgress)
+				return uc.UpdateTaskStatus(ctx, 1, domain.StatusInProgress)
 			},
 			func() error {
-				return uc.UpdateTaskStatus(1, domain.StatusCompleted)
+				return uc.UpdateTaskStatus(ctx, 1, domain.StatusCompleted)
 			},
 		}
 
@@ -381,7 +389,7 @@ func TestInvariantRefinement(t *testing.T) {
 			require.NoError(t, err, "Operation %d failed", i)
 
 			// Check invariants after each operation
-			state, _ := goRepo.GetSystemState()
+			state, _ := goRepo.GetSystemState(ctx)
 			err = checker.CheckAllInvariants(state)
 			assert.NoError(t, err, "Invariants violated after operation %d", i)
 
@@ -393,6 +401,7 @@ func TestInvariantRefinement(t *testing.T) {
 
 // TestPropertyRefinement verifies liveness and safety properties
 func TestPropertyRefinement(t *testing.T) {
+	ctx := context.Background()
 	t.Run("NoCyclicDependencies", func(t *testing.T) {
 		goRepo := memory.NewMemoryRepository()
 		setupTestUsers(t, goRepo)
@@ -401,19 +410,19 @@ func TestPropertyRefinement(t *testing.T) {
 		checker := invariants.NewInvariantChecker()
 		uc := usecase.NewTaskUseCase(uow, checker)
 
-		uc.Authenticate("alice")
+		uc.Authenticate(ctx, "alice")
 
 		// Create tasks
-		task1, _ := uc.CreateTask("T1", "D1", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{})
-		task2, _ := uc.CreateTask("T2", "D2", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{task1.ID})
-		task3, _ := uc.CreateTask("T3", "D3", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{task2.ID})
+		task1, _ := uc.CreateTask(ctx, "T1", "D1", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{})
+		task2, _ := uc.CreateTask(ctx, "T2", "D2", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{task1.ID})
+		task3, _ := uc.CreateTask(ctx, "T3", "D3", domain.PriorityLow, "alice", nil, nil, []domain.TaskID{task2.ID})
 
 		// Attempt to create cycle - should fail
-		_, err := uc.CreateTask("T4", "D4", domain.PriorityLow, "alice", nil, nil,
+		_, err := uc.CreateTask(ctx, "T4", "D4", domain.PriorityLow, "alice", nil, nil,
 			[]domain.TaskID{task3.ID, task1.ID})
 
 		// Either it fails explicitly or invariants catch it
-		state, _ := goRepo.GetSystemState()
+		state, _ := goRepo.GetSystemState(ctx)
 		invErr := checker.CheckAllInvariants(state)
 
 		assert.True(t, err != nil || invErr == nil, "Cyclic dependency should be prevented")
@@ -430,21 +439,21 @@ func TestPropertyRefinement(t *testing.T) {
 		checker := invariants.NewInvariantChecker()
 		uc := usecase.NewTaskUseCase(uow, checker)
 
-		uc.Authenticate("alice")
+		uc.Authenticate(ctx, "alice")
 
 		// Create and reassign task
-		task, _ := uc.CreateTask("Task", "Desc", domain.PriorityMedium, "alice", nil, nil, nil)
+		task, _ := uc.CreateTask(ctx, "Task", "Desc", domain.PriorityMedium, "alice", nil, nil, nil)
 
 		// Check initial ownership
-		state, _ := goRepo.GetSystemState()
+		state, _ := goRepo.GetSystemState(ctx)
 		assert.Contains(t, state.GetUserTasks("alice"), task.ID)
 
 		// Reassign to bob
-		err := uc.ReassignTask(task.ID, "bob")
+		err := uc.ReassignTask(ctx, task.ID, "bob")
 		require.NoError(t, err)
 
 		// Check ownership transferred
-		state, _ = goRepo.GetSystemState()
+		state, _ = goRepo.GetSystemState(ctx)
 		assert.NotContains(t, state.GetUserTasks("alice"), task.ID)
 		assert.Contains(t, state.GetUserTasks("bob"), task.ID)
 
@@ -455,6 +464,7 @@ func TestPropertyRefinement(t *testing.T) {
 
 // TestSimulationRelation verifies simulation between Go and TLA+ traces
 func TestSimulationRelation(t *testing.T) {
+	ctx := context.Background()
 	t.Run("TraceEquivalence", func(t *testing.T) {
 		// Generate random operation sequence
 		rand.Seed(time.Now().UnixNano())
@@ -481,7 +491,7 @@ func TestSimulationRelation(t *testing.T) {
 			tlaTrace = append(tlaTrace, tlaResult)
 
 			// States should remain equivalent
-			state, _ := goRepo.GetSystemState()
+			state, _ := goRepo.GetSystemState(ctx)
 			assert.True(t, checkStateEquivalence(state, op))
 		}
 
@@ -493,6 +503,7 @@ func TestSimulationRelation(t *testing.T) {
 // Helper functions
 
 func setupTestUsers(t *testing.T, repo *memory.MemoryRepository) {
+	ctx := context.Background()
 	users := []domain.User{
 		{ID: "alice", Name: "Alice", Email: "alice@test.com", JoinedAt: time.Now()},
 		{ID: "bob", Name: "Bob", Email: "bob@test.com", JoinedAt: time.Now()},
@@ -500,7 +511,7 @@ func setupTestUsers(t *testing.T, repo *memory.MemoryRepository) {
 	}
 
 	for _, user := range users {
-		err := repo.CreateUser(&user)
+		err := repo.CreateUser(ctx, &user)
 		require.NoError(t, err)
 	}
 }
@@ -680,10 +691,11 @@ func generateRandomOperations(count int) []Operation {
 }
 
 func executeGoOperation(uc *usecase.TaskUseCase, op Operation) string {
+	ctx := context.Background()
 	switch op.Type {
 	case "Authenticate":
 		userID := op.Params["user"].(string)
-		_, err := uc.Authenticate(domain.UserID(userID))
+		_, err := uc.Authenticate(ctx, domain.UserID(userID))
 		if err != nil {
 			return fmt.Sprintf("Authenticate(%s) -> ERROR", userID)
 		}
@@ -695,6 +707,7 @@ func executeGoOperation(uc *usecase.TaskUseCase, op Operation) string {
 		assignee := op.Params["assignee"].(string)
 
 		_, err := uc.CreateTask(
+			ctx,
 			title, "Description",
 			domain.Priority(priority),
 			domain.UserID(assignee),